@@ -0,0 +1,74 @@
+package async
+
+import "time"
+
+// Batch groups the items of a channel into slices, for bulk operations like batched writes. A batch is emitted when it
+// reaches the size, or when the max wait has elapsed since its first item was received, whatever comes first; the
+// remaining items are emitted as a last, smaller batch when the input channel is closed.
+//
+// # Type parameters:
+//   - T: the type of items in the input channel
+//
+// # Parameters:
+//   - input: a receive-only channel from which items of type T are read
+//   - size: the max number of items in a batch; values below 1 disable batching, emitting each item alone
+//   - maxWait: the max time an item waits for its batch to fill up; values <= 0 wait until the batch is full
+//
+// # Returns:
+//   - a receive-only channel that emits the batches, never empty. The channel is automatically closed after the input
+//     channel is closed and the last batch is emitted.
+//
+// # Example:
+//
+//	for batch := range Batch(events, 100, time.Second) {
+//		db.InsertMany(batch)
+//	}
+func Batch[T any](input <-chan T, size int, maxWait time.Duration) <-chan []T {
+	output := make(chan []T)
+	size = max(size, 1)
+
+	go func() {
+		defer close(output)
+
+		var batch []T
+		var timer *time.Timer
+		var timeout <-chan time.Time
+
+		flush := func() {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+
+			if len(batch) > 0 {
+				output <- batch
+				batch = nil
+			}
+		}
+
+		for {
+			select {
+			case item, ok := <-input:
+				if !ok {
+					flush()
+					return
+				}
+
+				batch = append(batch, item)
+				if len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+
+				if len(batch) >= size {
+					flush()
+				}
+
+			case <-timeout:
+				flush()
+			}
+		}
+	}()
+
+	return output
+}
@@ -0,0 +1,114 @@
+package async
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collectBatches[T any](output <-chan []T) [][]T {
+	var batches [][]T
+	for batch := range output {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
+
+func TestBatch_GroupsBySize(t *testing.T) {
+	// Given
+	input := feed(1, 2, 3, 4, 5, 6, 7)
+
+	// When
+	output := Batch(input, 3, time.Minute)
+
+	// Then
+	assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, collectBatches(output))
+}
+
+func TestBatch_FlushesAfterMaxWait(t *testing.T) {
+	// Given
+	input := make(chan int)
+	output := Batch(input, 10, 30*time.Millisecond)
+
+	// When
+	input <- 1
+	input <- 2
+
+	// Then
+	select {
+	case batch := <-output:
+		assert.Equal(t, []int{1, 2}, batch)
+	case <-time.After(time.Second):
+		t.Fatal("Batch was not flushed after the max wait")
+	}
+
+	// The next batch waits for its own first item
+	input <- 3
+	close(input)
+	assert.Equal(t, [][]int{{3}}, collectBatches(output))
+}
+
+func TestBatch_MaxWaitStartsAtFirstItem(t *testing.T) {
+	// Given
+	input := make(chan int)
+	output := Batch(input, 10, 50*time.Millisecond)
+
+	// When
+	time.Sleep(80 * time.Millisecond) // longer than the max wait, with nothing buffered
+	start := time.Now()
+	input <- 1
+	batch := <-output
+
+	// Then
+	assert.Equal(t, []int{1}, batch)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	close(input)
+	assert.Empty(t, collectBatches(output))
+}
+
+func TestBatch_NoMaxWait(t *testing.T) {
+	// Given
+	input := make(chan int)
+	output := Batch(input, 2, 0)
+
+	// When
+	input <- 1
+
+	// Then
+	select {
+	case <-output:
+		t.Fatal("Batch should wait until it's full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	input <- 2
+	assert.Equal(t, []int{1, 2}, <-output)
+	close(input)
+	assert.Empty(t, collectBatches(output))
+}
+
+func TestBatch_EmptyInput(t *testing.T) {
+	// Given
+	input := make(chan int)
+	close(input)
+
+	// When
+	output := Batch(input, 3, time.Second)
+
+	// Then
+	assert.Empty(t, collectBatches(output))
+}
+
+func TestBatch_ZeroSize(t *testing.T) {
+	// Given
+	input := feed("a", "b", "c")
+
+	// When
+	output := Batch(input, 0, time.Second)
+
+	// Then
+	assert.Equal(t, [][]string{{"a"}, {"b"}, {"c"}}, collectBatches(output), "Should emit each item alone")
+}
@@ -0,0 +1,91 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// ConcurrentChannelCtx processes items from an input channel concurrently, like ConcurrentChannel, but stops early when
+// the context is canceled. The workers check the context between items, so once it's canceled no new items are taken
+// from the input, pending results are dropped, and the output channel is closed.
+//
+// # Type parameters:
+//   - T: the type of items in the input channel
+//   - R: the type of items in the output channel (result type)
+//
+// # Parameters:
+//   - ctx: the context that aborts the processing when canceled; it's also passed to fn
+//   - input: a receive-only channel from which items of type T are read
+//   - concurrency: the number of worker goroutines to spawn for parallel processing
+//   - fn: a function that transforms an item of type T into a result of type R
+//
+// # Returns:
+//   - a receive-only channel that emits results of type R. The channel is automatically closed when all items from the
+//     input channel have been processed, or when the context is canceled.
+//
+// # Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//
+//	results := ConcurrentChannelCtx(ctx, urls, 3, func(ctx context.Context, url string) string {
+//		return download(ctx, url)
+//	})
+//
+//	for result := range results {
+//		fmt.Println(result)
+//	}
+//
+// Note: The order of results in the output channel is not guaranteed to match
+// the order of items in the input channel due to concurrent processing.
+func ConcurrentChannelCtx[T any, R any](
+	ctx context.Context,
+	input <-chan T,
+	concurrency int,
+	fn func(context.Context, T) R,
+) <-chan R {
+	output := make(chan R)
+
+	var wg sync.WaitGroup
+	wg.Add(max(concurrency, 0))
+
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				// Checked first, so a canceled context wins over a ready input
+				if ctx.Err() != nil {
+					return
+				}
+
+				var item T
+				var ok bool
+
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok = <-input:
+					if !ok {
+						return
+					}
+				}
+
+				result := fn(ctx, item)
+
+				select {
+				case <-ctx.Done():
+					return
+				case output <- result:
+				}
+			}
+		}()
+	}
+
+	// Close output once all workers are done
+	go func() {
+		wg.Wait()
+		close(output)
+	}()
+
+	return output
+}
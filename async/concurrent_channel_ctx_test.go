@@ -0,0 +1,139 @@
+package async
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentChannelCtx_BasicFunctionality(t *testing.T) {
+	// Given
+	input := feed(1, 2, 3, 4, 5)
+
+	// When
+	output := ConcurrentChannelCtx(context.Background(), input, 2, func(ctx context.Context, n int) int {
+		return n * n
+	})
+
+	// Then
+	var results []int
+	for result := range output {
+		results = append(results, result)
+	}
+	sort.Ints(results)
+
+	assert.Equal(t, []int{1, 4, 9, 16, 25}, results)
+}
+
+func TestConcurrentChannelCtx_StopsWhenCanceled(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithCancel(context.Background())
+	input := make(chan int)
+	var processed atomic.Int32
+
+	go func() {
+		defer close(input)
+		for i := 0; ; i++ {
+			select {
+			case input <- i:
+			case <-time.After(time.Second):
+				return // the workers stopped taking items
+			}
+		}
+	}()
+
+	// When
+	output := ConcurrentChannelCtx(ctx, input, 3, func(ctx context.Context, n int) int {
+		processed.Add(1)
+		return n
+	})
+
+	for range 5 {
+		<-output
+	}
+	cancel()
+
+	// Then
+	done := make(chan bool)
+	go func() {
+		for range output {
+		}
+		done <- true
+	}()
+
+	select {
+	case <-done:
+		before := processed.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, before, processed.Load(), "Should not process items after cancellation")
+	case <-time.After(time.Second):
+		t.Fatal("Output channel was not closed after cancellation")
+	}
+}
+
+func TestConcurrentChannelCtx_ConsumerGivesUp(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithCancel(context.Background())
+	input := make(chan int, 100)
+	for i := range 100 {
+		input <- i
+	}
+	close(input)
+
+	// When
+	output := ConcurrentChannelCtx(ctx, input, 4, func(ctx context.Context, n int) int {
+		return n
+	})
+
+	<-output
+	cancel() // nobody reads the output anymore
+
+	// Then
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case _, ok := <-output:
+		assert.False(t, ok, "Workers should have dropped their results and closed the output")
+	case <-time.After(time.Second):
+		t.Fatal("Workers blocked sending results after cancellation")
+	}
+	assert.NotEmpty(t, input, "Should stop dispatching the remaining items")
+}
+
+func TestConcurrentChannelCtx_PassesContext(t *testing.T) {
+	// Given
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	input := feed(1)
+
+	// When
+	output := ConcurrentChannelCtx(ctx, input, 1, func(ctx context.Context, n int) string {
+		return ctx.Value(key{}).(string)
+	})
+
+	// Then
+	assert.Equal(t, "value", <-output)
+}
+
+func TestConcurrentChannelCtx_ZeroConcurrency(t *testing.T) {
+	// Given
+	input := make(chan int, 1)
+	input <- 1
+	close(input)
+
+	// When
+	output := ConcurrentChannelCtx(context.Background(), input, 0, func(ctx context.Context, n int) int {
+		return n
+	})
+
+	// Then
+	select {
+	case _, ok := <-output:
+		assert.False(t, ok, "Should process nothing with 0 workers")
+	case <-time.After(time.Second):
+		t.Fatal("Output channel was not closed with 0 workers")
+	}
+}
@@ -0,0 +1,80 @@
+package async
+
+import "sync"
+
+// ConcurrentChannelErr processes items from an input channel concurrently, like ConcurrentChannel, but with a function
+// that can fail. Successful results are emitted on one channel and errors on another, so a few failing items don't
+// discard the whole batch.
+//
+// # Type parameters:
+//   - T: the type of items in the input channel
+//   - R: the type of items in the output channel (result type)
+//
+// # Parameters:
+//   - input: a receive-only channel from which items of type T are read
+//   - concurrency: the number of worker goroutines to spawn for parallel processing
+//   - fn: a function that transforms an item of type T into a result of type R, or returns an error
+//
+// # Returns:
+//   - a receive-only channel that emits the results of type R of the items that succeeded
+//   - a receive-only channel that emits the errors of the items that failed
+//
+// Both channels are closed when all items from the input channel have been processed. They are unbuffered, so both
+// must be consumed at the same time, otherwise the workers block.
+//
+// # Example:
+//
+//	results, errs := ConcurrentChannelErr(urls, 3, download)
+//
+//	for results != nil || errs != nil {
+//		select {
+//		case r, ok := <-results:
+//			if !ok {
+//				results = nil
+//				continue
+//			}
+//			fmt.Println(r)
+//		case err, ok := <-errs:
+//			if !ok {
+//				errs = nil
+//				continue
+//			}
+//			fmt.Println(err)
+//		}
+//	}
+//
+// Note: The order of results in the output channel is not guaranteed to match
+// the order of items in the input channel due to concurrent processing.
+func ConcurrentChannelErr[T any, R any](
+	input <-chan T,
+	concurrency int,
+	fn func(T) (R, error),
+) (<-chan R, <-chan error) {
+	output := make(chan R)
+	errs := make(chan error)
+
+	var wg sync.WaitGroup
+	wg.Add(max(concurrency, 0))
+
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for item := range input {
+				if result, err := fn(item); err != nil {
+					errs <- err
+				} else {
+					output <- result
+				}
+			}
+		}()
+	}
+
+	// Close both channels once all workers are done
+	go func() {
+		wg.Wait()
+		close(output)
+		close(errs)
+	}()
+
+	return output, errs
+}
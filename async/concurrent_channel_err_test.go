@@ -0,0 +1,150 @@
+package async
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collectResultsAndErrors[R any](results <-chan R, errs <-chan error) ([]R, []error) {
+	var outR []R
+	var outE []error
+
+	for results != nil || errs != nil {
+		select {
+		case r, ok := <-results:
+			if !ok {
+				results = nil
+				continue
+			}
+			outR = append(outR, r)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			outE = append(outE, err)
+		}
+	}
+
+	return outR, outE
+}
+
+func feed[T any](items ...T) <-chan T {
+	input := make(chan T)
+	go func() {
+		for _, item := range items {
+			input <- item
+		}
+		close(input)
+	}()
+
+	return input
+}
+
+func TestConcurrentChannelErr_SplitsResultsAndErrors(t *testing.T) {
+	// Given
+	input := feed(1, 2, 3, 4, 5, 6)
+
+	// When
+	results, errs := ConcurrentChannelErr(input, 3, func(n int) (int, error) {
+		if n%2 == 0 {
+			return 0, fmt.Errorf("even number %d", n)
+		}
+		return n * n, nil
+	})
+
+	// Then
+	outR, outE := collectResultsAndErrors(results, errs)
+	sort.Ints(outR)
+
+	assert.Equal(t, []int{1, 9, 25}, outR)
+	assert.Len(t, outE, 3)
+}
+
+func TestConcurrentChannelErr_AllSucceed(t *testing.T) {
+	// Given
+	input := feed("a", "b", "c")
+
+	// When
+	results, errs := ConcurrentChannelErr(input, 2, func(s string) (string, error) {
+		return s + s, nil
+	})
+
+	// Then
+	outR, outE := collectResultsAndErrors(results, errs)
+	sort.Strings(outR)
+
+	assert.Equal(t, []string{"aa", "bb", "cc"}, outR)
+	assert.Empty(t, outE)
+}
+
+func TestConcurrentChannelErr_AllFail(t *testing.T) {
+	// Given
+	expected := errors.New("failed")
+	input := feed(1, 2, 3)
+
+	// When
+	results, errs := ConcurrentChannelErr(input, 2, func(n int) (int, error) {
+		return 0, expected
+	})
+
+	// Then
+	outR, outE := collectResultsAndErrors(results, errs)
+
+	assert.Empty(t, outR)
+	assert.Equal(t, []error{expected, expected, expected}, outE)
+}
+
+func TestConcurrentChannelErr_EmptyInput(t *testing.T) {
+	// Given
+	input := make(chan int)
+	close(input)
+
+	// When
+	results, errs := ConcurrentChannelErr(input, 3, func(n int) (int, error) {
+		return n, nil
+	})
+
+	// Then
+	outR, outE := collectResultsAndErrors(results, errs)
+
+	assert.Empty(t, outR)
+	assert.Empty(t, outE)
+}
+
+func TestConcurrentChannelErr_ZeroConcurrency(t *testing.T) {
+	// Given
+	input := make(chan int, 2)
+	input <- 1
+	input <- 2
+	close(input)
+
+	// When
+	results, errs := ConcurrentChannelErr(input, 0, func(n int) (int, error) {
+		return n * 2, nil
+	})
+
+	// Then
+	done := make(chan bool)
+	var outR []int
+	var outE []error
+
+	go func() {
+		outR, outE = collectResultsAndErrors(results, errs)
+		done <- true
+	}()
+
+	select {
+	case <-done:
+		assert.Empty(t, outR, "Should process nothing with 0 workers")
+		assert.Empty(t, outE, "Should process nothing with 0 workers")
+		assert.Len(t, input, 2, "Should not consume the input with 0 workers")
+	case <-time.After(time.Second):
+		t.Fatal("Channels were not closed with 0 workers")
+	}
+}
@@ -0,0 +1,49 @@
+package async
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// ConcurrentChannelRateLimited processes items from an input channel concurrently, like ConcurrentChannel, but caps how
+// many items start per second, regardless of the number of workers. Limiting the workers bounds the concurrency but not
+// the rate, which is what APIs with a requests-per-second quota need.
+//
+// The rate is enforced with a token bucket without bursts, so the items start evenly spaced, one every 1/rps seconds.
+//
+// # Type parameters:
+//   - T: the type of items in the input channel
+//   - R: the type of items in the output channel (result type)
+//
+// # Parameters:
+//   - input: a receive-only channel from which items of type T are read
+//   - concurrency: the number of worker goroutines to spawn for parallel processing
+//   - rps: the max number of items that start per second; values <= 0 disable the limit
+//   - fn: a function that transforms an item of type T into a result of type R
+//
+// # Returns:
+//   - a receive-only channel that emits results of type R. The channel is automatically
+//     closed when all items from the input channel have been processed.
+//
+// # Example:
+//
+//	// At most 5 requests per second, with up to 10 in flight
+//	responses := ConcurrentChannelRateLimited(urls, 10, 5, fetchUrl)
+//
+// Note: The order of results in the output channel is not guaranteed to match
+// the order of items in the input channel due to concurrent processing.
+func ConcurrentChannelRateLimited[T any, R any](input <-chan T, concurrency int, rps float64, fn func(T) R) <-chan R {
+	limit := rate.Inf
+	if rps > 0 {
+		limit = rate.Limit(rps)
+	}
+
+	limiter := rate.NewLimiter(limit, 1)
+
+	return ConcurrentChannel(input, concurrency, func(item T) R {
+		// Never fails, as the context is never canceled and the burst is never exceeded
+		_ = limiter.Wait(context.Background())
+		return fn(item)
+	})
+}
@@ -0,0 +1,97 @@
+package async
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentChannelRateLimited_BasicFunctionality(t *testing.T) {
+	// Given
+	input := feed(1, 2, 3)
+
+	// When
+	output := ConcurrentChannelRateLimited(input, 2, 100, func(n int) int {
+		return n * n
+	})
+
+	// Then
+	var results []int
+	for result := range output {
+		results = append(results, result)
+	}
+	sort.Ints(results)
+
+	assert.Equal(t, []int{1, 4, 9}, results)
+}
+
+func TestConcurrentChannelRateLimited_CapsRate(t *testing.T) {
+	// Given
+	input := feed(1, 2, 3, 4, 5, 6)
+
+	var mu sync.Mutex
+	var starts []time.Time
+
+	// When
+	output := ConcurrentChannelRateLimited(input, 6, 20, func(n int) int {
+		mu.Lock()
+		starts = append(starts, time.Now())
+		mu.Unlock()
+		return n
+	})
+
+	for range output {
+	}
+
+	// Then
+	require.Len(t, starts, 6)
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	// 6 items at 20 per second need at least 5 intervals of 50ms, even with a worker per item
+	assert.GreaterOrEqual(t, starts[5].Sub(starts[0]), 225*time.Millisecond)
+}
+
+func TestConcurrentChannelRateLimited_NoLimit(t *testing.T) {
+	// Given
+	items := make([]int, 100)
+	input := feed(items...)
+	start := time.Now()
+
+	// When
+	output := ConcurrentChannelRateLimited(input, 4, 0, func(n int) int {
+		return n
+	})
+
+	count := 0
+	for range output {
+		count++
+	}
+
+	// Then
+	assert.Equal(t, 100, count)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestConcurrentChannelRateLimited_ZeroConcurrency(t *testing.T) {
+	// Given
+	input := make(chan int, 1)
+	input <- 1
+	close(input)
+
+	// When
+	output := ConcurrentChannelRateLimited(input, 0, 10, func(n int) int {
+		return n
+	})
+
+	// Then
+	select {
+	case _, ok := <-output:
+		assert.False(t, ok, "Should process nothing with 0 workers")
+	case <-time.After(time.Second):
+		t.Fatal("Output channel was not closed with 0 workers")
+	}
+}
@@ -0,0 +1,75 @@
+package async
+
+import (
+	"fmt"
+	"time"
+)
+
+// retryBaseDelay is the delay before the first retry; it doubles on each following retry, up to retryMaxDelay.
+var retryBaseDelay = 500 * time.Millisecond
+
+// retryMaxDelay caps the delay between retries, so a large number of retries doesn't end in endless waits.
+var retryMaxDelay = 30 * time.Second
+
+// ConcurrentChannelRetry processes items from an input channel concurrently, like ConcurrentChannelErr, but retries the
+// function for each failed item, with an exponential backoff, before giving up on it. This keeps the retry logic out of
+// functions that are flaky, like network calls.
+//
+// # Type parameters:
+//   - T: the type of items in the input channel
+//   - R: the type of items in the output channel (result type)
+//
+// # Parameters:
+//   - input: a receive-only channel from which items of type T are read
+//   - concurrency: the number of worker goroutines to spawn for parallel processing
+//   - retries: the number of retries after the first attempt fails; 0 means no retries
+//   - fn: a function that transforms an item of type T into a result of type R, or returns an error
+//
+// # Returns:
+//   - a receive-only channel that emits the results of type R of the items that succeeded
+//   - a receive-only channel that emits, for the items that failed all attempts, the error of the last attempt
+//
+// The retries of an item start after 500ms and the delay doubles on each one, up to 30s, while the worker waits. Both channels are
+// closed when all items from the input channel have been processed, and must be consumed at the same time.
+//
+// # Example:
+//
+//	results, errs := ConcurrentChannelRetry(urls, 3, 2, download)
+//
+// Note: The order of results in the output channel is not guaranteed to match
+// the order of items in the input channel due to concurrent processing.
+func ConcurrentChannelRetry[T any, R any](
+	input <-chan T,
+	concurrency int,
+	retries int,
+	fn func(T) (R, error),
+) (<-chan R, <-chan error) {
+	return ConcurrentChannelErr(input, concurrency, func(item T) (R, error) {
+		var zero R
+		var err error
+
+		for attempt := 0; attempt <= retries; attempt++ {
+			if attempt > 0 {
+				time.Sleep(retryDelay(attempt))
+			}
+
+			var result R
+			if result, err = fn(item); err == nil {
+				return result, nil
+			}
+		}
+
+		return zero, fmt.Errorf("failed after %d attempts: %w", max(retries, 0)+1, err)
+	})
+}
+
+// retryDelay returns the delay before the given retry attempt (starting at 1), doubling from retryBaseDelay and capped
+// at retryMaxDelay. The shift is bounded too, so the delay can't overflow into a negative duration.
+func retryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay << min(attempt-1, 30)
+	if delay <= 0 || delay > retryMaxDelay {
+		return retryMaxDelay
+	}
+
+	return delay
+}
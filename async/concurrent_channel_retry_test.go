@@ -0,0 +1,117 @@
+package async
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withRetryBaseDelay(t *testing.T, delay time.Duration) {
+	previous := retryBaseDelay
+	retryBaseDelay = delay
+	t.Cleanup(func() { retryBaseDelay = previous })
+}
+
+func TestConcurrentChannelRetry_RecoversFlakyItems(t *testing.T) {
+	// Given
+	withRetryBaseDelay(t, time.Millisecond)
+	input := feed(1, 2, 3, 4)
+
+	var mu sync.Mutex
+	attempts := make(map[int]int)
+
+	// When
+	results, errs := ConcurrentChannelRetry(input, 2, 2, func(n int) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts[n]++
+		if attempts[n] < n {
+			return 0, errors.New("flaky")
+		}
+		return n * 10, nil
+	})
+
+	// Then
+	outR, outE := collectResultsAndErrors(results, errs)
+	sort.Ints(outR)
+
+	// 1, 2 and 3 succeed within the 3 attempts; 4 needs a 4th attempt
+	assert.Equal(t, []int{10, 20, 30}, outR)
+	require.Len(t, outE, 1)
+	assert.Equal(t, map[int]int{1: 1, 2: 2, 3: 3, 4: 3}, attempts)
+}
+
+func TestConcurrentChannelRetry_WrapsLastError(t *testing.T) {
+	// Given
+	withRetryBaseDelay(t, time.Millisecond)
+	expected := errors.New("permanent failure")
+	input := feed("item")
+
+	// When
+	results, errs := ConcurrentChannelRetry(input, 1, 1, func(s string) (string, error) {
+		return "", expected
+	})
+
+	// Then
+	outR, outE := collectResultsAndErrors(results, errs)
+
+	assert.Empty(t, outR)
+	require.Len(t, outE, 1)
+	assert.ErrorIs(t, outE[0], expected)
+	assert.Contains(t, outE[0].Error(), "failed after 2 attempts")
+}
+
+func TestConcurrentChannelRetry_NoRetries(t *testing.T) {
+	// Given
+	calls := 0
+	input := feed(1)
+
+	// When
+	results, errs := ConcurrentChannelRetry(input, 1, 0, func(n int) (int, error) {
+		calls++
+		return 0, errors.New("failed")
+	})
+
+	// Then
+	_, outE := collectResultsAndErrors(results, errs)
+
+	assert.Len(t, outE, 1)
+	assert.Equal(t, 1, calls)
+}
+
+func TestConcurrentChannelRetry_BacksOffExponentially(t *testing.T) {
+	// Given
+	withRetryBaseDelay(t, 20*time.Millisecond)
+	input := feed(1)
+	var times []time.Time
+
+	// When
+	results, errs := ConcurrentChannelRetry(input, 1, 2, func(n int) (int, error) {
+		times = append(times, time.Now())
+		return 0, errors.New("failed")
+	})
+	collectResultsAndErrors(results, errs)
+
+	// Then
+	require.Len(t, times, 3)
+	assert.GreaterOrEqual(t, times[1].Sub(times[0]), 20*time.Millisecond)
+	assert.GreaterOrEqual(t, times[2].Sub(times[1]), 40*time.Millisecond)
+}
+
+func TestRetryDelay_IsCapped(t *testing.T) {
+	// Given
+	withRetryBaseDelay(t, 500*time.Millisecond)
+
+	// Then
+	assert.Equal(t, 500*time.Millisecond, retryDelay(1))
+	assert.Equal(t, 4*time.Second, retryDelay(4))
+	assert.Equal(t, retryMaxDelay, retryDelay(10))
+	assert.Equal(t, retryMaxDelay, retryDelay(100))
+	assert.Equal(t, retryMaxDelay, retryDelay(1_000_000))
+}
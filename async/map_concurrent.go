@@ -0,0 +1,53 @@
+package async
+
+import "sync"
+
+// MapConcurrent applies a transformation function to each element in a slice concurrently and returns a slice with the
+// transformed results. It's the slice-in/slice-out counterpart to SliceToChannel, for when all the results are needed
+// at once.
+//
+// The items are processed by a bounded pool of worker goroutines, and each result is stored at the same index of its
+// item, so the order of the input slice is preserved.
+//
+// # Type Parameters:
+//   - T: the type of elements in the input slice
+//   - R: the type of elements returned by the transformation function
+//
+// # Parameters:
+//   - items: the slice of items to transform
+//   - workers: the number of worker goroutines; values below 1 are treated as 1
+//   - fn: the transformation function to apply to each item
+//
+// # Returns:
+//   - A slice with the transformed results, in the same order as the items
+//
+// # Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	doubled := MapConcurrent(numbers, 2, func(n int) int { return n * 2 })
+//	fmt.Println(doubled) // outputs: [2 4 6 8 10]
+func MapConcurrent[T any, R any](items []T, workers int, fn func(T) R) []R {
+	results := make([]R, len(items))
+	workers = min(max(workers, 1), len(items))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				results[idx] = fn(items[idx])
+			}
+		}()
+	}
+
+	for idx := range items {
+		indexes <- idx
+	}
+	close(indexes)
+
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,78 @@
+package async
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapConcurrent_PreservesOrder(t *testing.T) {
+	// Given
+	items := []int{5, 4, 3, 2, 1}
+
+	// When
+	result := MapConcurrent(items, 3, func(n int) int {
+		// Later items finish first
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n * 10
+	})
+
+	// Then
+	assert.Equal(t, []int{50, 40, 30, 20, 10}, result)
+}
+
+func TestMapConcurrent_IntToString(t *testing.T) {
+	// Given
+	items := []int{1, 2, 3}
+
+	// When
+	result := MapConcurrent(items, 2, strconv.Itoa)
+
+	// Then
+	assert.Equal(t, []string{"1", "2", "3"}, result)
+}
+
+func TestMapConcurrent_EmptySlice(t *testing.T) {
+	// When
+	result := MapConcurrent([]int{}, 3, func(n int) int { return n })
+
+	// Then
+	assert.Empty(t, result)
+	assert.NotNil(t, result)
+}
+
+func TestMapConcurrent_BoundsConcurrency(t *testing.T) {
+	// Given
+	items := make([]int, 20)
+	var running, peak atomic.Int32
+
+	// When
+	MapConcurrent(items, 3, func(n int) int {
+		current := running.Add(1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return n
+	})
+
+	// Then
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	assert.Greater(t, peak.Load(), int32(1))
+}
+
+func TestMapConcurrent_ZeroWorkers(t *testing.T) {
+	// When
+	result := MapConcurrent([]int{1, 2, 3}, 0, func(n int) int { return n * n })
+
+	// Then
+	assert.Equal(t, []int{1, 4, 9}, result, "Should fall back to a single worker")
+}
@@ -0,0 +1,55 @@
+package async
+
+import "sync"
+
+// Merge fans in several channels into a single one, so the results of many pipelines can be consumed in one place. The
+// output channel is closed only after all the input channels are closed.
+//
+// # Type parameters:
+//   - T: the type of items in the channels
+//
+// # Parameters:
+//   - channels: the receive-only channels to merge
+//
+// # Returns:
+//   - a receive-only channel that emits the items of all the channels. Without channels, it's closed immediately; with
+//     a single channel, that channel is returned as it is.
+//
+// # Example:
+//
+//	images := ConcurrentChannel(imageUrls, 3, download)
+//	videos := ConcurrentChannel(videoUrls, 2, download)
+//
+//	for file := range Merge(images, videos) {
+//		fmt.Println(file)
+//	}
+//
+// Note: The items of each channel keep their relative order, but the items of different channels are interleaved as
+// they arrive.
+func Merge[T any](channels ...<-chan T) <-chan T {
+	if len(channels) == 1 {
+		return channels[0]
+	}
+
+	output := make(chan T)
+
+	var wg sync.WaitGroup
+	wg.Add(len(channels))
+
+	for _, ch := range channels {
+		go func(ch <-chan T) {
+			defer wg.Done()
+			for item := range ch {
+				output <- item
+			}
+		}(ch)
+	}
+
+	// Close output once all channels are drained
+	go func() {
+		wg.Wait()
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,103 @@
+package async
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge_MultipleChannels(t *testing.T) {
+	// Given
+	a := feed(1, 2, 3)
+	b := feed(4, 5)
+	c := feed(6)
+
+	// When
+	output := Merge(a, b, c)
+
+	// Then
+	var results []int
+	for item := range output {
+		results = append(results, item)
+	}
+	sort.Ints(results)
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, results)
+}
+
+func TestMerge_KeepsOrderOfEachChannel(t *testing.T) {
+	// Given
+	a := feed("a1", "a2", "a3")
+	b := feed("b1", "b2", "b3")
+
+	// When
+	output := Merge(a, b)
+
+	// Then
+	var fromA, fromB []string
+	for item := range output {
+		if item[0] == 'a' {
+			fromA = append(fromA, item)
+		} else {
+			fromB = append(fromB, item)
+		}
+	}
+
+	assert.Equal(t, []string{"a1", "a2", "a3"}, fromA)
+	assert.Equal(t, []string{"b1", "b2", "b3"}, fromB)
+}
+
+func TestMerge_WaitsForAllChannels(t *testing.T) {
+	// Given
+	fast := feed(1)
+	slow := make(chan int)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		slow <- 2
+		close(slow)
+	}()
+
+	// When
+	output := Merge(fast, slow)
+
+	// Then
+	var results []int
+	for item := range output {
+		results = append(results, item)
+	}
+
+	assert.Equal(t, []int{1, 2}, results)
+}
+
+func TestMerge_ZeroChannels(t *testing.T) {
+	// When
+	output := Merge[int]()
+
+	// Then
+	select {
+	case _, ok := <-output:
+		assert.False(t, ok, "Output should be closed without channels")
+	case <-time.After(time.Second):
+		t.Fatal("Output channel was not closed")
+	}
+}
+
+func TestMerge_SingleChannel(t *testing.T) {
+	// Given
+	input := feed(1, 2, 3)
+
+	// When
+	output := Merge(input)
+
+	// Then
+	var results []int
+	for item := range output {
+		results = append(results, item)
+	}
+
+	assert.Equal(t, input, output, "A single channel should be passed through")
+	assert.Equal(t, []int{1, 2, 3}, results)
+}
@@ -0,0 +1,55 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/zeebo/blake3"
+)
+
+// Blake3Bytes computes the BLAKE3 hash of the input byte slice and returns it as a hexadecimal string.
+// It returns an error if the hashing process fails.
+//
+// # Parameters:
+//   - bytes: the byte slice to be hashed
+//
+// # Returns:
+//   - A hexadecimal string representation of the BLAKE3 hash
+//   - An error if the write operation fails, wrapped with context
+//
+// # Example:
+//
+//	hash, err := Blake3Bytes([]byte("hello world"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(hash) // Output: d74981efa70a0c880b8d8c1985d075dbcbf679b99a5f9914e5aaf96b831a9e24
+func Blake3Bytes(bytes []byte) (string, error) {
+	h := blake3.New()
+	_, err := h.Write(bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to write bytes to hasher: %w", err)
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// Blake3String computes the BLAKE3 hash of the input string and returns it as a hexadecimal string.
+// It returns an error if the hashing process fails.
+//
+// # Parameters:
+//   - str: the string to be hashed
+//
+// # Returns:
+//   - A hexadecimal string representation of the BLAKE3 hash
+//   - An error if the write operation fails, wrapped with context
+//
+// # Example:
+//
+//	hash, err := Blake3String("hello world")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(hash) // Output: d74981efa70a0c880b8d8c1985d075dbcbf679b99a5f9914e5aaf96b831a9e24
+func Blake3String(str string) (string, error) {
+	return Blake3Bytes([]byte(str))
+}
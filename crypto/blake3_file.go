@@ -0,0 +1,64 @@
+package crypto
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/zeebo/blake3"
+)
+
+// Blake3Reader computes the BLAKE3 hash of a reader.
+//
+// # Parameters:
+//   - reader: the reader to hash
+//
+// # Returns:
+//   - string: the BLAKE3 hash as a lowercase hexadecimal string
+//   - error: any error that occurred during hashing
+//
+// # Example:
+//
+//	hash, err := Blake3Reader(fileReader)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("BLAKE3: %s\n", hash)
+func Blake3Reader(reader io.Reader) (string, error) {
+	hash := blake3.New()
+
+	// Copy the reader content to the hash
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+
+	// Calculate the final hash and return as hex string
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// Blake3File computes the BLAKE3 hash of the file at the given path. The hash has the same format as the Hash of a
+// fetch.Response, so it can be used to verify downloads.
+//
+// # Parameters:
+//   - filePath: the path to the file to hash
+//
+// # Returns:
+//   - string: the BLAKE3 hash as a lowercase hexadecimal string
+//   - error: any error that occurred during file operations or hashing
+//
+// # Example:
+//
+//	hash, err := Blake3File("/path/to/file.txt")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("BLAKE3: %s\n", hash)
+func Blake3File(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return Blake3Reader(file)
+}
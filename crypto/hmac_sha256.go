@@ -0,0 +1,57 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// HmacSha256 computes the HMAC-SHA256 of a message with the given key and returns it as a hexadecimal string. It's
+// used to sign payloads, like webhooks, so the receiver can check they weren't forged or tampered with.
+//
+// # Parameters:
+//   - key: the secret key shared with the receiver
+//   - message: the message to be signed
+//
+// # Returns:
+//   - A lowercase hexadecimal string representation of the HMAC-SHA256, with 64 characters
+//
+// # Example:
+//
+//	signature := HmacSha256([]byte("key"), []byte("The quick brown fox jumps over the lazy dog"))
+//	fmt.Println(signature) // Output: f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8
+func HmacSha256(key, message []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message) // never returns an error
+
+	return fmt.Sprintf("%x", mac.Sum(nil))
+}
+
+// HmacSha256Verify checks if the expected HMAC-SHA256 is the signature of a message with the given key. The comparison
+// takes constant time, so it doesn't leak how much of a forged signature is right through timing attacks.
+//
+// # Parameters:
+//   - key: the secret key shared with the sender
+//   - message: the message that was signed
+//   - expectedHex: the signature received, as a hexadecimal string in any case
+//
+// # Returns:
+//   - true if the signature matches; false if it doesn't, or if it's not valid hexadecimal
+//
+// # Example:
+//
+//	if !HmacSha256Verify(secret, body, r.Header.Get("X-Signature")) {
+//	    http.Error(w, "invalid signature", http.StatusUnauthorized)
+//	}
+func HmacSha256Verify(key, message []byte, expectedHex string) bool {
+	expected, err := hex.DecodeString(expectedHex)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,67 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHmacSha256(t *testing.T) {
+	t.Run("quick brown fox", func(t *testing.T) {
+		signature := HmacSha256([]byte("key"), []byte("The quick brown fox jumps over the lazy dog"))
+		assert.Equal(t, "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", signature)
+	})
+
+	t.Run("RFC 4231 test case 1", func(t *testing.T) {
+		key := []byte(strings.Repeat("\x0b", 20))
+		signature := HmacSha256(key, []byte("Hi There"))
+		assert.Equal(t, "b0344c61d8db38535ca8afceaf0bf12b881dc200c9833da726e9376c2e32cff7", signature)
+	})
+
+	t.Run("RFC 4231 test case 2", func(t *testing.T) {
+		signature := HmacSha256([]byte("Jefe"), []byte("what do ya want for nothing?"))
+		assert.Equal(t, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", signature)
+	})
+
+	t.Run("empty key and message", func(t *testing.T) {
+		signature := HmacSha256(nil, nil)
+		assert.Equal(t, "b613679a0814d9ec772f95d778c35fc5ff1697c493715653c6c712144292c5ad", signature)
+	})
+
+	t.Run("different keys produce different signatures", func(t *testing.T) {
+		message := []byte("payload")
+		assert.NotEqual(t, HmacSha256([]byte("key1"), message), HmacSha256([]byte("key2"), message))
+	})
+}
+
+func TestHmacSha256Verify(t *testing.T) {
+	key := []byte("secret")
+	message := []byte(`{"event":"release"}`)
+	signature := HmacSha256(key, message)
+
+	t.Run("valid signature", func(t *testing.T) {
+		assert.True(t, HmacSha256Verify(key, message, signature))
+	})
+
+	t.Run("uppercase signature", func(t *testing.T) {
+		assert.True(t, HmacSha256Verify(key, message, strings.ToUpper(signature)))
+	})
+
+	t.Run("tampered message", func(t *testing.T) {
+		assert.False(t, HmacSha256Verify(key, []byte(`{"event":"delete"}`), signature))
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		assert.False(t, HmacSha256Verify([]byte("other"), message, signature))
+	})
+
+	t.Run("truncated signature", func(t *testing.T) {
+		assert.False(t, HmacSha256Verify(key, message, signature[:32]))
+	})
+
+	t.Run("invalid hexadecimal", func(t *testing.T) {
+		assert.False(t, HmacSha256Verify(key, message, "not-a-signature"))
+		assert.False(t, HmacSha256Verify(key, message, ""))
+	})
+}
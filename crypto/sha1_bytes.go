@@ -0,0 +1,60 @@
+package crypto
+
+import (
+	"crypto/sha1"
+	"fmt"
+)
+
+// Sha1Bytes computes the SHA-1 hash of the input byte slice and returns it as a hexadecimal string.
+// It returns an error if the hashing process fails.
+//
+// SHA-1 is a legacy, non-cryptographic hash: it is broken against collisions, so use it only to verify checksums
+// published by systems that don't offer anything better, never for security.
+//
+// # Parameters:
+//   - bytes: the byte slice to be hashed
+//
+// # Returns:
+//   - A hexadecimal string representation of the SHA-1 hash
+//   - An error if the write operation fails, wrapped with context
+//
+// # Example:
+//
+//	hash, err := Sha1Bytes([]byte("hello world"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(hash) // Output: 2aae6c35c94fcfb415dbe95f408b9ce91ee846ed
+func Sha1Bytes(bytes []byte) (string, error) {
+	h := sha1.New()
+	_, err := h.Write(bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to write bytes to hasher: %w", err)
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// Sha1String computes the SHA-1 hash of the input string and returns it as a hexadecimal string.
+// It returns an error if the hashing process fails.
+//
+// SHA-1 is a legacy, non-cryptographic hash: it is broken against collisions, so use it only to verify checksums
+// published by systems that don't offer anything better, never for security.
+//
+// # Parameters:
+//   - str: the string to be hashed
+//
+// # Returns:
+//   - A hexadecimal string representation of the SHA-1 hash
+//   - An error if the write operation fails, wrapped with context
+//
+// # Example:
+//
+//	hash, err := Sha1String("hello world")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(hash) // Output: 2aae6c35c94fcfb415dbe95f408b9ce91ee846ed
+func Sha1String(str string) (string, error) {
+	return Sha1Bytes([]byte(str))
+}
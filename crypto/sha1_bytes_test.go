@@ -0,0 +1,80 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSha1Bytes(t *testing.T) {
+	t.Run("empty input", func(t *testing.T) {
+		hash, err := Sha1Bytes([]byte{})
+		require.NoError(t, err)
+		assert.Equal(t, "da39a3ee5e6b4b0d3255bfef95601890afd80709", hash)
+	})
+
+	t.Run("abc", func(t *testing.T) {
+		hash, err := Sha1Bytes([]byte("abc"))
+		require.NoError(t, err)
+		assert.Equal(t, "a9993e364706816aba3e25717850c26c9cd0d89d", hash)
+	})
+
+	t.Run("hello world", func(t *testing.T) {
+		hash, err := Sha1Bytes([]byte("hello world"))
+		require.NoError(t, err)
+		assert.Equal(t, "2aae6c35c94fcfb415dbe95f408b9ce91ee846ed", hash)
+	})
+
+	t.Run("two-block message", func(t *testing.T) {
+		hash, err := Sha1Bytes([]byte("abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq"))
+		require.NoError(t, err)
+		assert.Equal(t, "84983e441c3bd26ebaae4aa1f95129e5e54670f1", hash)
+	})
+
+	t.Run("nil byte slice", func(t *testing.T) {
+		hash, err := Sha1Bytes(nil)
+		require.NoError(t, err)
+		// nil slice is treated same as empty slice
+		assert.Equal(t, "da39a3ee5e6b4b0d3255bfef95601890afd80709", hash)
+	})
+
+	t.Run("long input", func(t *testing.T) {
+		hash, err := Sha1Bytes([]byte(strings.Repeat("a", 10000)))
+		require.NoError(t, err)
+		assert.Len(t, hash, 40)
+	})
+}
+
+func TestSha1String(t *testing.T) {
+	t.Run("abc", func(t *testing.T) {
+		hash, err := Sha1String("abc")
+		require.NoError(t, err)
+		assert.Equal(t, "a9993e364706816aba3e25717850c26c9cd0d89d", hash)
+	})
+
+	t.Run("hash output is always lowercase hexadecimal", func(t *testing.T) {
+		hash, err := Sha1String("test")
+		require.NoError(t, err)
+		assert.Len(t, hash, 40)
+
+		for _, char := range hash {
+			assert.True(t,
+				(char >= '0' && char <= '9') || (char >= 'a' && char <= 'f'),
+				"hash should only contain lowercase hex characters, found: %c", char)
+		}
+	})
+
+	t.Run("consistency between Sha1String and Sha1Bytes", func(t *testing.T) {
+		testInput := "consistency test"
+
+		hashFromString, err1 := Sha1String(testInput)
+		require.NoError(t, err1)
+
+		hashFromBytes, err2 := Sha1Bytes([]byte(testInput))
+		require.NoError(t, err2)
+
+		assert.Equal(t, hashFromString, hashFromBytes)
+	})
+}
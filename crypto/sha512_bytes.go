@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"crypto/sha512"
+	"fmt"
+)
+
+// Sha512Bytes computes the SHA-512 hash of the input byte slice and returns it as a hexadecimal string.
+// It returns an error if the hashing process fails.
+//
+// # Parameters:
+//   - bytes: the byte slice to be hashed
+//
+// # Returns:
+//   - A hexadecimal string representation of the SHA-512 hash
+//   - An error if the write operation fails, wrapped with context
+//
+// # Example:
+//
+//	hash, err := Sha512Bytes([]byte("hello world"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(hash) // Output: 309ecc489c12d6eb4cc40f50c902f2b4d0ed77ee511a7c7a9bcd3ca86d4cd86f989dd35bc5ff499670da34255b45b0cfd830e81f605dcf7dc5542e93ae9cd76f
+func Sha512Bytes(bytes []byte) (string, error) {
+	h := sha512.New()
+	_, err := h.Write(bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to write bytes to hasher: %w", err)
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// Sha512String computes the SHA-512 hash of the input string and returns it as a hexadecimal string.
+// It returns an error if the hashing process fails.
+//
+// # Parameters:
+//   - str: the string to be hashed
+//
+// # Returns:
+//   - A hexadecimal string representation of the SHA-512 hash
+//   - An error if the write operation fails, wrapped with context
+//
+// # Example:
+//
+//	hash, err := Sha512String("hello world")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(hash) // Output: 309ecc489c12d6eb4cc40f50c902f2b4d0ed77ee511a7c7a9bcd3ca86d4cd86f989dd35bc5ff499670da34255b45b0cfd830e81f605dcf7dc5542e93ae9cd76f
+func Sha512String(str string) (string, error) {
+	return Sha512Bytes([]byte(str))
+}
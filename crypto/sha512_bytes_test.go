@@ -0,0 +1,80 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSha512Bytes(t *testing.T) {
+	t.Run("empty input", func(t *testing.T) {
+		hash, err := Sha512Bytes([]byte{})
+		require.NoError(t, err)
+		assert.Equal(t, "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e", hash)
+	})
+
+	t.Run("abc", func(t *testing.T) {
+		hash, err := Sha512Bytes([]byte("abc"))
+		require.NoError(t, err)
+		assert.Equal(t, "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f", hash)
+	})
+
+	t.Run("hello world", func(t *testing.T) {
+		hash, err := Sha512Bytes([]byte("hello world"))
+		require.NoError(t, err)
+		assert.Equal(t, "309ecc489c12d6eb4cc40f50c902f2b4d0ed77ee511a7c7a9bcd3ca86d4cd86f989dd35bc5ff499670da34255b45b0cfd830e81f605dcf7dc5542e93ae9cd76f", hash)
+	})
+
+	t.Run("two-block message", func(t *testing.T) {
+		hash, err := Sha512Bytes([]byte("abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq"))
+		require.NoError(t, err)
+		assert.Equal(t, "204a8fc6dda82f0a0ced7beb8e08a41657c16ef468b228a8279be331a703c33596fd15c13b1b07f9aa1d3bea57789ca031ad85c7a71dd70354ec631238ca3445", hash)
+	})
+
+	t.Run("nil byte slice", func(t *testing.T) {
+		hash, err := Sha512Bytes(nil)
+		require.NoError(t, err)
+		// nil slice is treated same as empty slice
+		assert.Equal(t, "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e", hash)
+	})
+
+	t.Run("long input", func(t *testing.T) {
+		hash, err := Sha512Bytes([]byte(strings.Repeat("a", 10000)))
+		require.NoError(t, err)
+		assert.Len(t, hash, 128)
+	})
+}
+
+func TestSha512String(t *testing.T) {
+	t.Run("abc", func(t *testing.T) {
+		hash, err := Sha512String("abc")
+		require.NoError(t, err)
+		assert.Equal(t, "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f", hash)
+	})
+
+	t.Run("hash output is always lowercase hexadecimal", func(t *testing.T) {
+		hash, err := Sha512String("test")
+		require.NoError(t, err)
+		assert.Len(t, hash, 128)
+
+		for _, char := range hash {
+			assert.True(t,
+				(char >= '0' && char <= '9') || (char >= 'a' && char <= 'f'),
+				"hash should only contain lowercase hex characters, found: %c", char)
+		}
+	})
+
+	t.Run("consistency between Sha512String and Sha512Bytes", func(t *testing.T) {
+		testInput := "consistency test"
+
+		hashFromString, err1 := Sha512String(testInput)
+		require.NoError(t, err1)
+
+		hashFromBytes, err2 := Sha512Bytes([]byte(testInput))
+		require.NoError(t, err2)
+
+		assert.Equal(t, hashFromString, hashFromBytes)
+	})
+}
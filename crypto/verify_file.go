@@ -0,0 +1,73 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/vegidio/go-sak/types"
+	"github.com/zeebo/blake3"
+)
+
+// VerifyFile checks if the file at the given path matches an expected checksum, like after a download. The file is
+// streamed through the hasher, so it's never loaded in memory as a whole.
+//
+// # Parameters:
+//   - filePath: the path to the file to verify
+//   - expectedHex: the expected hash as a hexadecimal string; the comparison is case-insensitive
+//   - algo: the hash algorithm of the checksum; one of "sha256", "sha512" or "blake3"
+//
+// # Returns:
+//   - A Result whose Data is true when the file matches the checksum. Otherwise, Data is false and Err is set with the
+//     mismatch, the unsupported algorithm, or the error reading the file.
+//
+// # Example:
+//
+//	result := VerifyFile("/tmp/app.tar.xz", checksum, "sha256")
+//	if !result.IsSuccess() {
+//	    log.Fatal(result.Err)
+//	}
+func VerifyFile(filePath, expectedHex, algo string) types.Result[bool] {
+	hasher, err := newHasher(algo)
+	if err != nil {
+		return types.Result[bool]{Err: err}
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return types.Result[bool]{Err: err}
+	}
+	defer file.Close()
+
+	if _, err = io.Copy(hasher, file); err != nil {
+		return types.Result[bool]{Err: err}
+	}
+
+	actual := fmt.Sprintf("%x", hasher.Sum(nil))
+	if !strings.EqualFold(actual, strings.TrimSpace(expectedHex)) {
+		return types.Result[bool]{Err: fmt.Errorf("checksum mismatch: expected %s, got %s", expectedHex, actual)}
+	}
+
+	return types.Result[bool]{Data: true}
+}
+
+// region - Private functions
+
+func newHasher(algo string) (hash.Hash, error) {
+	switch strings.ToLower(algo) {
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	case "blake3":
+		return blake3.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm: %s", algo)
+	}
+}
+
+// endregion
@@ -0,0 +1,55 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("hello world"), 0644))
+
+	checksums := map[string]string{
+		"sha256": "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+		"sha512": "309ecc489c12d6eb4cc40f50c902f2b4d0ed77ee511a7c7a9bcd3ca86d4cd86f989dd35bc5ff499670da34255b45b0cfd830e81f605dcf7dc5542e93ae9cd76f",
+		"blake3": "d74981efa70a0c880b8d8c1985d075dbcbf679b99a5f9914e5aaf96b831a9e24",
+	}
+
+	for algo, checksum := range checksums {
+		t.Run("matching "+algo, func(t *testing.T) {
+			result := VerifyFile(filePath, checksum, algo)
+			assert.True(t, result.IsSuccess())
+			assert.True(t, result.Data)
+		})
+	}
+
+	t.Run("comparison is case-insensitive", func(t *testing.T) {
+		result := VerifyFile(filePath, strings.ToUpper(checksums["sha256"]), "SHA256")
+		assert.NoError(t, result.Err)
+		assert.True(t, result.Data)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		result := VerifyFile(filePath, checksums["sha256"], "blake3")
+		assert.False(t, result.IsSuccess())
+		assert.False(t, result.Data)
+		assert.Contains(t, result.Err.Error(), "checksum mismatch")
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		result := VerifyFile(filePath, checksums["sha256"], "md5")
+		assert.False(t, result.Data)
+		assert.ErrorContains(t, result.Err, "unsupported hash algorithm")
+	})
+
+	t.Run("file does not exist", func(t *testing.T) {
+		result := VerifyFile(filepath.Join(t.TempDir(), "missing.txt"), checksums["sha256"], "sha256")
+		assert.False(t, result.Data)
+		assert.ErrorIs(t, result.Err, os.ErrNotExist)
+	})
+}
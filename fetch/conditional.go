@@ -0,0 +1,53 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetTextConditional performs a conditional GET request, sending If-None-Match and If-Modified-Since so the server can
+// answer with 304 Not Modified when the cached copy is still fresh. Combined with the memo package, this allows remote
+// resources to be cached by URL without downloading them again.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts.
+//   - url: the URL to send the GET request to.
+//   - etag: the ETag of the cached copy, sent as If-None-Match; empty to omit it.
+//   - lastModified: the Last-Modified value of the cached copy, sent as If-Modified-Since; empty to omit it.
+//
+// Returns:
+//   - body: the response body as a string, or empty when the resource was not modified.
+//   - newEtag: the ETag returned by the server, or the given etag when a 304 response doesn't include one.
+//   - notModified: true when the server answered with 304 Not Modified, meaning the cached copy can be reused.
+//   - err: an error if the request fails or the response indicates an error.
+func (f *Fetch) GetTextConditional(
+	ctx context.Context,
+	url string,
+	etag string,
+	lastModified string,
+) (body string, newEtag string, notModified bool, err error) {
+	headers := make(map[string]string)
+	if etag != "" {
+		headers["If-None-Match"] = etag
+	}
+	if lastModified != "" {
+		headers["If-Modified-Since"] = lastModified
+	}
+
+	resp, err := f.doBody(ctx, url, headers, nil, "GET")
+	if err != nil {
+		return "", "", false, err
+	}
+
+	newEtag = resp.Header().Get("ETag")
+
+	if resp.StatusCode() == http.StatusNotModified {
+		if newEtag == "" {
+			newEtag = etag
+		}
+
+		return "", newEtag, true, nil
+	}
+
+	return resp.String(), newEtag, false, nil
+}
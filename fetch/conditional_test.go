@@ -0,0 +1,107 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetch_GetTextConditional(t *testing.T) {
+	const lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+
+	newServer := func(t *testing.T, sendEtagOn304 bool) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("If-None-Match") == `"v1"` || r.Header.Get("If-Modified-Since") == lastModified {
+				if sendEtagOn304 {
+					w.Header().Set("ETag", `"v1"`)
+				}
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Last-Modified", lastModified)
+			w.Write([]byte("fresh content"))
+		}))
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	t.Run("first request returns body and etag", func(t *testing.T) {
+		server := newServer(t, true)
+
+		f := New(nil, 0, false)
+		body, etag, notModified, err := f.GetTextConditional(context.Background(), server.URL, "", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "fresh content", body)
+		assert.Equal(t, `"v1"`, etag)
+		assert.False(t, notModified)
+	})
+
+	t.Run("matching etag is not modified", func(t *testing.T) {
+		server := newServer(t, true)
+
+		f := New(nil, 0, false)
+		body, etag, notModified, err := f.GetTextConditional(context.Background(), server.URL, `"v1"`, "")
+
+		require.NoError(t, err)
+		assert.Empty(t, body)
+		assert.Equal(t, `"v1"`, etag)
+		assert.True(t, notModified)
+	})
+
+	t.Run("matching last modified is not modified", func(t *testing.T) {
+		server := newServer(t, false)
+
+		f := New(nil, 0, false)
+		body, etag, notModified, err := f.GetTextConditional(context.Background(), server.URL, "", lastModified)
+
+		require.NoError(t, err)
+		assert.Empty(t, body)
+		assert.Empty(t, etag)
+		assert.True(t, notModified)
+	})
+
+	t.Run("304 without etag keeps the cached one", func(t *testing.T) {
+		server := newServer(t, false)
+
+		f := New(nil, 0, false)
+		_, etag, notModified, err := f.GetTextConditional(context.Background(), server.URL, `"v1"`, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, `"v1"`, etag)
+		assert.True(t, notModified)
+	})
+
+	t.Run("stale etag returns new content", func(t *testing.T) {
+		server := newServer(t, true)
+
+		f := New(nil, 0, false)
+		body, etag, notModified, err := f.GetTextConditional(context.Background(), server.URL, `"v0"`, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "fresh content", body)
+		assert.Equal(t, `"v1"`, etag)
+		assert.False(t, notModified)
+	})
+
+	t.Run("server returns error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		_, _, notModified, err := f.GetTextConditional(context.Background(), server.URL, `"v1"`, "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+		assert.False(t, notModified)
+	})
+}
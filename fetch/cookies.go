@@ -4,14 +4,75 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/browserutils/kooky"
 	_ "github.com/browserutils/kooky/browser/all"
 	"github.com/samber/lo"
+	"golang.org/x/net/publicsuffix"
 )
 
+// NewWithCookies creates a new Fetch instance whose requests and downloads share a cookie jar seeded with the given
+// cookies. Cookies set by the server are remembered and sent on subsequent requests, enabling login-then-fetch flows.
+//
+// Parameters:
+//   - headers: a map of headers to be set on each request.
+//   - retries: the number of retry attempts for failed requests.
+//   - disableHttp2: a boolean flag to disable HTTP/2.
+//   - rawUrl: the URL the seed cookies belong to.
+//   - cookies: the cookies used to seed the jar.
+//
+// Returns a new Fetch instance, or an error if the URL is invalid.
+func NewWithCookies(
+	headers map[string]string,
+	retries int,
+	disableHttp2 bool,
+	rawUrl string,
+	cookies []Cookie,
+) (*Fetch, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cookie URL: %w", err)
+	}
+
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
+	}
+
+	jar.SetCookies(u, lo.Map(cookies, func(cookie Cookie, _ int) *http.Cookie {
+		return &http.Cookie{
+			Name:    cookie.Name,
+			Value:   cookie.Value,
+			Domain:  cookie.Domain,
+			Path:    cookie.Path,
+			Expires: cookie.Expires,
+			Secure:  cookie.Secure,
+		}
+	}))
+
+	f := New(headers, retries, disableHttp2)
+	f.SetCookieJar(jar)
+
+	return f, nil
+}
+
+// SetCookieJar attaches a cookie jar to the Fetch instance, shared by requests and downloads, so cookies set by the
+// server are remembered and sent on subsequent requests. Passing nil disables cookie handling.
+//
+// Parameters:
+//   - jar: the cookie jar to use.
+func (f *Fetch) SetCookieJar(jar http.CookieJar) {
+	f.restClient.SetCookieJar(jar)
+	f.httpClient.Jar = jar
+}
+
 func GetFileCookies(filePath string) ([]Cookie, error) {
 	cookies := make([]Cookie, 0)
 
@@ -37,26 +98,91 @@ func GetFileCookies(filePath string) ([]Cookie, error) {
 			continue
 		}
 
-		name := parts[5]
-		value := parts[6]
+		var expires time.Time
+		if seconds, err := strconv.ParseInt(parts[4], 10, 64); err == nil && seconds > 0 {
+			expires = time.Unix(seconds, 0)
+		}
 
 		cookies = append(cookies, Cookie{
-			Name:  name,
-			Value: value,
+			Name:    parts[5],
+			Value:   parts[6],
+			Domain:  parts[0],
+			Path:    parts[2],
+			Expires: expires,
+			Secure:  strings.EqualFold(parts[3], "TRUE"),
 		})
 	}
 
 	return cookies, nil
 }
 
+// WriteFileCookies writes cookies to a file in the Netscape cookie format, the inverse of GetFileCookies. Cookies
+// without an expiry are written as session cookies (expiry 0), and the subdomain flag is derived from whether the
+// domain starts with a dot.
+//
+// Parameters:
+//   - filePath: the path of the file to write; it's created or truncated.
+//   - cookies: the cookies to write.
+//
+// Returns an error if the file can't be written.
+func WriteFileCookies(filePath string, cookies []Cookie) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if _, err = w.WriteString("# Netscape HTTP Cookie File\n"); err != nil {
+		return err
+	}
+
+	for _, cookie := range cookies {
+		var expires int64
+		if !cookie.Expires.IsZero() {
+			expires = cookie.Expires.Unix()
+		}
+
+		path := cookie.Path
+		if path == "" {
+			path = "/"
+		}
+
+		line := strings.Join([]string{
+			cookie.Domain,
+			netscapeBool(strings.HasPrefix(cookie.Domain, ".")),
+			path,
+			netscapeBool(cookie.Secure),
+			strconv.FormatInt(expires, 10),
+			cookie.Name,
+			cookie.Value,
+		}, "\t")
+
+		if _, err = w.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
 func GetBrowserCookies(domain string) []Cookie {
 	cookies := make([]Cookie, 0)
 
 	cookiesSeq := kooky.TraverseCookies(context.Background(), kooky.Valid, kooky.DomainHasSuffix(domain)).OnlyCookies()
 	for cookie := range cookiesSeq {
 		cookies = append(cookies, Cookie{
-			Name:  cookie.Name,
-			Value: cookie.Value,
+			Name:    cookie.Name,
+			Value:   cookie.Value,
+			Domain:  cookie.Domain,
+			Path:    cookie.Path,
+			Expires: cookie.Expires,
+			Secure:  cookie.Secure,
 		})
 	}
 
@@ -70,3 +196,11 @@ func CookiesToHeader(cookies []Cookie) string {
 
 	return strings.Join(parts, "; ")
 }
+
+func netscapeBool(value bool) string {
+	if value {
+		return "TRUE"
+	}
+
+	return "FALSE"
+}
@@ -1,10 +1,19 @@
 package fetch
 
 import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,9 +35,9 @@ func TestGetFileCookies(t *testing.T) {
 		assert.Len(t, cookies, 3)
 
 		expectedCookies := []Cookie{
-			{Name: "sessionid", Value: "abc123"},
-			{Name: "authtoken", Value: "xyz789"},
-			{Name: "preferences", Value: "theme=dark"},
+			{Name: "sessionid", Value: "abc123", Domain: ".example.com", Path: "/", Expires: time.Unix(1234567890, 0)},
+			{Name: "authtoken", Value: "xyz789", Domain: ".example.com", Path: "/api", Secure: true, Expires: time.Unix(1234567891, 0)},
+			{Name: "preferences", Value: "theme=dark", Domain: ".google.com", Path: "/search"},
 		}
 
 		assert.Equal(t, expectedCookies, cookies)
@@ -76,9 +85,9 @@ another invalid line
 		assert.Len(t, cookies, 3)
 
 		expectedCookies := []Cookie{
-			{Name: "sessionid", Value: "abc123"},
-			{Name: "authtoken", Value: "xyz789"},
-			{Name: "preferences", Value: "theme=dark"},
+			{Name: "sessionid", Value: "abc123", Domain: ".example.com", Path: "/", Expires: time.Unix(1234567890, 0)},
+			{Name: "authtoken", Value: "xyz789", Domain: ".example.com", Path: "/api", Secure: true, Expires: time.Unix(1234567891, 0)},
+			{Name: "preferences", Value: "theme=dark", Domain: ".google.com", Path: "/search"},
 		}
 
 		assert.Equal(t, expectedCookies, cookies)
@@ -103,9 +112,9 @@ invalid	line
 		assert.Len(t, cookies, 3)
 
 		expectedCookies := []Cookie{
-			{Name: "sessionid", Value: "abc123"},
-			{Name: "preferences", Value: "theme=dark"},
-			{Name: "testcookie", Value: "testvalue"},
+			{Name: "sessionid", Value: "abc123", Domain: ".example.com", Path: "/", Expires: time.Unix(1234567890, 0)},
+			{Name: "preferences", Value: "theme=dark", Domain: ".google.com", Path: "/search"},
+			{Name: "testcookie", Value: "testvalue", Domain: ".test.com", Path: "/", Secure: true, Expires: time.Unix(1234567892, 0)},
 		}
 
 		assert.Equal(t, expectedCookies, cookies)
@@ -125,9 +134,9 @@ invalid	line
 		assert.Len(t, cookies, 3)
 
 		expectedCookies := []Cookie{
-			{Name: "", Value: ""},
-			{Name: "emptycookie", Value: ""},
-			{Name: "normalcookie", Value: "normalvalue"},
+			{Name: "", Value: "", Domain: ".example.com", Path: "/", Expires: time.Unix(1234567890, 0)},
+			{Name: "emptycookie", Value: "", Domain: ".google.com", Path: "/search"},
+			{Name: "normalcookie", Value: "normalvalue", Domain: ".test.com", Path: "/", Secure: true, Expires: time.Unix(1234567892, 0)},
 		}
 
 		assert.Equal(t, expectedCookies, cookies)
@@ -147,9 +156,9 @@ invalid	line
 		assert.Len(t, cookies, 3)
 
 		expectedCookies := []Cookie{
-			{Name: "special_cookie-1", Value: "value with spaces"},
-			{Name: "cookie.with.dots", Value: "value=with=equals"},
-			{Name: "cookie-with-dashes", Value: "value;with;semicolons"},
+			{Name: "special_cookie-1", Value: "value with spaces", Domain: ".example.com", Path: "/", Expires: time.Unix(1234567890, 0)},
+			{Name: "cookie.with.dots", Value: "value=with=equals", Domain: ".example.com", Path: "/api", Secure: true, Expires: time.Unix(1234567891, 0)},
+			{Name: "cookie-with-dashes", Value: "value;with;semicolons", Domain: ".google.com", Path: "/search"},
 		}
 
 		assert.Equal(t, expectedCookies, cookies)
@@ -195,14 +204,69 @@ invalid	line
 		assert.Len(t, cookies, 2)
 
 		expectedCookies := []Cookie{
-			{Name: "sessionid", Value: "abc123"},
-			{Name: "preferences", Value: "theme=dark"},
+			{Name: "sessionid", Value: "abc123", Domain: ".example.com", Path: "/", Expires: time.Unix(1234567890, 0)},
+			{Name: "preferences", Value: "theme=dark", Domain: ".google.com", Path: "/search"},
 		}
 
 		assert.Equal(t, expectedCookies, cookies)
 	})
 }
 
+func TestWriteFileCookies(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		content := `# Netscape HTTP Cookie File
+.example.com	TRUE	/	FALSE	1234567890	sessionid	abc123
+.example.com	TRUE	/api	TRUE	1234567891	authtoken	xyz789
+google.com	FALSE	/search	FALSE	0	preferences	theme=dark
+`
+		source := createTempFile(t, content)
+
+		cookies, err := GetFileCookies(source)
+		require.NoError(t, err)
+
+		target := filepath.Join(t.TempDir(), "written.txt")
+		err = WriteFileCookies(target, cookies)
+		require.NoError(t, err)
+
+		written, err := os.ReadFile(target)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(written))
+
+		reread, err := GetFileCookies(target)
+		require.NoError(t, err)
+		assert.Equal(t, cookies, reread)
+	})
+
+	t.Run("MinimalCookies", func(t *testing.T) {
+		target := filepath.Join(t.TempDir(), "minimal.txt")
+		err := WriteFileCookies(target, []Cookie{{Name: "a", Value: "1"}})
+		require.NoError(t, err)
+
+		written, err := os.ReadFile(target)
+		require.NoError(t, err)
+		assert.Equal(t, "# Netscape HTTP Cookie File\n\tFALSE\t/\tFALSE\t0\ta\t1\n", string(written))
+
+		cookies, err := GetFileCookies(target)
+		require.NoError(t, err)
+		assert.Equal(t, []Cookie{{Name: "a", Value: "1", Path: "/"}}, cookies)
+	})
+
+	t.Run("EmptySlice", func(t *testing.T) {
+		target := filepath.Join(t.TempDir(), "empty.txt")
+		err := WriteFileCookies(target, nil)
+		require.NoError(t, err)
+
+		cookies, err := GetFileCookies(target)
+		require.NoError(t, err)
+		assert.Empty(t, cookies)
+	})
+
+	t.Run("InvalidPath", func(t *testing.T) {
+		err := WriteFileCookies("/path/to/nonexistent/dir/cookies.txt", nil)
+		assert.Error(t, err)
+	})
+}
+
 func TestCookiesToHeader(t *testing.T) {
 	t.Run("EmptySlice", func(t *testing.T) {
 		cookies := []Cookie{}
@@ -328,3 +392,143 @@ func createTempFile(t *testing.T, content string) string {
 
 	return tempFile
 }
+
+func newSessionServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123", Path: "/"})
+			w.WriteHeader(http.StatusOK)
+		case "/data":
+			cookie, err := r.Cookie("session")
+			if err != nil || cookie.Value != "abc123" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("secret data"))
+		}
+	}))
+}
+
+func TestNewWithCookies(t *testing.T) {
+	t.Run("SeededCookiesAreSent", func(t *testing.T) {
+		server := newSessionServer(t)
+		defer server.Close()
+
+		f, err := NewWithCookies(nil, 0, false, server.URL, []Cookie{{Name: "session", Value: "abc123"}})
+		require.NoError(t, err)
+
+		result, err := f.GetText(context.Background(), server.URL+"/data")
+		assert.NoError(t, err)
+		assert.Equal(t, "secret data", result)
+	})
+
+	t.Run("SeededCookiesAreSentOnDownloads", func(t *testing.T) {
+		server := newSessionServer(t)
+		defer server.Close()
+
+		f, err := NewWithCookies(nil, 0, false, server.URL, []Cookie{{Name: "session", Value: "abc123"}})
+		require.NoError(t, err)
+
+		filePath := filepath.Join(t.TempDir(), "data.txt")
+		req, err := f.NewRequest(server.URL+"/data", filePath, nil)
+		require.NoError(t, err)
+		require.NoError(t, f.DownloadFile(req).Error())
+
+		data, err := os.ReadFile(filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret data", string(data))
+	})
+
+	t.Run("SeededCookieAttributesAreKept", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			names := lo.Map(r.Cookies(), func(c *http.Cookie, _ int) string { return c.Name })
+			slices.Sort(names)
+			w.Write([]byte(strings.Join(names, ",")))
+		}))
+		defer server.Close()
+
+		f, err := NewWithCookies(nil, 0, false, server.URL, []Cookie{
+			{Name: "plain", Value: "1"},
+			{Name: "secure", Value: "2", Secure: true},
+			{Name: "scoped", Value: "3", Path: "/api"},
+			{Name: "expired", Value: "4", Expires: time.Now().Add(-time.Hour)},
+		})
+		require.NoError(t, err)
+
+		// Loopback counts as a secure origin, so the Secure cookie is sent too
+		result, err := f.GetText(context.Background(), server.URL+"/")
+		require.NoError(t, err)
+		assert.Equal(t, "plain,secure", result)
+
+		result, err = f.GetText(context.Background(), server.URL+"/api/data")
+		require.NoError(t, err)
+		assert.Equal(t, "plain,scoped,secure", result)
+	})
+
+	t.Run("SeededSecureCookieIsHttpsOnly", func(t *testing.T) {
+		f, err := NewWithCookies(nil, 0, false, "https://example.com", []Cookie{
+			{Name: "plain", Value: "1"},
+			{Name: "secure", Value: "2", Secure: true},
+		})
+		require.NoError(t, err)
+
+		names := func(rawUrl string) []string {
+			u, err := url.Parse(rawUrl)
+			require.NoError(t, err)
+			return lo.Map(f.httpClient.Jar.Cookies(u), func(c *http.Cookie, _ int) string { return c.Name })
+		}
+
+		assert.ElementsMatch(t, []string{"plain", "secure"}, names("https://example.com"))
+		assert.ElementsMatch(t, []string{"plain"}, names("http://example.com"))
+	})
+
+	t.Run("InvalidURL", func(t *testing.T) {
+		f, err := NewWithCookies(nil, 0, false, "://invalid", nil)
+		assert.Error(t, err)
+		assert.Nil(t, f)
+	})
+}
+
+func TestSetCookieJar(t *testing.T) {
+	t.Run("LoginThenFetch", func(t *testing.T) {
+		server := newSessionServer(t)
+		defer server.Close()
+
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+
+		f := New(nil, 0, false)
+		f.SetCookieJar(jar)
+
+		_, err = f.GetText(context.Background(), server.URL+"/login")
+		require.NoError(t, err)
+
+		// The cookie set by the login response is also used by the download client
+		filePath := filepath.Join(t.TempDir(), "data.txt")
+		req, err := f.NewRequest(server.URL+"/data", filePath, nil)
+		require.NoError(t, err)
+
+		response := f.DownloadFile(req)
+		require.NoError(t, response.Error())
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("NilJarDisablesCookies", func(t *testing.T) {
+		server := newSessionServer(t)
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		f.SetCookieJar(nil)
+
+		_, err := f.GetText(context.Background(), server.URL+"/login")
+		require.NoError(t, err)
+
+		_, err = f.GetText(context.Background(), server.URL+"/data")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "401")
+	})
+}
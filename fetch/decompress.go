@@ -0,0 +1,134 @@
+package fetch
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// SetAutoDecompress enables or disables transparent decompression of gzip, deflate and brotli response bodies for
+// GetText, GetResult and the other REST methods, based on the Content-Encoding header. When enabled, the client also
+// advertises these encodings in Accept-Encoding unless the header was set explicitly. Bodies are decoded as they are
+// read, so the compressed payload is never buffered on its own.
+//
+// It's disabled by default. Downloads are never decompressed, so compressed files are saved as they are served.
+//
+// Parameters:
+//   - enabled: whether response bodies should be decompressed.
+func (f *Fetch) SetAutoDecompress(enabled bool) {
+	client := f.restClient.GetClient()
+	current, wrapped := client.Transport.(*decompressTransport)
+
+	switch {
+	case enabled && !wrapped:
+		base := client.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		client.Transport = &decompressTransport{base: base}
+	case !enabled && wrapped:
+		client.Transport = current.base
+	}
+}
+
+// region - Private functions
+
+// decompressTransport is a http.RoundTripper that decodes compressed response bodies on the fly.
+type decompressTransport struct {
+	base http.RoundTripper
+}
+
+func (t *decompressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", "gzip, deflate, br")
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	noBody := req.Method == http.MethodHead || resp.StatusCode == http.StatusNoContent ||
+		resp.StatusCode == http.StatusNotModified || resp.ContentLength == 0
+
+	if noBody || !isSupportedEncoding(encoding) {
+		return resp, nil
+	}
+
+	resp.Body = &decodingReader{encoding: encoding, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	return resp, nil
+}
+
+func isSupportedEncoding(encoding string) bool {
+	switch encoding {
+	case "gzip", "x-gzip", "deflate", "br":
+		return true
+	default:
+		return false
+	}
+}
+
+// decodingReader lazily wraps the body with the right decoder on the first Read, so empty bodies don't fail.
+type decodingReader struct {
+	encoding string
+	body     io.ReadCloser
+	decoder  io.Reader
+	err      error
+}
+
+func (r *decodingReader) Read(p []byte) (int, error) {
+	if r.decoder == nil && r.err == nil {
+		r.decoder, r.err = newDecoder(r.encoding, r.body)
+	}
+
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	return r.decoder.Read(p)
+}
+
+func (r *decodingReader) Close() error {
+	if closer, ok := r.decoder.(io.Closer); ok {
+		closer.Close()
+	}
+
+	return r.body.Close()
+}
+
+func newDecoder(encoding string, body io.Reader) (io.Reader, error) {
+	switch encoding {
+	case "gzip", "x-gzip":
+		return gzip.NewReader(body)
+	case "br":
+		return brotli.NewReader(body), nil
+	default:
+		// "deflate" should be zlib-wrapped, but some servers send a raw deflate stream
+		buffered := bufio.NewReader(body)
+		header, err := buffered.Peek(2)
+		if err == nil && isZlibHeader(header) {
+			return zlib.NewReader(buffered)
+		}
+
+		return flate.NewReader(buffered), nil
+	}
+}
+
+func isZlibHeader(header []byte) bool {
+	return header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0
+}
+
+// endregion
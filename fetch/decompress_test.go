@@ -0,0 +1,135 @@
+package fetch
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compress(t *testing.T, encoding string, data string) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	var w io.WriteCloser
+
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buffer)
+	case "deflate":
+		w = zlib.NewWriter(&buffer)
+	case "raw-deflate":
+		fw, err := flate.NewWriter(&buffer, flate.DefaultCompression)
+		require.NoError(t, err)
+		w = fw
+	case "br":
+		w = brotli.NewWriter(&buffer)
+	}
+
+	_, err := w.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buffer.Bytes()
+}
+
+func TestFetch_SetAutoDecompress(t *testing.T) {
+	expected := strings.Repeat("Hello, compressed World!\n", 99) + "The end."
+
+	tests := []struct {
+		name       string
+		compressor string
+		header     string
+	}{
+		{name: "gzip", compressor: "gzip", header: "gzip"},
+		{name: "deflate (zlib)", compressor: "deflate", header: "deflate"},
+		{name: "deflate (raw)", compressor: "raw-deflate", header: "deflate"},
+		{name: "brotli", compressor: "br", header: "br"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := compress(t, tt.compressor, expected)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "gzip, deflate, br", r.Header.Get("Accept-Encoding"))
+				w.Header().Set("Content-Encoding", tt.header)
+				w.WriteHeader(http.StatusOK)
+				w.Write(body)
+			}))
+			defer server.Close()
+
+			f := New(nil, 0, false)
+			f.SetAutoDecompress(true)
+
+			result, err := f.GetText(context.Background(), server.URL)
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, result)
+		})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		body := compress(t, "br", expected)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "br")
+			w.WriteHeader(http.StatusOK)
+			w.Write(body)
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		result, err := f.GetText(context.Background(), server.URL)
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(body), result)
+	})
+
+	t.Run("can be disabled again", func(t *testing.T) {
+		f := New(nil, 0, false)
+		original := f.restClient.GetClient().Transport
+
+		f.SetAutoDecompress(true)
+		f.SetAutoDecompress(true)
+		_, wrapped := f.restClient.GetClient().Transport.(*decompressTransport)
+		assert.True(t, wrapped)
+
+		f.SetAutoDecompress(false)
+		assert.Equal(t, original, f.restClient.GetClient().Transport)
+	})
+
+	t.Run("uncompressed and empty responses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/empty" {
+				w.Header().Set("Content-Encoding", "gzip")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("plain"))
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		f.SetAutoDecompress(true)
+
+		result, err := f.GetText(context.Background(), server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "plain", result)
+
+		result, err = f.GetText(context.Background(), server.URL+"/empty")
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
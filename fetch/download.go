@@ -3,15 +3,24 @@ package fetch
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
+	"math"
+	"math/rand/v2"
+	"mime"
 	"net/http"
+	"net/url"
 	"os"
+	"path"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
-	"github.com/zeebo/blake3"
+	"golang.org/x/time/rate"
 )
 
 // NewRequest creates a new download request with the specified URL and file path.
@@ -49,80 +58,70 @@ func (f *Fetch) NewRequest(url string, filePath string, headers map[string]strin
 	}, nil
 }
 
-// DownloadFile downloads a single file based on the provided request.
+// NewRequestWithMirrors creates a new download request for a file that is available from several URLs. The first URL
+// is the primary one; the others are mirrors that DownloadFile falls back to, in order, when a URL still fails after
+// exhausting the retries. Data already written by a failed URL is kept, so the download resumes against the next one.
 //
 // Parameters:
-//   - request: a Request object containing the details of the file to download.
+//   - urls: The URLs to download the file from, starting with the primary one.
+//   - filePath: The path where the downloaded file will be saved.
+//   - headers: Optional headers to set on the request.
 //
 // Returns:
-//   - A Response object that contains the status and details of the download process.
-func (f *Fetch) DownloadFile(request *Request) *Response {
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Tie the http.Request to the context.
-	// It means that if the context is canceled, the request will be canceled too.
-	request.httpReq = request.httpReq.WithContext(ctx)
-
-	response := &Response{
-		Request: request,
-		Done:    make(chan struct{}, 1),
-		cancel:  cancel,
+//   - A Request object containing the URLs and file path.
+//   - An error if no URL is provided or the request creation fails.
+func (f *Fetch) NewRequestWithMirrors(urls []string, filePath string, headers map[string]string) (*Request, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("failed to create request: no URL provided")
 	}
 
-	go func() {
-		defer close(response.Done)
-
-		// How many bytes are already on the disk?
-		var offset int64
-		if info, err := os.Stat(request.FilePath); err == nil {
-			offset = info.Size()
-		}
+	request, err := f.NewRequest(urls[0], filePath, headers)
+	if err != nil {
+		return nil, err
+	}
 
-		// Open (or create) a file for appending and reading (needed to hash existing bytes)
-		file, err := os.OpenFile(request.FilePath, os.O_CREATE|os.O_RDWR, 0o644)
-		if err != nil {
-			response.err = fmt.Errorf("could not open file: %w", err)
-			return
-		}
-
-		defer file.Close()
-		hasher := blake3.New()
-
-		if offset > 0 {
-			if _, hashErr := io.CopyN(hasher, file, offset); hashErr != nil {
-				response.err = fmt.Errorf("could not hash existing data: %w", hashErr)
-				return
-			}
-
-			// Seek to the end of existing data
-			if _, fErr := file.Seek(offset, io.SeekStart); fErr != nil {
-				response.err = fmt.Errorf("could not seek: %w", fErr)
-				return
-			}
+	for _, mirror := range urls[1:] {
+		if _, err = url.Parse(mirror); err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
+	}
 
-		// Set up the progress callback
-		pw := &progressWriter{
-			file:   file,
-			hasher: hasher,
-			callback: func(downloaded int64) {
-				response.Downloaded += downloaded
-				if response.Size > 0 {
-					response.Progress = float64(response.Downloaded) / float64(response.Size)
-				}
-			},
-		}
+	request.Mirrors = urls[1:]
+	return request, nil
+}
 
-		// Perform the download (with resume & retries)
-		f.downloadWithRetries(response, offset, file, pw, ctx)
+// DownloadFile downloads a single file based on the provided request.
+//
+// Parameters:
+//   - request: a Request object containing the details of the file to download.
+//
+// Returns:
+//   - A Response object that contains the status and details of the download process.
+func (f *Fetch) DownloadFile(request *Request) *Response {
+	response, ctx := f.newResponse(request)
 
-		sum := hasher.Sum(nil)
-		response.Hash = hex.EncodeToString(sum)
+	go func() {
+		defer close(response.Done)
+		f.download(ctx, response)
+		f.finishDownload(response)
 	}()
 
 	return response
 }
 
+// DownloadFileWithProgress downloads a single file like DownloadFile, invoking the callback as bytes arrive.
+//
+// Parameters:
+//   - request: a Request object containing the details of the file to download.
+//   - callback: a function that receives the bytes downloaded so far and the total size of the file.
+//
+// Returns:
+//   - A Response object that contains the status and details of the download process.
+func (f *Fetch) DownloadFileWithProgress(request *Request, callback func(downloaded, total int64)) *Response {
+	request.OnProgress = callback
+	return f.DownloadFile(request)
+}
+
 // DownloadFiles downloads multiple files concurrently.
 //
 // Parameters:
@@ -201,15 +200,300 @@ func (f *Fetch) DownloadFiles(requests []*Request, parallel int) (<-chan *Respon
 
 // region - Private functions
 
+// newResponse creates the Response for a download, along with the context that bounds it.
+func (f *Fetch) newResponse(request *Request) (*Response, context.Context) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+
+	if f.timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), f.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+
+	// Tie the http.Request to the context.
+	// It means that if the context is canceled, the request will be canceled too.
+	request.httpReq = request.httpReq.WithContext(ctx)
+
+	response := &Response{
+		Request: request,
+		Url:     request.Url,
+		Done:    make(chan struct{}, 1),
+		cancel:  cancel,
+	}
+
+	return response, ctx
+}
+
+// download performs a sequential download of the request into its file, resuming from any bytes already on disk.
+func (f *Fetch) download(ctx context.Context, response *Response) {
+	request := response.Request
+
+	hasher, err := request.newHasher()
+	if err != nil {
+		response.err = err
+		return
+	}
+
+	if err = f.resolveFilePath(ctx, request); err != nil {
+		response.err = err
+		return
+	}
+
+	// How many bytes are already on the disk?
+	var offset int64
+	if info, err := os.Stat(request.FilePath); err == nil {
+		offset = info.Size()
+	}
+
+	// Open (or create) a file for appending and reading (needed to hash existing bytes)
+	file, err := os.OpenFile(request.FilePath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		response.err = fmt.Errorf("could not open file: %w", err)
+		return
+	}
+
+	defer file.Close()
+
+	if offset > 0 {
+		if _, hashErr := io.CopyN(hasher, file, offset); hashErr != nil {
+			response.err = fmt.Errorf("could not hash existing data: %w", hashErr)
+			return
+		}
+
+		// Seek to the end of existing data
+		if _, fErr := file.Seek(offset, io.SeekStart); fErr != nil {
+			response.err = fmt.Errorf("could not seek: %w", fErr)
+			return
+		}
+	}
+
+	// Set up the progress callback
+	pw := &progressWriter{
+		file:     file,
+		hasher:   hasher,
+		callback: trackProgress(response),
+	}
+
+	// Perform the download (with resume & retries), falling back to the mirrors
+	f.downloadFromMirrors(ctx, response, offset, file, hasher, f.limitSize(response, throttle(ctx, pw, f.limiters())))
+
+	// Don't leave a truncated file behind when it was too large
+	if isMaxSizeError(response.err) {
+		file.Close()
+		os.Remove(request.FilePath)
+		return
+	}
+
+	sum := hasher.Sum(nil)
+	response.Hash = hex.EncodeToString(sum)
+}
+
+// downloadFromMirrors downloads the request from its primary URL and then from each mirror in turn, until one of them
+// succeeds. The request's primary http.Request is restored afterward.
+func (f *Fetch) downloadFromMirrors(
+	ctx context.Context,
+	response *Response,
+	offset int64,
+	file *os.File,
+	hasher hash.Hash,
+	writer io.Writer,
+) {
+	request := response.Request
+	primary := request.httpReq
+	defer func() { request.httpReq = primary }()
+
+	for i, mirror := range append([]string{request.Url}, request.Mirrors...) {
+		if i > 0 {
+			log.WithFields(log.Fields{
+				"error":  response.err,
+				"status": response.StatusCode,
+				"url":    response.Url,
+			}).Warn("failed to download file; trying mirror ", mirror)
+
+			var err error
+			if offset, err = rewind(file, hasher, offset, response.err == nil); err != nil {
+				response.err = err
+				return
+			}
+
+			mirrorUrl, _ := url.Parse(mirror)
+			req := primary.Clone(ctx)
+			req.URL = mirrorUrl
+			req.Host = ""
+
+			request.httpReq = req
+			response.StatusCode = 0
+			response.err = nil
+		}
+
+		response.Url = mirror
+		f.downloadWithRetries(response, offset, file, hasher, writer, ctx)
+
+		// Neither a canceled download nor a file that's too large will go any better on a mirror
+		if ctx.Err() != nil || isMaxSizeError(response.err) {
+			return
+		}
+
+		notFound := response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusGone
+		if response.err == nil && !notFound {
+			return
+		}
+	}
+}
+
+// rewind prepares the file for the next mirror. When the previous URL failed mid-way, the data it wrote is kept so the
+// next one can resume from it; when it answered with an error page instead, the file is truncated back to offset. The
+// hasher is rebuilt from the bytes that are kept, and the new offset is returned.
+func rewind(file *os.File, hasher hash.Hash, offset int64, discard bool) (int64, error) {
+	if discard {
+		if err := file.Truncate(offset); err != nil {
+			return 0, fmt.Errorf("truncate failed: %w", err)
+		}
+	} else {
+		end, err := file.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, fmt.Errorf("could not seek: %w", err)
+		}
+
+		offset = end
+	}
+
+	hasher.Reset()
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("could not seek: %w", err)
+	}
+	if _, err := io.CopyN(hasher, file, offset); err != nil {
+		return 0, fmt.Errorf("could not hash existing data: %w", err)
+	}
+
+	return offset, nil
+}
+
+// resolveFilePath handles requests whose FilePath is a directory: the file name is taken from the server's
+// Content-Disposition header, falling back to the last segment of the URL path.
+func (f *Fetch) resolveFilePath(ctx context.Context, request *Request) error {
+	info, err := os.Stat(request.FilePath)
+	if err != nil || !info.IsDir() {
+		return nil
+	}
+
+	name := ""
+
+	req := request.httpReq.Clone(ctx)
+	req.Method = http.MethodHead
+	req.Header.Del("Range")
+
+	if resp, headErr := f.httpClient.Do(req); headErr == nil {
+		resp.Body.Close()
+		name = filenameFromContentDisposition(resp.Header.Get("Content-Disposition"))
+	}
+
+	if name == "" {
+		name = sanitizeFilename(path.Base(request.httpReq.URL.Path))
+	}
+
+	if name == "" {
+		return fmt.Errorf("could not determine a file name for %s", request.Url)
+	}
+
+	request.FilePath = filepath.Join(request.FilePath, name)
+	return nil
+}
+
+// filenameFromContentDisposition extracts the file name from a Content-Disposition header, supporting quoted names and
+// the RFC 5987 extended `filename*` parameter. It returns an empty string if no usable name is found.
+func filenameFromContentDisposition(header string) string {
+	if header == "" {
+		return ""
+	}
+
+	_, params, err := mime.ParseMediaType(header)
+	if err != nil {
+		return ""
+	}
+
+	return sanitizeFilename(params["filename"])
+}
+
+// sanitizeFilename strips any directory components so a server can't make us write outside the target directory.
+func sanitizeFilename(name string) string {
+	name = filepath.Base(filepath.FromSlash(strings.ReplaceAll(name, "\\", "/")))
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		return ""
+	}
+
+	return name
+}
+
+// trackProgress returns a progressWriter callback that updates the response and notifies the request's OnProgress.
+func trackProgress(response *Response) func(downloaded int64) {
+	return func(downloaded int64) {
+		response.Downloaded += downloaded
+		if response.Size > 0 {
+			response.Progress = float64(response.Downloaded) / float64(response.Size)
+		}
+		if response.Request.OnProgress != nil {
+			response.Request.OnProgress(response.Downloaded, response.Size)
+		}
+	}
+}
+
+// limiters returns the bandwidth limiters that apply to a single download: a new per-download limiter, if configured,
+// and the limiter shared by every download of this Fetch instance.
+func (f *Fetch) limiters() []*rate.Limiter {
+	limiters := make([]*rate.Limiter, 0, 2)
+
+	if limiter := newByteLimiter(f.maxBytesPerSecond); limiter != nil {
+		limiters = append(limiters, limiter)
+	}
+	if f.sharedLimiter != nil {
+		limiters = append(limiters, f.sharedLimiter)
+	}
+
+	return limiters
+}
+
+// throttle wraps the writer with the given bandwidth limiters, if any.
+func throttle(ctx context.Context, writer io.Writer, limiters []*rate.Limiter) io.Writer {
+	if len(limiters) == 0 {
+		return writer
+	}
+
+	return &throttledWriter{ctx: ctx, writer: writer, limiters: limiters}
+}
+
+// limitSize wraps the writer so the download fails once it exceeds the limit set by SetMaxBytes, if any.
+func (f *Fetch) limitSize(response *Response, writer io.Writer) io.Writer {
+	if f.maxBytes <= 0 {
+		return writer
+	}
+
+	return &limitWriter{writer: writer, response: response, limit: f.maxBytes}
+}
+
+// finishDownload normalizes timeout errors and verifies the expected checksum, if any.
+func (f *Fetch) finishDownload(response *Response) {
+	if errors.Is(response.err, context.DeadlineExceeded) {
+		response.err = fmt.Errorf("download timed out after %s: %w", f.timeout, context.DeadlineExceeded)
+	}
+
+	if response.err == nil {
+		response.err = verifyHash(response.Request, response.Hash)
+	}
+}
+
 func (f *Fetch) downloadWithRetries(
 	response *Response,
 	offset int64,
 	file *os.File,
+	hasher hash.Hash,
 	writer io.Writer,
 	ctx context.Context,
 ) {
 	var resp *http.Response
 	var err error
+	var wait time.Duration
 
 	for attempt := 0; attempt <= f.retries; attempt++ {
 		// Before each attempt, see if we've been canceled
@@ -221,12 +505,15 @@ func (f *Fetch) downloadWithRetries(
 		}
 
 		if attempt > 0 {
-			backoff := time.Duration(fibonacci(attempt+1)) * time.Second
+			backoff := f.backoff(attempt)
+			if wait > 0 {
+				backoff, wait = wait, 0
+			}
 
 			log.WithFields(log.Fields{
 				"attempt": attempt,
 				"error":   err,
-				"url":     response.Request.Url,
+				"url":     response.Url,
 			}).Warn("failed to download file; retrying in ", backoff)
 
 			time.Sleep(backoff)
@@ -246,25 +533,33 @@ func (f *Fetch) downloadWithRetries(
 			continue
 		}
 
-		// Fallback if server doesn’t support Range
-		if isRangeReq && resp.StatusCode == http.StatusOK {
-			// Truncate file and reset offset
-			if tErr := file.Truncate(0); tErr != nil {
-				response.StatusCode = resp.StatusCode
-				response.Size = 0
-				response.err = fmt.Errorf("truncate failed: %w", tErr)
+		// Fallback if server doesn’t support Range, or if the range it sent doesn't start where the file ends, which
+		// means the remote file changed; appending to the partial file would corrupt it
+		restart := isRangeReq && resp.StatusCode == http.StatusOK
+		if isRangeReq && resp.StatusCode == http.StatusPartialContent {
+			if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); !ok || start != offset {
+				log.WithFields(log.Fields{
+					"contentRange": resp.Header.Get("Content-Range"),
+					"offset":       offset,
+					"url":          response.Url,
+				}).Warn("resumed range doesn't match the partial file; restarting the download from scratch")
+
+				restart = true
 			}
+		}
 
-			offset = 0
+		if restart {
+			resp.Body.Close()
 
-			if _, sErr := file.Seek(0, io.SeekStart); sErr != nil {
+			// Truncate the file and reset the offset and the hash
+			if offset, err = rewind(file, hasher, 0, true); err != nil {
 				response.StatusCode = resp.StatusCode
 				response.Size = 0
-				response.err = fmt.Errorf("seek after truncate failed: %w", sErr)
+				response.err = err
+				break
 			}
 
 			attempt-- // retry same attempt count with fresh download
-			resp.Body.Close()
 			continue
 		}
 
@@ -285,6 +580,12 @@ func (f *Fetch) downloadWithRetries(
 		if resp.StatusCode != 404 && resp.StatusCode != 410 && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
 			response.err = fmt.Errorf("unexpected status: %d", resp.StatusCode)
 			resp.Body.Close()
+
+			if !f.isRetryableStatus(resp.StatusCode) {
+				break
+			}
+
+			wait = retryAfter(resp.StatusCode, resp.Header, f.retryAfterLimit())
 			continue
 		}
 
@@ -301,12 +602,27 @@ func (f *Fetch) downloadWithRetries(
 			response.Size = offset + resp.ContentLength
 		}
 
+		// Fail early when the server already tells us the file is too large
+		if f.maxBytes > 0 && response.Size > f.maxBytes {
+			response.StatusCode = resp.StatusCode
+			response.err = &maxSizeError{limit: f.maxBytes}
+			resp.Body.Close()
+			break
+		}
+
 		// Track where this attempt started
 		startOffset := offset
 
 		// Actually copy data
 		_, err = io.Copy(writer, resp.Body)
 		if err != nil {
+			if isMaxSizeError(err) {
+				response.StatusCode = resp.StatusCode
+				response.err = err
+				resp.Body.Close()
+				break
+			}
+
 			if ctx.Err() != nil {
 				response.err = ctx.Err()
 				resp.Body.Close()
@@ -342,6 +658,78 @@ func (f *Fetch) downloadWithRetries(
 	}
 }
 
+// contentRangeStart returns the first byte position of a Content-Range header like "bytes 500-999/1234".
+func contentRangeStart(header string) (int64, bool) {
+	var start, end int64
+	var total string
+
+	if _, err := fmt.Sscanf(header, "bytes %d-%d/%s", &start, &end, &total); err != nil {
+		return 0, false
+	}
+
+	return start, true
+}
+
+func verifyHash(request *Request, hash string) error {
+	if request.expectedHash == "" {
+		return nil
+	}
+
+	if _, ok := hashAlgos[request.expectedAlgo]; !ok {
+		return fmt.Errorf("unsupported hash algorithm: %s", request.expectedAlgo)
+	}
+
+	if request.expectedAlgo != request.hashAlgo() {
+		return fmt.Errorf("cannot verify a %s checksum: the download was hashed with %s", request.expectedAlgo,
+			request.hashAlgo())
+	}
+
+	if hash != request.expectedHash {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", request.expectedHash, hash)
+	}
+
+	return nil
+}
+
+// backoff returns the delay before the given retry attempt (starting at 1). Without a RetryConfig, the delays follow the
+// Fibonacci sequence in seconds.
+func (f *Fetch) backoff(attempt int) time.Duration {
+	if f.retryConfig == nil {
+		return time.Duration(fibonacci(attempt+1)) * time.Second
+	}
+
+	config := f.retryConfig
+	delay := float64(config.BaseDelay)
+	if config.Multiplier > 1 && delay > 0 {
+		delay *= math.Pow(config.Multiplier, float64(attempt-1))
+	}
+
+	if config.MaxDelay > 0 && delay > float64(config.MaxDelay) {
+		delay = float64(config.MaxDelay)
+	}
+
+	if config.Jitter && delay > 0 {
+		delay = delay/2 + rand.Float64()*delay/2
+	}
+
+	// Without a MaxDelay, the delay can grow past what a time.Duration holds (or to +Inf) after many attempts
+	if delay >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(delay)
+}
+
+// retryAfterLimit returns the longest wait a Retry-After header can impose: the MaxDelay of the RetryConfig when there
+// is one, or maxRetryAfter otherwise.
+func (f *Fetch) retryAfterLimit() time.Duration {
+	if f.retryConfig != nil && f.retryConfig.MaxDelay > 0 {
+		return f.retryConfig.MaxDelay
+	}
+
+	return maxRetryAfter
+}
+
 func fibonacci(n int) int {
 	if n <= 1 {
 		return n
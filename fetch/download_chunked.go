@@ -0,0 +1,271 @@
+package fetch
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+// DownloadFileChunked downloads a single file by splitting it into byte ranges that are fetched concurrently and
+// written in place. It falls back to a regular DownloadFile when the server doesn't advertise `Accept-Ranges: bytes`,
+// doesn't report a Content-Length, or when fewer than two chunks are requested.
+//
+// Unlike DownloadFile, a chunked download always starts from scratch; any existing file is overwritten.
+//
+// Parameters:
+//   - request: a Request object containing the details of the file to download.
+//   - chunks: the number of byte ranges to download concurrently.
+//
+// Returns:
+//   - A Response object that contains the status and details of the download process.
+func (f *Fetch) DownloadFileChunked(request *Request, chunks int) *Response {
+	response, ctx := f.newResponse(request)
+
+	go func() {
+		defer close(response.Done)
+
+		size, ok := f.probeRanges(ctx, request)
+		if int64(chunks) > size {
+			chunks = int(size)
+		}
+
+		if !ok || chunks <= 1 {
+			f.download(ctx, response)
+		} else {
+			f.downloadChunks(ctx, response, size, chunks)
+		}
+
+		f.finishDownload(response)
+	}()
+
+	return response
+}
+
+// region - Private functions
+
+// probeRanges sends a HEAD request to find out whether the server supports byte ranges, returning the file size.
+func (f *Fetch) probeRanges(ctx context.Context, request *Request) (int64, bool) {
+	req := request.httpReq.Clone(ctx)
+	req.Method = http.MethodHead
+	req.Header.Del("Range")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return 0, false
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Accept-Ranges") != "bytes" || resp.ContentLength <= 0 {
+		return 0, false
+	}
+
+	return resp.ContentLength, true
+}
+
+func (f *Fetch) downloadChunks(ctx context.Context, response *Response, size int64, chunks int) {
+	request := response.Request
+
+	hasher, err := request.newHasher()
+	if err != nil {
+		response.err = err
+		return
+	}
+
+	if err = f.resolveFilePath(ctx, request); err != nil {
+		response.err = err
+		return
+	}
+
+	// The exact size is known up front, so there's no need to start downloading a file that is too large
+	if f.maxBytes > 0 && size > f.maxBytes {
+		response.err = &maxSizeError{limit: f.maxBytes}
+		return
+	}
+
+	file, err := os.OpenFile(request.FilePath, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0o644)
+	if err != nil {
+		response.err = fmt.Errorf("could not open file: %w", err)
+		return
+	}
+
+	defer file.Close()
+
+	if err = file.Truncate(size); err != nil {
+		response.err = fmt.Errorf("could not allocate file: %w", err)
+		return
+	}
+
+	response.Size = size
+
+	// Cancel the remaining chunks as soon as one of them fails
+	chunkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = make([]error, chunks)
+	)
+
+	progress := func(downloaded int64) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		response.Downloaded += downloaded
+		response.Progress = float64(response.Downloaded) / float64(response.Size)
+		if request.OnProgress != nil {
+			request.OnProgress(response.Downloaded, response.Size)
+		}
+	}
+
+	limiters := f.limiters()
+	chunkSize := size / int64(chunks)
+	for i := 0; i < chunks; i++ {
+		start := int64(i) * chunkSize
+		end := start + chunkSize - 1
+		if i == chunks-1 {
+			end = size - 1
+		}
+
+		wg.Add(1)
+
+		go func(i int, start, end int64) {
+			defer wg.Done()
+
+			if chunkErr := f.downloadChunk(chunkCtx, request, file, start, end, limiters, progress); chunkErr != nil {
+				errs[i] = chunkErr
+				cancel()
+			}
+		}(i, start, end)
+	}
+
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		response.err = ctx.Err()
+		return
+	}
+
+	for _, chunkErr := range errs {
+		if chunkErr != nil && !errors.Is(chunkErr, context.Canceled) {
+			response.err = chunkErr
+			return
+		}
+	}
+
+	// Hash the reassembled file
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		response.err = fmt.Errorf("could not seek: %w", err)
+		return
+	}
+	if _, err = io.Copy(hasher, file); err != nil {
+		response.err = fmt.Errorf("could not hash downloaded data: %w", err)
+		return
+	}
+
+	response.Hash = hex.EncodeToString(hasher.Sum(nil))
+	response.StatusCode = http.StatusPartialContent
+	response.Progress = 1
+}
+
+// downloadChunk downloads the inclusive byte range [start, end] into the file, resuming within the range on retries.
+func (f *Fetch) downloadChunk(
+	ctx context.Context,
+	request *Request,
+	file *os.File,
+	start, end int64,
+	limiters []*rate.Limiter,
+	callback func(downloaded int64),
+) error {
+	var err error
+	var wait time.Duration
+	pos := start
+
+	for attempt := 0; attempt <= f.retries; attempt++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if attempt > 0 {
+			backoff := f.backoff(attempt)
+			if wait > 0 {
+				backoff, wait = wait, 0
+			}
+
+			log.WithFields(log.Fields{
+				"attempt": attempt,
+				"error":   err,
+				"range":   fmt.Sprintf("%d-%d", pos, end),
+				"url":     request.Url,
+			}).Warn("failed to download chunk; retrying in ", backoff)
+
+			time.Sleep(backoff)
+		}
+
+		req := request.httpReq.Clone(ctx)
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", pos, end))
+
+		resp, doErr := f.httpClient.Do(req)
+		if doErr != nil {
+			err = fmt.Errorf("request error: %w", doErr)
+			continue
+		}
+
+		if resp.StatusCode != http.StatusPartialContent {
+			resp.Body.Close()
+			err = fmt.Errorf("unexpected status: %d", resp.StatusCode)
+
+			if resp.StatusCode >= 400 && !f.isRetryableStatus(resp.StatusCode) {
+				return err
+			}
+
+			wait = retryAfter(resp.StatusCode, resp.Header, f.retryAfterLimit())
+			continue
+		}
+
+		pw := &progressWriter{
+			file: &offsetWriter{file: file, offset: pos},
+			callback: func(downloaded int64) {
+				pos += downloaded
+				callback(downloaded)
+			},
+		}
+
+		_, err = io.Copy(throttle(ctx, pw, limiters), io.LimitReader(resp.Body, end-pos+1))
+		resp.Body.Close()
+
+		if err == nil && pos > end {
+			return nil
+		}
+
+		if err == nil {
+			err = fmt.Errorf("chunk ended early at byte %d of %d-%d", pos, start, end)
+		}
+	}
+
+	return err
+}
+
+// offsetWriter writes sequentially into a file starting at a fixed offset, so several writers can share one file.
+type offsetWriter struct {
+	file   *os.File
+	offset int64
+}
+
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.file.WriteAt(p, w.offset)
+	w.offset += int64(n)
+	return n, err
+}
+
+// endregion
@@ -0,0 +1,162 @@
+package fetch
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/blake3"
+)
+
+func TestDownloadFileChunked(t *testing.T) {
+	fullContent := strings.Repeat("0123456789abcdefghijklmnopqrstuvwxyz", 3000)
+	hasher := blake3.New()
+	hasher.Write([]byte(fullContent))
+	expectedHash := fmt.Sprintf("%x", hasher.Sum(nil))
+
+	t.Run("downloads ranges concurrently", func(t *testing.T) {
+		var rangeRequests atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") != "" {
+				rangeRequests.Add(1)
+			}
+			http.ServeContent(w, r, "file.txt", time.Now(), strings.NewReader(fullContent))
+		}))
+		defer server.Close()
+
+		filePath := filepath.Join(t.TempDir(), "chunked.txt")
+		f := New(nil, 0, false)
+		req, err := f.NewRequest(server.URL, filePath, nil)
+		require.NoError(t, err)
+
+		response := f.DownloadFileChunked(req, 4)
+		require.NoError(t, response.Error())
+
+		assert.Equal(t, int32(4), rangeRequests.Load())
+		assert.Equal(t, http.StatusPartialContent, response.StatusCode)
+		assert.Equal(t, int64(len(fullContent)), response.Size)
+		assert.Equal(t, int64(len(fullContent)), response.Downloaded)
+		assert.Equal(t, float64(1), response.Progress)
+		assert.Equal(t, expectedHash, response.Hash)
+
+		content, readErr := os.ReadFile(filePath)
+		assert.NoError(t, readErr)
+		assert.Equal(t, fullContent, string(content))
+	})
+
+	t.Run("hashes with the selected algorithm", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "file.txt", time.Now(), strings.NewReader(fullContent))
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		req, err := f.NewRequest(server.URL, filepath.Join(t.TempDir(), "chunked.txt"), nil)
+		require.NoError(t, err)
+		req.HashAlgo = "sha512"
+
+		response := f.DownloadFileChunked(req, 4)
+		require.NoError(t, response.Error())
+
+		sum := sha512.Sum512([]byte(fullContent))
+		assert.Equal(t, hex.EncodeToString(sum[:]), response.Hash)
+	})
+
+	t.Run("overwrites an existing file", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "file.txt", time.Now(), strings.NewReader(fullContent))
+		}))
+		defer server.Close()
+
+		filePath := filepath.Join(t.TempDir(), "chunked.txt")
+		require.NoError(t, os.WriteFile(filePath, bytes.Repeat([]byte("x"), len(fullContent)*2), 0644))
+
+		f := New(nil, 0, false)
+		req, err := f.NewRequest(server.URL, filePath, nil)
+		require.NoError(t, err)
+
+		response := f.DownloadFileChunked(req, 3)
+		require.NoError(t, response.Error())
+		assert.Equal(t, expectedHash, response.Hash)
+
+		content, readErr := os.ReadFile(filePath)
+		assert.NoError(t, readErr)
+		assert.Equal(t, fullContent, string(content))
+	})
+
+	t.Run("falls back when ranges are not supported", func(t *testing.T) {
+		var rangeRequests atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") != "" {
+				rangeRequests.Add(1)
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(fullContent))
+		}))
+		defer server.Close()
+
+		filePath := filepath.Join(t.TempDir(), "fallback.txt")
+		f := New(nil, 0, false)
+		req, err := f.NewRequest(server.URL, filePath, nil)
+		require.NoError(t, err)
+
+		response := f.DownloadFileChunked(req, 4)
+		require.NoError(t, response.Error())
+
+		assert.Equal(t, int32(0), rangeRequests.Load())
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, expectedHash, response.Hash)
+		assert.Equal(t, int64(len(fullContent)), response.Downloaded)
+	})
+
+	t.Run("single chunk uses a regular download", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "file.txt", time.Now(), strings.NewReader(fullContent))
+		}))
+		defer server.Close()
+
+		filePath := filepath.Join(t.TempDir(), "single.txt")
+		f := New(nil, 0, false)
+		req, err := f.NewRequest(server.URL, filePath, nil)
+		require.NoError(t, err)
+
+		response := f.DownloadFileChunked(req, 1)
+		require.NoError(t, response.Error())
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, expectedHash, response.Hash)
+	})
+
+	t.Run("chunk failure fails the download", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.Header.Get("Range"), "bytes=0-") {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			http.ServeContent(w, r, "file.txt", time.Now(), strings.NewReader(fullContent))
+		}))
+		defer server.Close()
+
+		filePath := filepath.Join(t.TempDir(), "failed.txt")
+		f := New(nil, 0, false)
+		req, err := f.NewRequest(server.URL, filePath, nil)
+		require.NoError(t, err)
+
+		err = f.DownloadFileChunked(req, 4).Error()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected status: 500")
+	})
+}
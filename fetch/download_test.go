@@ -1,7 +1,12 @@
 package fetch
 
 import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -14,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zeebo/blake3"
+	"golang.org/x/time/rate"
 )
 
 func TestNewRequest(t *testing.T) {
@@ -258,6 +264,36 @@ func TestDownloadFileWithCancel(t *testing.T) {
 	assert.True(t, response.IsComplete())
 }
 
+func TestDownloadFileWithTimeout(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// Create a server that sends part of the body and then stalls
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	filePath := filepath.Join(tempDir, "timeout_test.txt")
+	f := NewWithTimeout(nil, 0, false, 200*time.Millisecond)
+	req, err := f.NewRequest(server.URL, filePath, nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	err = f.DownloadFile(req).Error()
+
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
 func TestDownloadFiles(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "download_files_test")
 	require.NoError(t, err)
@@ -442,6 +478,512 @@ func TestDownloadWithRangeRequest(t *testing.T) {
 	assert.Equal(t, fullContent, string(content))
 }
 
+func TestDownloadResumeValidation(t *testing.T) {
+	fullContent := "the remote file changed since the partial download"
+	hasher := blake3.New()
+	hasher.Write([]byte(fullContent))
+	expectedHash := fmt.Sprintf("%x", hasher.Sum(nil))
+
+	tests := []struct {
+		name         string
+		contentRange string
+		status       int
+	}{
+		{name: "range starts before the end of the file", contentRange: "bytes 0-49/50", status: http.StatusPartialContent},
+		{name: "range starts after the end of the file", contentRange: "bytes 20-49/50", status: http.StatusPartialContent},
+		{name: "range without Content-Range", status: http.StatusPartialContent},
+		{name: "server ignores the range", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rangeRequests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Range") != "" {
+					rangeRequests++
+					if tt.contentRange != "" {
+						w.Header().Set("Content-Range", tt.contentRange)
+					}
+					w.WriteHeader(tt.status)
+					w.Write([]byte(fullContent))
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(fullContent))
+			}))
+			defer server.Close()
+
+			// The partial file doesn't match the remote file anymore
+			filePath := filepath.Join(t.TempDir(), "resume.txt")
+			require.NoError(t, os.WriteFile(filePath, []byte("stale data"), 0644))
+
+			f := New(nil, 0, false)
+			req, err := f.NewRequest(server.URL, filePath, nil)
+			require.NoError(t, err)
+
+			response := f.DownloadFile(req)
+
+			require.NoError(t, response.Error())
+			assert.Equal(t, 1, rangeRequests)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.Equal(t, expectedHash, response.Hash)
+			assert.Equal(t, int64(len(fullContent)), response.Downloaded)
+
+			content, readErr := os.ReadFile(filePath)
+			require.NoError(t, readErr)
+			assert.Equal(t, fullContent, string(content))
+		})
+	}
+}
+
+func TestDownloadFileWithMirrors(t *testing.T) {
+	fullContent := strings.Repeat("0123456789abcdefghijklmnopqrstuvwxyz", 100)
+	hasher := blake3.New()
+	hasher.Write([]byte(fullContent))
+	expectedHash := fmt.Sprintf("%x", hasher.Sum(nil))
+
+	newStatusServer := func(status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte("error page"))
+		}))
+	}
+
+	newMirror := func(ranges *[]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*ranges = append(*ranges, r.Header.Get("Range"))
+			http.ServeContent(w, r, "file.txt", time.Now(), strings.NewReader(fullContent))
+		}))
+	}
+
+	t.Run("falls back to the next mirror", func(t *testing.T) {
+		broken := newStatusServer(http.StatusInternalServerError)
+		defer broken.Close()
+		missing := newStatusServer(http.StatusNotFound)
+		defer missing.Close()
+
+		var ranges []string
+		mirror := newMirror(&ranges)
+		defer mirror.Close()
+
+		filePath := filepath.Join(t.TempDir(), "mirrored.txt")
+		f := New(nil, 0, false)
+		req, err := f.NewRequestWithMirrors([]string{broken.URL, missing.URL, mirror.URL}, filePath, nil)
+		require.NoError(t, err)
+
+		response := f.DownloadFile(req)
+		require.NoError(t, response.Error())
+
+		assert.Equal(t, mirror.URL, response.Url)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, expectedHash, response.Hash)
+		assert.Equal(t, []string{""}, ranges)
+		assert.Equal(t, broken.URL, req.httpReq.URL.String())
+
+		content, readErr := os.ReadFile(filePath)
+		assert.NoError(t, readErr)
+		assert.Equal(t, fullContent, string(content))
+	})
+
+	t.Run("resumes against the mirror", func(t *testing.T) {
+		half := len(fullContent) / 2
+		primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Length", fmt.Sprint(len(fullContent)))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(fullContent[:half]))
+			w.(http.Flusher).Flush()
+
+			// Drop the connection mid-way
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+		}))
+		defer primary.Close()
+
+		var ranges []string
+		mirror := newMirror(&ranges)
+		defer mirror.Close()
+
+		filePath := filepath.Join(t.TempDir(), "resumed.txt")
+		f := New(nil, 0, false)
+		req, err := f.NewRequestWithMirrors([]string{primary.URL, mirror.URL}, filePath, nil)
+		require.NoError(t, err)
+
+		response := f.DownloadFile(req)
+		require.NoError(t, response.Error())
+
+		assert.Equal(t, mirror.URL, response.Url)
+		assert.Equal(t, http.StatusPartialContent, response.StatusCode)
+		assert.Equal(t, []string{fmt.Sprintf("bytes=%d-", half)}, ranges)
+		assert.Equal(t, expectedHash, response.Hash)
+	})
+
+	t.Run("fails when every URL fails", func(t *testing.T) {
+		first := newStatusServer(http.StatusServiceUnavailable)
+		defer first.Close()
+		second := newStatusServer(http.StatusBadGateway)
+		defer second.Close()
+
+		f := New(nil, 0, false)
+		req, err := f.NewRequestWithMirrors([]string{first.URL, second.URL}, filepath.Join(t.TempDir(), "none.txt"), nil)
+		require.NoError(t, err)
+
+		response := f.DownloadFile(req)
+		err = response.Error()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected status: 502")
+		assert.Equal(t, second.URL, response.Url)
+	})
+
+	t.Run("invalid URLs", func(t *testing.T) {
+		f := New(nil, 0, false)
+
+		_, err := f.NewRequestWithMirrors(nil, "file.txt", nil)
+		assert.Error(t, err)
+
+		_, err = f.NewRequestWithMirrors([]string{"http://example.com", "://invalid-url"}, "file.txt", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestDownloadFileWithProgress(t *testing.T) {
+	fullContent := strings.Repeat("0123456789", 1000)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var start int
+		if n, _ := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &start); n == 1 {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(fullContent)-1, len(fullContent)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte(fullContent[start:]))
+			return
+		}
+
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(fullContent)))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fullContent))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		existing string
+	}{
+		{name: "fresh download"},
+		{name: "resumed download", existing: fullContent[:4000]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "progress.txt")
+			if tt.existing != "" {
+				require.NoError(t, os.WriteFile(filePath, []byte(tt.existing), 0644))
+			}
+
+			f := New(nil, 0, false)
+			req, err := f.NewRequest(server.URL, filePath, nil)
+			require.NoError(t, err)
+
+			var calls []int64
+			var lastTotal int64
+			response := f.DownloadFileWithProgress(req, func(downloaded, total int64) {
+				calls = append(calls, downloaded)
+				lastTotal = total
+			})
+
+			require.NoError(t, response.Error())
+			require.NotEmpty(t, calls)
+
+			// The first call must already account for the bytes on disk
+			assert.Greater(t, calls[0], int64(len(tt.existing)))
+			assert.IsNonDecreasing(t, calls)
+			assert.Equal(t, int64(len(fullContent)), calls[len(calls)-1])
+			assert.Equal(t, int64(len(fullContent)), lastTotal)
+		})
+	}
+}
+
+func TestDownloadFileExpectHash(t *testing.T) {
+	content := "verify me"
+	hasher := blake3.New()
+	hasher.Write([]byte(content))
+	expectedHash := fmt.Sprintf("%x", hasher.Sum(nil))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		algo     string
+		expected string
+		errorMsg string
+	}{
+		{name: "matching hash", algo: "blake3", expected: expectedHash},
+		{name: "matching hash is case-insensitive", algo: "BLAKE3", expected: strings.ToUpper(expectedHash)},
+		{
+			name:     "mismatched hash",
+			algo:     "blake3",
+			expected: "deadbeef",
+			errorMsg: fmt.Sprintf("checksum mismatch: expected deadbeef, got %s", expectedHash),
+		},
+		{name: "unsupported algorithm", algo: "md5", expected: "deadbeef", errorMsg: "unsupported hash algorithm"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "verify.txt")
+
+			f := New(nil, 0, false)
+			req, err := f.NewRequest(server.URL, filePath, nil)
+			require.NoError(t, err)
+			req.ExpectHash(tt.algo, tt.expected)
+
+			err = f.DownloadFile(req).Error()
+
+			if tt.errorMsg != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// The file is kept in every case
+			data, readErr := os.ReadFile(filePath)
+			assert.NoError(t, readErr)
+			assert.Equal(t, content, string(data))
+		})
+	}
+}
+
+func TestDownloadFileHashAlgo(t *testing.T) {
+	content := strings.Repeat("hash me with something else ", 200)
+	sha256Sum := sha256.Sum256([]byte(content))
+	sha512Sum := sha512.Sum512([]byte(content))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "file.txt", time.Now(), strings.NewReader(content))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		algo     string
+		existing string
+		expected string
+	}{
+		{name: "sha256", algo: "sha256", expected: hex.EncodeToString(sha256Sum[:])},
+		{name: "sha512", algo: "SHA512", expected: hex.EncodeToString(sha512Sum[:])},
+		{name: "resumed sha256", algo: "sha256", existing: content[:1000], expected: hex.EncodeToString(sha256Sum[:])},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "hashed.txt")
+			if tt.existing != "" {
+				require.NoError(t, os.WriteFile(filePath, []byte(tt.existing), 0644))
+			}
+
+			f := New(nil, 0, false)
+			req, err := f.NewRequest(server.URL, filePath, nil)
+			require.NoError(t, err)
+			req.HashAlgo = tt.algo
+
+			response := f.DownloadFile(req)
+
+			require.NoError(t, response.Error())
+			assert.Equal(t, tt.expected, response.Hash)
+		})
+	}
+
+	t.Run("expected hash selects the algorithm", func(t *testing.T) {
+		f := New(nil, 0, false)
+		req, err := f.NewRequest(server.URL, filepath.Join(t.TempDir(), "hashed.txt"), nil)
+		require.NoError(t, err)
+		req.ExpectHash("sha256", hex.EncodeToString(sha256Sum[:]))
+
+		response := f.DownloadFile(req)
+
+		require.NoError(t, response.Error())
+		assert.Equal(t, hex.EncodeToString(sha256Sum[:]), response.Hash)
+	})
+
+	t.Run("expected hash of another algorithm fails", func(t *testing.T) {
+		f := New(nil, 0, false)
+		req, err := f.NewRequest(server.URL, filepath.Join(t.TempDir(), "hashed.txt"), nil)
+		require.NoError(t, err)
+		req.HashAlgo = "sha512"
+		req.ExpectHash("sha256", hex.EncodeToString(sha256Sum[:]))
+
+		err = f.DownloadFile(req).Error()
+
+		assert.EqualError(t, err, "cannot verify a sha256 checksum: the download was hashed with sha512")
+	})
+
+	t.Run("unsupported algorithm fails before downloading", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "hashed.txt")
+		f := New(nil, 0, false)
+		req, err := f.NewRequest(server.URL, filePath, nil)
+		require.NoError(t, err)
+		req.HashAlgo = "md5"
+
+		err = f.DownloadFile(req).Error()
+
+		assert.EqualError(t, err, "unsupported hash algorithm: md5")
+		assert.NoFileExists(t, filePath)
+	})
+}
+
+func TestDownloadFileThrottled(t *testing.T) {
+	content := strings.Repeat("x", 15*1024)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	t.Run("per-download limit", func(t *testing.T) {
+		f := New(nil, 0, false)
+		f.SetMaxBytesPerSecond(10 * 1024)
+
+		req, err := f.NewRequest(server.URL, filepath.Join(t.TempDir(), "throttled.txt"), nil)
+		require.NoError(t, err)
+
+		// The first 10 KiB fit in the burst, the remaining 5 KiB take ~500ms
+		start := time.Now()
+		require.NoError(t, f.DownloadFile(req).Error())
+		assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	})
+
+	t.Run("shared limit across concurrent downloads", func(t *testing.T) {
+		f := New(nil, 0, false)
+		f.SetSharedMaxBytesPerSecond(20 * 1024)
+
+		tempDir := t.TempDir()
+		requests := make([]*Request, 0, 2)
+		for i := range 2 {
+			req, err := f.NewRequest(server.URL, filepath.Join(tempDir, fmt.Sprintf("shared_%d.txt", i)), nil)
+			require.NoError(t, err)
+			requests = append(requests, req)
+		}
+
+		// 30 KiB in total: 20 KiB fit in the burst, the remaining 10 KiB take ~500ms
+		start := time.Now()
+		responses, _ := f.DownloadFiles(requests, 2)
+		for resp := range responses {
+			assert.NoError(t, resp.Error())
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	})
+
+	t.Run("zero means unlimited", func(t *testing.T) {
+		f := New(nil, 0, false)
+		f.SetMaxBytesPerSecond(0)
+		f.SetSharedMaxBytesPerSecond(0)
+
+		assert.Empty(t, f.limiters())
+
+		req, err := f.NewRequest(server.URL, filepath.Join(t.TempDir(), "unlimited.txt"), nil)
+		require.NoError(t, err)
+		require.NoError(t, f.DownloadFile(req).Error())
+	})
+}
+
+func TestThrottledWriterSplitsLargeWrites(t *testing.T) {
+	buffer := &strings.Builder{}
+	tw := &throttledWriter{
+		ctx:      context.Background(),
+		writer:   buffer,
+		limiters: []*rate.Limiter{rate.NewLimiter(rate.Inf, 4)},
+	}
+
+	n, err := tw.Write([]byte("Hello, World!"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 13, n)
+	assert.Equal(t, "Hello, World!", buffer.String())
+}
+
+func TestDownloadFileToDirectory(t *testing.T) {
+	content := "named by the server"
+
+	tests := []struct {
+		name        string
+		disposition string
+		urlPath     string
+		expected    string
+	}{
+		{name: "plain filename", disposition: "attachment; filename=report.pdf", urlPath: "/download", expected: "report.pdf"},
+		{name: "quoted filename", disposition: `attachment; filename="my report.pdf"`, urlPath: "/download", expected: "my report.pdf"},
+		{
+			name:        "RFC 5987 filename",
+			disposition: "attachment; filename*=UTF-8''na%C3%AFve%20file.txt",
+			urlPath:     "/download",
+			expected:    "naïve file.txt",
+		},
+		{
+			name:        "path components are stripped",
+			disposition: `attachment; filename="../../etc/passwd"`,
+			urlPath:     "/download",
+			expected:    "passwd",
+		},
+		{name: "falls back to the URL path", urlPath: "/files/archive.tar.xz", expected: "archive.tar.xz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.urlPath, r.URL.Path)
+				if tt.disposition != "" {
+					w.Header().Set("Content-Disposition", tt.disposition)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(content))
+			}))
+			defer server.Close()
+
+			tempDir := t.TempDir()
+			f := New(nil, 0, false)
+			req, err := f.NewRequest(server.URL+tt.urlPath, tempDir, nil)
+			require.NoError(t, err)
+
+			response := f.DownloadFile(req)
+			require.NoError(t, response.Error())
+
+			expectedPath := filepath.Join(tempDir, tt.expected)
+			assert.Equal(t, expectedPath, req.FilePath)
+
+			data, readErr := os.ReadFile(expectedPath)
+			assert.NoError(t, readErr)
+			assert.Equal(t, content, string(data))
+		})
+	}
+}
+
+func TestFilenameFromContentDisposition(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{header: "", expected: ""},
+		{header: "inline", expected: ""},
+		{header: "attachment; filename=file.txt", expected: "file.txt"},
+		{header: `attachment; filename="file name.txt"`, expected: "file name.txt"},
+		{header: `attachment; filename="fallback.txt"; filename*=UTF-8''%E2%82%AC%20rates.txt`, expected: "€ rates.txt"},
+		{header: `attachment; filename="..\\..\\evil.exe"`, expected: "evil.exe"},
+		{header: `attachment; filename=".."`, expected: ""},
+		{header: "not a valid; header", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, filenameFromContentDisposition(tt.header))
+		})
+	}
+}
+
 func TestProgressWriter(t *testing.T) {
 	var downloadedBytes int64
 	var callCount int
@@ -516,6 +1058,171 @@ func TestFibonacci(t *testing.T) {
 	}
 }
 
+func TestBackoff(t *testing.T) {
+	t.Run("defaults to Fibonacci seconds", func(t *testing.T) {
+		f := New(nil, 3, false)
+
+		assert.Equal(t, 1*time.Second, f.backoff(1))
+		assert.Equal(t, 2*time.Second, f.backoff(2))
+		assert.Equal(t, 3*time.Second, f.backoff(3))
+		assert.Equal(t, 5*time.Second, f.backoff(4))
+	})
+
+	t.Run("fixed delay", func(t *testing.T) {
+		f := NewWithRetry(nil, 3, false, RetryConfig{BaseDelay: 100 * time.Millisecond})
+
+		assert.Equal(t, 100*time.Millisecond, f.backoff(1))
+		assert.Equal(t, 100*time.Millisecond, f.backoff(5))
+	})
+
+	t.Run("exponential delay capped at max", func(t *testing.T) {
+		f := NewWithRetry(nil, 5, false, RetryConfig{
+			BaseDelay:  100 * time.Millisecond,
+			MaxDelay:   500 * time.Millisecond,
+			Multiplier: 2,
+		})
+
+		assert.Equal(t, 100*time.Millisecond, f.backoff(1))
+		assert.Equal(t, 200*time.Millisecond, f.backoff(2))
+		assert.Equal(t, 400*time.Millisecond, f.backoff(3))
+		assert.Equal(t, 500*time.Millisecond, f.backoff(4))
+	})
+
+	t.Run("exponential delay without max doesn't overflow", func(t *testing.T) {
+		f := NewWithRetry(nil, 5000, false, RetryConfig{BaseDelay: time.Second, Multiplier: 2})
+
+		assert.Equal(t, time.Duration(math.MaxInt64), f.backoff(100))
+		assert.Equal(t, time.Duration(math.MaxInt64), f.backoff(5000))
+	})
+
+	t.Run("exponential delay without base stays zero", func(t *testing.T) {
+		f := NewWithRetry(nil, 5000, false, RetryConfig{Multiplier: 2})
+
+		assert.Equal(t, time.Duration(0), f.backoff(5000))
+	})
+
+	t.Run("jitter stays within bounds", func(t *testing.T) {
+		f := NewWithRetry(nil, 3, false, RetryConfig{BaseDelay: 100 * time.Millisecond, Jitter: true})
+
+		for range 100 {
+			delay := f.backoff(1)
+			assert.GreaterOrEqual(t, delay, 50*time.Millisecond)
+			assert.LessOrEqual(t, delay, 100*time.Millisecond)
+		}
+	})
+}
+
+func TestDownloadWithRetryConfig(t *testing.T) {
+	var requestCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		if requestCount < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("finally"))
+	}))
+	defer server.Close()
+
+	f := NewWithRetry(nil, 3, false, RetryConfig{BaseDelay: 10 * time.Millisecond})
+	req, err := f.NewRequest(server.URL, filepath.Join(t.TempDir(), "retry.txt"), nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	assert.NoError(t, f.DownloadFile(req).Error())
+	assert.Equal(t, 3, requestCount)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestDownloadWithRetryStatuses(t *testing.T) {
+	var requestCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	f := New(nil, 3, false)
+	f.SetRetryStatuses([]int{http.StatusServiceUnavailable})
+
+	req, err := f.NewRequest(server.URL, filepath.Join(t.TempDir(), "statuses.txt"), nil)
+	require.NoError(t, err)
+
+	err = f.DownloadFile(req).Error()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status: 500")
+	assert.Equal(t, 1, requestCount)
+}
+
+func TestDownloadFileMaxBytes(t *testing.T) {
+	content := strings.Repeat("x", 4096)
+
+	t.Run("Content-Length over the limit", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(content))
+		}))
+		defer server.Close()
+
+		filePath := filepath.Join(t.TempDir(), "large.txt")
+		f := New(nil, 2, false)
+		f.SetMaxBytes(1024)
+
+		req, err := f.NewRequest(server.URL, filePath, nil)
+		require.NoError(t, err)
+
+		err = f.DownloadFile(req).Error()
+		assert.Error(t, err)
+		assert.Equal(t, "response exceeded max size of 1024 bytes", err.Error())
+		assert.NoFileExists(t, filePath)
+	})
+
+	t.Run("streamed body over the limit", func(t *testing.T) {
+		var requestCount int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestCount++
+			w.WriteHeader(http.StatusOK)
+			for i := 0; i < 4; i++ {
+				w.Write([]byte(content[:1024]))
+				w.(http.Flusher).Flush()
+			}
+		}))
+		defer server.Close()
+
+		filePath := filepath.Join(t.TempDir(), "streamed.txt")
+		f := New(nil, 2, false)
+		f.SetMaxBytes(2000)
+
+		req, err := f.NewRequest(server.URL, filePath, nil)
+		require.NoError(t, err)
+
+		err = f.DownloadFile(req).Error()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "response exceeded max size of 2000 bytes")
+		assert.Equal(t, 1, requestCount)
+		assert.NoFileExists(t, filePath)
+	})
+
+	t.Run("file within the limit", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(content))
+		}))
+		defer server.Close()
+
+		filePath := filepath.Join(t.TempDir(), "small.txt")
+		f := New(nil, 0, false)
+		f.SetMaxBytes(int64(len(content)))
+
+		req, err := f.NewRequest(server.URL, filePath, nil)
+		require.NoError(t, err)
+
+		assert.NoError(t, f.DownloadFile(req).Error())
+		assert.FileExists(t, filePath)
+	})
+}
+
 func TestDownloadFilePermissionError(t *testing.T) {
 	// Skip this test on Windows as it handles permissions differently
 	if os.Getenv("GOOS") == "windows" {
@@ -684,3 +1391,15 @@ func TestBlake3FileHash(t *testing.T) {
 	assert.Equal(t, expectedHash, actualHash)
 	assert.Equal(t, testContent, string(fileContent))
 }
+
+func TestRetryAfterLimit(t *testing.T) {
+	t.Run("defaults to the package cap", func(t *testing.T) {
+		assert.Equal(t, maxRetryAfter, New(nil, 3, false).retryAfterLimit())
+		assert.Equal(t, maxRetryAfter, NewWithRetry(nil, 3, false, RetryConfig{BaseDelay: time.Second}).retryAfterLimit())
+	})
+
+	t.Run("uses the max delay when configured", func(t *testing.T) {
+		f := NewWithRetry(nil, 3, false, RetryConfig{BaseDelay: time.Second, MaxDelay: 10 * time.Second})
+		assert.Equal(t, 10*time.Second, f.retryAfterLimit())
+	})
+}
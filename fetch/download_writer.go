@@ -0,0 +1,150 @@
+package fetch
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DownloadToWriter downloads the content of a URL into an arbitrary writer instead of a file. The content is still
+// hashed and its progress tracked, but since a writer can't be rewound, resuming doesn't apply: it always performs a
+// full GET, and retries only happen while no data has been written yet.
+//
+// Parameters:
+//   - url: The URL to download the content from.
+//   - w: The writer where the downloaded content will be written.
+//   - headers: Optional headers to set on the request.
+//   - hashAlgo: Optional algorithm used to compute Response.Hash: "blake3" (the default), "sha256", or "sha512".
+//
+// Returns:
+//   - A Response object that contains the status and details of the download process.
+//   - An error if the request creation fails or the hash algorithm isn't supported.
+func (f *Fetch) DownloadToWriter(url string, w io.Writer, headers map[string]string, hashAlgo ...string) (*Response, error) {
+	request, err := f.NewRequest(url, "", headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hashAlgo) > 0 {
+		request.HashAlgo = hashAlgo[0]
+	}
+
+	hasher, err := request.newHasher()
+	if err != nil {
+		return nil, err
+	}
+
+	response, ctx := f.newResponse(request)
+
+	go func() {
+		defer close(response.Done)
+
+		pw := &progressWriter{
+			file:     w,
+			hasher:   hasher,
+			callback: trackProgress(response),
+		}
+
+		f.streamWithRetries(ctx, response, f.limitSize(response, throttle(ctx, pw, f.limiters())))
+
+		response.Hash = hex.EncodeToString(hasher.Sum(nil))
+		f.finishDownload(response)
+	}()
+
+	return response, nil
+}
+
+// region - Private functions
+
+func (f *Fetch) streamWithRetries(ctx context.Context, response *Response, writer io.Writer) {
+	var err error
+	var wait time.Duration
+
+	for attempt := 0; attempt <= f.retries; attempt++ {
+		// Before each attempt, see if we've been canceled
+		if ctx.Err() != nil {
+			response.err = ctx.Err()
+			return
+		}
+
+		if attempt > 0 {
+			backoff := f.backoff(attempt)
+			if wait > 0 {
+				backoff, wait = wait, 0
+			}
+
+			log.WithFields(log.Fields{
+				"attempt": attempt,
+				"error":   err,
+				"url":     response.Request.Url,
+			}).Warn("failed to download content; retrying in ", backoff)
+
+			time.Sleep(backoff)
+		}
+
+		resp, doErr := f.httpClient.Do(response.Request.httpReq)
+		if doErr != nil {
+			err = fmt.Errorf("request error: %w", doErr)
+			response.err = err
+			continue
+		}
+
+		response.StatusCode = resp.StatusCode
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			resp.Body.Close()
+			err = fmt.Errorf("unexpected status: %d", resp.StatusCode)
+			response.err = err
+
+			// HTTP 404 and 410 mean the content is not there, so there's no point in retrying
+			if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+				return
+			}
+
+			if !f.isRetryableStatus(resp.StatusCode) {
+				return
+			}
+
+			wait = retryAfter(resp.StatusCode, resp.Header, f.retryAfterLimit())
+			continue
+		}
+
+		response.Size = resp.ContentLength
+
+		if f.maxBytes > 0 && response.Size > f.maxBytes {
+			resp.Body.Close()
+			response.err = &maxSizeError{limit: f.maxBytes}
+			return
+		}
+
+		_, err = io.Copy(writer, resp.Body)
+		resp.Body.Close()
+
+		if err != nil {
+			if isMaxSizeError(err) {
+				response.err = err
+			} else if ctx.Err() != nil {
+				response.err = ctx.Err()
+			} else {
+				response.err = fmt.Errorf("download interrupted (wrote %d bytes): %w", response.Downloaded, err)
+			}
+			return
+		}
+
+		// Success
+		if response.Size == -1 {
+			response.Size = response.Downloaded
+		}
+
+		response.Progress = 1
+		response.err = nil
+		return
+	}
+}
+
+// endregion
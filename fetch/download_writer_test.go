@@ -0,0 +1,120 @@
+package fetch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/blake3"
+)
+
+func TestDownloadToWriter(t *testing.T) {
+	content := "streamed straight into memory"
+	hasher := blake3.New()
+	hasher.Write([]byte(content))
+	expectedHash := fmt.Sprintf("%x", hasher.Sum(nil))
+
+	t.Run("successful download", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("Range"))
+			assert.Equal(t, "custom-value", r.Header.Get("X-Custom-Header"))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(content))
+		}))
+		defer server.Close()
+
+		var buffer bytes.Buffer
+		f := New(nil, 0, false)
+		response, err := f.DownloadToWriter(server.URL, &buffer, map[string]string{"X-Custom-Header": "custom-value"})
+		require.NoError(t, err)
+
+		assert.NoError(t, response.Error())
+		assert.Equal(t, content, buffer.String())
+		assert.Equal(t, expectedHash, response.Hash)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, int64(len(content)), response.Size)
+		assert.Equal(t, int64(len(content)), response.Downloaded)
+		assert.Equal(t, float64(1), response.Progress)
+	})
+
+	t.Run("retries before any data is written", func(t *testing.T) {
+		var requestCount int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestCount++
+			if requestCount == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(content))
+		}))
+		defer server.Close()
+
+		var buffer bytes.Buffer
+		f := New(nil, 1, false)
+		response, err := f.DownloadToWriter(server.URL, &buffer, nil)
+		require.NoError(t, err)
+
+		assert.NoError(t, response.Error())
+		assert.Equal(t, 2, requestCount)
+		assert.Equal(t, content, buffer.String())
+	})
+
+	t.Run("not found is not retried", func(t *testing.T) {
+		var requestCount int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestCount++
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		var buffer bytes.Buffer
+		f := New(nil, 2, false)
+		response, err := f.DownloadToWriter(server.URL, &buffer, nil)
+		require.NoError(t, err)
+
+		err = response.Error()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+		assert.Equal(t, 1, requestCount)
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("invalid URL", func(t *testing.T) {
+		f := New(nil, 0, false)
+		response, err := f.DownloadToWriter("://invalid-url", &bytes.Buffer{}, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+
+	t.Run("hashes with the given algorithm", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(content))
+		}))
+		defer server.Close()
+
+		var buffer bytes.Buffer
+		f := New(nil, 0, false)
+		response, err := f.DownloadToWriter(server.URL, &buffer, nil, "sha256")
+		require.NoError(t, err)
+
+		sum := sha256.Sum256([]byte(content))
+		assert.NoError(t, response.Error())
+		assert.Equal(t, hex.EncodeToString(sum[:]), response.Hash)
+	})
+
+	t.Run("unsupported hash algorithm", func(t *testing.T) {
+		f := New(nil, 0, false)
+		response, err := f.DownloadToWriter("http://localhost", &bytes.Buffer{}, nil, "md5")
+
+		assert.Nil(t, response)
+		assert.EqualError(t, err, "unsupported hash algorithm: md5")
+	})
+}
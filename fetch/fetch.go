@@ -3,12 +3,14 @@ package fetch
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 )
 
 type Fetch struct {
@@ -16,6 +18,14 @@ type Fetch struct {
 	httpClient *http.Client
 	headers    map[string]string
 	retries    int
+	timeout    time.Duration
+
+	retryConfig       *RetryConfig
+	retryStatuses     map[int]bool
+	tlsConfig         *tls.Config
+	maxBytesPerSecond int64
+	sharedLimiter     *rate.Limiter
+	maxBytes          int64
 }
 
 var http11Transport = &http.Transport{
@@ -35,21 +45,60 @@ var userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (K
 //
 // Returns a new Fetch instance.
 func New(headers map[string]string, retries int, disableHttp2 bool) *Fetch {
-	logger := log.New()
+	return NewWithTimeout(headers, retries, disableHttp2, 0)
+}
 
-	f := resty.New()
-	f.SetRedirectPolicy(resty.FlexibleRedirectPolicy(maxRedirects), resty.RedirectPolicyFunc(func(req *http.Request, via []*http.Request) error {
-		if len(via) > 0 && via[0].URL.Scheme == "https" && req.URL.Scheme == "http" {
-			return fmt.Errorf("refusing redirect from https to http: %s", req.URL)
-		}
+// NewWithTimeout creates a new Fetch instance with specified headers, retry settings and a timeout that bounds how long
+// a single request or download can take.
+//
+// Parameters:
+//   - headers: a map of headers to be set on each request.
+//   - retries: the number of retry attempts for failed requests.
+//   - disableHttp2: a boolean flag to disable HTTP/2.
+//   - timeout: the maximum duration of a single request or download, including retries; 0 means no timeout.
+//
+// Returns a new Fetch instance.
+func NewWithTimeout(headers map[string]string, retries int, disableHttp2 bool, timeout time.Duration) *Fetch {
+	return newFetch(headers, retries, disableHttp2, timeout, nil)
+}
 
-		return nil
-	}))
+// NewWithRetry creates a new Fetch instance with specified headers and a custom backoff policy that is applied between
+// retries of both regular requests and downloads.
+//
+// Parameters:
+//   - headers: a map of headers to be set on each request.
+//   - retries: the number of retry attempts for failed requests.
+//   - disableHttp2: a boolean flag to disable HTTP/2.
+//   - config: the backoff policy used to compute the delay before each retry.
+//
+// Returns a new Fetch instance.
+func NewWithRetry(headers map[string]string, retries int, disableHttp2 bool, config RetryConfig) *Fetch {
+	return newFetch(headers, retries, disableHttp2, 0, &config)
+}
+
+func newFetch(
+	headers map[string]string,
+	retries int,
+	disableHttp2 bool,
+	timeout time.Duration,
+	retryConfig *RetryConfig,
+) *Fetch {
+	logger := log.New()
+
+	client := resty.New()
+	client.SetRedirectPolicy(resty.RedirectPolicyFunc(safeCheckRedirect))
 
 	if disableHttp2 {
-		f.SetTransport(http11Transport)
+		client.SetTransport(http11Transport)
 	}
 
+	if timeout > 0 {
+		client.SetTimeout(timeout)
+	}
+
+	httpClient := newIdleTimeoutClient(30 * time.Second)
+	httpClient.Timeout = timeout
+
 	if headers == nil {
 		headers = make(map[string]string)
 	}
@@ -61,38 +110,160 @@ func New(headers map[string]string, retries int, disableHttp2 bool) *Fetch {
 		headers["Content-Type"] = "application/json"
 	}
 
-	return &Fetch{
-		restClient: f.
-			SetLogger(logger).
-			SetHeaders(headers).
-			SetRetryCount(retries).
-			SetRetryWaitTime(0).
-			AddRetryCondition(
-				func(r *resty.Response, err error) bool {
-					if (err != nil || r.IsError()) && r.Request.Attempt <= retries {
-						sleep := time.Duration(fibonacci(r.Request.Attempt+1)) * time.Second
-
-						log.WithFields(log.Fields{
-							"attempt": r.Request.Attempt,
-							"error":   r.Error(),
-							"status":  r.StatusCode(),
-							"url":     r.Request.URL,
-						}).Warn("failed to get data; retrying in ", sleep)
-
-						time.Sleep(sleep)
-						return true
-					}
+	f := &Fetch{
+		httpClient:  httpClient,
+		headers:     headers,
+		retries:     retries,
+		timeout:     timeout,
+		retryConfig: retryConfig,
+	}
 
+	f.restClient = client.
+		SetLogger(logger).
+		OnBeforeRequest(resetMultipartBody).
+		SetHeaders(headers).
+		SetRetryCount(retries).
+		SetRetryWaitTime(0).
+		AddRetryCondition(
+			func(r *resty.Response, err error) bool {
+				// A body that is too large is not going to shrink on the next attempt
+				if errors.Is(err, resty.ErrResponseBodyTooLarge) {
 					return false
-				},
-			),
+				}
+
+				if (err != nil || (r.IsError() && f.isRetryableStatus(r.StatusCode()))) && r.Request.Attempt <= retries {
+					sleep := f.backoff(r.Request.Attempt)
+					if wait := retryAfter(r.StatusCode(), r.Header(), f.retryAfterLimit()); wait > 0 {
+						sleep = wait
+					}
+
+					log.WithFields(log.Fields{
+						"attempt": r.Request.Attempt,
+						"error":   r.Error(),
+						"status":  r.StatusCode(),
+						"url":     r.Request.URL,
+					}).Warn("failed to get data; retrying in ", sleep)
+
+					time.Sleep(sleep)
+					return true
+				}
+
+				return false
+			},
+		)
+
+	return f
+}
+
+// SetRetryStatuses restricts retries to responses with the given HTTP status codes; any other error status fails
+// immediately without consuming the retry budget. Network errors are always retried. Passing nil or an empty slice
+// restores the default behavior of retrying every error status.
+//
+// When a 429 or 503 response carries a Retry-After header, its value is used instead of the backoff delay, capped at the
+// MaxDelay of the RetryConfig or, without one, at 5 minutes.
+//
+// Parameters:
+//   - statuses: the HTTP status codes that should be retried, e.g. []int{429, 503}.
+func (f *Fetch) SetRetryStatuses(statuses []int) {
+	if len(statuses) == 0 {
+		f.retryStatuses = nil
+		return
+	}
 
-		httpClient: newIdleTimeoutClient(30 * time.Second),
-		headers:    headers,
-		retries:    retries,
+	f.retryStatuses = make(map[int]bool, len(statuses))
+	for _, status := range statuses {
+		f.retryStatuses[status] = true
 	}
 }
 
+// isRetryableStatus reports whether an error response with the given status code should be retried.
+func (f *Fetch) isRetryableStatus(status int) bool {
+	return f.retryStatuses == nil || f.retryStatuses[status]
+}
+
+// SetTLSConfig sets the TLS configuration used by both requests and downloads, e.g. to trust a custom CA pool when
+// talking to internal services. Passing nil restores the default configuration with full certificate verification.
+//
+// Parameters:
+//   - config: the TLS configuration to use.
+func (f *Fetch) SetTLSConfig(config *tls.Config) {
+	f.tlsConfig = config
+
+	client := f.restClient.GetClient()
+	client.Transport = withTLSConfig(client.Transport, config)
+	f.httpClient.Transport = withTLSConfig(f.httpClient.Transport, config)
+}
+
+// SetInsecureSkipVerify enables or disables TLS certificate verification for both requests and downloads, keeping the
+// rest of the TLS configuration set by SetTLSConfig.
+//
+// Skipping verification makes the connection vulnerable to man-in-the-middle attacks, since any certificate presented
+// by the server is accepted. Only use it in development, against servers you control; prefer SetTLSConfig with a custom
+// RootCAs pool for self-signed certificates.
+//
+// Parameters:
+//   - skip: whether certificate verification should be skipped.
+func (f *Fetch) SetInsecureSkipVerify(skip bool) {
+	config := &tls.Config{}
+	if f.tlsConfig != nil {
+		config = f.tlsConfig.Clone()
+	}
+
+	config.InsecureSkipVerify = skip
+	f.SetTLSConfig(config)
+}
+
+// SetMaxBytesPerSecond limits the download speed of each individual download started by this Fetch instance. A value of
+// 0 (the default) means unlimited. It should be called before starting any downloads.
+//
+// Parameters:
+//   - n: the maximum number of bytes per second for each download.
+func (f *Fetch) SetMaxBytesPerSecond(n int64) {
+	f.maxBytesPerSecond = n
+}
+
+// SetSharedMaxBytesPerSecond limits the combined download speed of all downloads started by this Fetch instance, which
+// is useful to cap the bandwidth used by DownloadFiles. A value of 0 (the default) means unlimited. It can be combined
+// with SetMaxBytesPerSecond and should be called before starting any downloads.
+//
+// Parameters:
+//   - n: the maximum number of bytes per second shared by all downloads.
+func (f *Fetch) SetSharedMaxBytesPerSecond(n int64) {
+	f.sharedLimiter = newByteLimiter(n)
+}
+
+// SetMaxBytes limits the size of response bodies read by the REST methods and of files written by the download
+// methods. Once the limit is exceeded, the read is aborted with an error and no partial file is left on disk.
+// Size-limit errors are never retried. A value of 0 (the default) means unlimited.
+//
+// Parameters:
+//   - n: the maximum number of bytes of a single response or download.
+func (f *Fetch) SetMaxBytes(n int64) {
+	if n < 0 {
+		n = 0
+	}
+
+	f.maxBytes = n
+	f.restClient.SetResponseBodyLimit(int(n))
+}
+
+// SetMaxRedirects limits the redirect chain of both requests and downloads: like the default cap of 5, a request fails
+// once the chain reaches n requests, counting the original one. A value of 0 disables redirects, so the 3xx response is
+// returned as-is: GetText and PostText return its body along with a *RedirectError holding the Location, and GetRaw
+// exposes the Location header. Redirects from https to http are always refused.
+//
+// Parameters:
+//   - n: the maximum length of the redirect chain; 0 to not follow redirects.
+func (f *Fetch) SetMaxRedirects(n int) {
+	if n < 0 {
+		n = 0
+	}
+
+	policy := limitedCheckRedirect(n)
+	f.restClient.SetRedirectPolicy(resty.RedirectPolicyFunc(policy))
+	f.httpClient.CheckRedirect = policy
+}
+
 // GetText performs a GET request to the specified URL and returns the response body as a string.
 //
 // Parameters:
@@ -101,35 +272,36 @@ func New(headers map[string]string, retries int, disableHttp2 bool) *Fetch {
 //
 // Returns the response body as a string and an error if the request fails.
 func (f *Fetch) GetText(ctx context.Context, url string) (string, error) {
-	resp, err := f.restClient.R().
-		SetContext(ctx).
-		Get(url)
+	return f.doText(ctx, url, nil, "GET")
+}
 
+// GetBytes performs a GET request to the specified URL and returns the response body unmodified, making it suitable
+// for binary payloads such as images or protobuf messages.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts.
+//   - url: the URL to send the GET request to.
+//
+// Returns the response body as a byte slice and an error if the request fails.
+func (f *Fetch) GetBytes(ctx context.Context, url string) ([]byte, error) {
+	resp, err := f.doBody(ctx, url, nil, nil, "GET")
 	if err != nil {
-		status := 0
-		if resp != nil {
-			status = resp.StatusCode()
-		}
-
-		log.WithFields(log.Fields{
-			"error":  err,
-			"status": status,
-			"url":    url,
-		}).Error("Error getting text")
-
-		return "", err
+		return nil, err
 	}
 
-	if resp.IsError() {
-		log.WithFields(log.Fields{
-			"status": resp.StatusCode(),
-			"url":    url,
-		}).Error("Error getting text")
-
-		return "", fmt.Errorf("%s", resp.Status())
-	}
+	return resp.Body(), nil
+}
 
-	return resp.String(), nil
+// PostText performs a POST request to the specified URL with a JSON body and returns the response body as a string.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts.
+//   - url: the URL to send the POST request to.
+//   - body: optional request body to be marshalled as JSON.
+//
+// Returns the response body as a string and an error if the request fails.
+func (f *Fetch) PostText(ctx context.Context, url string, body any) (string, error) {
+	return f.doText(ctx, url, body, "POST")
 }
 
 // GetResult performs a GET request to the specified URL and unmarshals the response body into the provided result
@@ -148,6 +320,21 @@ func (f *Fetch) GetResult(ctx context.Context, url string, headers map[string]st
 	return f.doRequest(ctx, url, headers, nil, result, "GET")
 }
 
+// GetRaw performs a GET request to the specified URL and returns the full response without unmarshalling the body, so
+// callers can inspect the status, headers and raw body themselves.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts.
+//   - url: the URL to send the GET request to.
+//   - headers: per-request headers to set in addition to the client defaults.
+//
+// Returns:
+//   - *resty.Response: the response from the GET request.
+//   - error: an error if the request fails or the response indicates an error.
+func (f *Fetch) GetRaw(ctx context.Context, url string, headers map[string]string) (*resty.Response, error) {
+	return f.doRequest(ctx, url, headers, nil, nil, "GET")
+}
+
 // PostResult performs a POST request to the specified URL and unmarshals the response body into the provided result
 // interface.
 //
@@ -189,6 +376,10 @@ func (f *Fetch) doRequest(ctx context.Context, url string, headers map[string]st
 		return nil, fmt.Errorf("unsupported HTTP method: %s", method)
 	}
 
+	if errors.Is(err, resty.ErrResponseBodyTooLarge) {
+		err = &maxSizeError{limit: f.maxBytes}
+	}
+
 	if err != nil {
 		status := 0
 		if resp != nil {
@@ -215,3 +406,71 @@ func (f *Fetch) doRequest(ctx context.Context, url string, headers map[string]st
 
 	return resp, nil
 }
+
+// doText performs an HTTP request with the specified method and returns the response body as a string
+func (f *Fetch) doText(ctx context.Context, url string, body any, method string) (string, error) {
+	resp, err := f.doBody(ctx, url, nil, body, method)
+	if err != nil {
+		return "", err
+	}
+
+	// A redirect that reached here wasn't followed, so its target is handed to the caller along with the body
+	if location := resp.Header().Get("Location"); resp.StatusCode()/100 == 3 && location != "" {
+		return resp.String(), &RedirectError{StatusCode: resp.StatusCode(), Location: location}
+	}
+
+	return resp.String(), nil
+}
+
+// doBody performs an HTTP request with the specified method and returns the response, whose body is left untouched
+func (f *Fetch) doBody(ctx context.Context, url string, headers map[string]string, body any, method string) (*resty.Response, error) {
+	req := f.restClient.R().
+		SetContext(ctx).
+		SetHeaders(headers)
+
+	if body != nil {
+		req.SetBody(body)
+	}
+
+	var resp *resty.Response
+	var err error
+
+	switch method {
+	case "GET":
+		resp, err = req.Get(url)
+	case "POST":
+		resp, err = req.Post(url)
+	default:
+		return nil, fmt.Errorf("unsupported HTTP method: %s", method)
+	}
+
+	if errors.Is(err, resty.ErrResponseBodyTooLarge) {
+		err = &maxSizeError{limit: f.maxBytes}
+	}
+
+	if err != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode()
+		}
+
+		log.WithFields(log.Fields{
+			"error":  err,
+			"status": status,
+			"url":    url,
+		}).Error("Error getting text")
+
+		return nil, err
+	}
+
+	if resp.IsError() {
+		log.WithFields(log.Fields{
+			"status": resp.StatusCode(),
+			"url":    url,
+		}).Error("Error getting text")
+
+		return nil, fmt.Errorf("%s", resp.Status())
+	}
+
+	return resp, nil
+}
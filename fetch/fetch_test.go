@@ -2,14 +2,18 @@ package fetch
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNew(t *testing.T) {
@@ -67,6 +71,69 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func TestNewWithTimeout(t *testing.T) {
+	t.Run("sets timeout on both clients", func(t *testing.T) {
+		f := NewWithTimeout(nil, 1, false, 5*time.Second)
+
+		assert.NotNil(t, f)
+		assert.Equal(t, 5*time.Second, f.timeout)
+		assert.Equal(t, 5*time.Second, f.restClient.GetClient().Timeout)
+		assert.Equal(t, 5*time.Second, f.httpClient.Timeout)
+	})
+
+	t.Run("New has no timeout", func(t *testing.T) {
+		f := New(nil, 1, false)
+
+		assert.Equal(t, time.Duration(0), f.timeout)
+		assert.Equal(t, time.Duration(0), f.httpClient.Timeout)
+	})
+
+	t.Run("GetText fails when the server is too slow", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(2 * time.Second):
+			case <-r.Context().Done():
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		f := NewWithTimeout(nil, 0, false, 100*time.Millisecond)
+		result, err := f.GetText(context.Background(), server.URL)
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestNewWithRetry(t *testing.T) {
+	t.Run("applies the backoff to requests", func(t *testing.T) {
+		var requestCount int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestCount++
+			if requestCount < 3 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("Success after retry"))
+		}))
+		defer server.Close()
+
+		f := NewWithRetry(nil, 3, false, RetryConfig{BaseDelay: 10 * time.Millisecond, Multiplier: 2})
+		assert.NotNil(t, f.retryConfig)
+
+		start := time.Now()
+		result, err := f.GetText(context.Background(), server.URL)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Success after retry", result)
+		assert.Equal(t, 3, requestCount)
+		// The default Fibonacci backoff would have waited 2s
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
 func TestFetch_GetText(t *testing.T) {
 	t.Run("successful request", func(t *testing.T) {
 		expectedBody := "Hello, World!"
@@ -165,6 +232,60 @@ func TestFetch_GetText(t *testing.T) {
 	})
 }
 
+func TestFetch_GetBytes(t *testing.T) {
+	t.Run("returns binary body unmodified", func(t *testing.T) {
+		// Invalid UTF-8 and surrounding whitespace would be altered by a string round-trip or GetText's trimming
+		expected := []byte{' ', 0x89, 'P', 'N', 'G', 0x00, 0xFF, 0xFE, '\n'}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(expected)
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		result, err := f.GetBytes(context.Background(), server.URL)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("server returns error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("Not Found"))
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		result, err := f.GetBytes(context.Background(), server.URL)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "404")
+		assert.Nil(t, result)
+	})
+
+	t.Run("retries on server error", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 2 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte{0x01, 0x02})
+		}))
+		defer server.Close()
+
+		f := NewWithRetry(nil, 2, false, RetryConfig{BaseDelay: 10 * time.Millisecond})
+		result, err := f.GetBytes(context.Background(), server.URL)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02}, result)
+		assert.Equal(t, 2, attempts)
+	})
+}
+
 func TestFetch_GetResult(t *testing.T) {
 	type TestResponse struct {
 		Message string `json:"message"`
@@ -294,6 +415,167 @@ func TestFetch_GetResult(t *testing.T) {
 	})
 }
 
+func TestFetch_GetRaw(t *testing.T) {
+	t.Run("exposes response headers and body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+			assert.Equal(t, "custom-value", r.Header.Get("X-Custom-Header"))
+
+			w.Header().Set("Content-Type", "text/plain")
+			w.Header().Set("X-RateLimit-Remaining", "42")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"not": "parsed"}`))
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		resp, err := f.GetRaw(context.Background(), server.URL, map[string]string{"X-Custom-Header": "custom-value"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
+		assert.Equal(t, "42", resp.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "text/plain", resp.Header().Get("Content-Type"))
+		assert.Equal(t, `{"not": "parsed"}`, resp.String())
+	})
+
+	t.Run("server returns error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		resp, err := f.GetRaw(context.Background(), server.URL, nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "429")
+		assert.NotNil(t, resp)
+		assert.Equal(t, "10", resp.Header().Get("Retry-After"))
+	})
+}
+
+func TestFetch_PostText(t *testing.T) {
+	t.Run("successful request with JSON body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+			var payload map[string]string
+			err := json.NewDecoder(r.Body).Decode(&payload)
+			assert.NoError(t, err)
+			assert.Equal(t, "value", payload["key"])
+
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("Posted!"))
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		result, err := f.PostText(context.Background(), server.URL, map[string]string{"key": "value"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Posted!", result)
+	})
+
+	t.Run("nil body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, int64(0), r.ContentLength)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("empty"))
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		result, err := f.PostText(context.Background(), server.URL, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "empty", result)
+	})
+
+	t.Run("server returns error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		result, err := f.PostText(context.Background(), server.URL, map[string]string{"key": "value"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "422")
+		assert.Empty(t, result)
+	})
+}
+
+func TestFetch_PostResult(t *testing.T) {
+	type TestRequest struct {
+		Name string `json:"name"`
+	}
+
+	type TestResponse struct {
+		Message string `json:"message"`
+	}
+
+	t.Run("successful JSON round trip", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+
+			var payload TestRequest
+			err := json.NewDecoder(r.Body).Decode(&payload)
+			assert.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(TestResponse{Message: "hello " + payload.Name})
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		var result TestResponse
+		resp, err := f.PostResult(context.Background(), server.URL, nil, TestRequest{Name: "world"}, &result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode())
+		assert.Equal(t, "hello world", result.Message)
+	})
+
+	t.Run("per-request content type overrides default", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/vnd.api+json", r.Header.Get("Content-Type"))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"message": "ok"}`))
+		}))
+		defer server.Close()
+
+		headers := map[string]string{"Content-Type": "application/vnd.api+json"}
+
+		f := New(nil, 0, false)
+		var result TestResponse
+		_, err := f.PostResult(context.Background(), server.URL, headers, TestRequest{Name: "x"}, &result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", result.Message)
+	})
+
+	t.Run("server returns error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		var result TestResponse
+		resp, err := f.PostResult(context.Background(), server.URL, nil, TestRequest{}, &result)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "400")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+}
+
 func TestFetch_Integration_WithRetry(t *testing.T) {
 	t.Run("retry on server error", func(t *testing.T) {
 		var requestCount int
@@ -336,6 +618,245 @@ func TestFetch_Integration_WithRetry(t *testing.T) {
 	})
 }
 
+func TestFetch_SetRetryStatuses(t *testing.T) {
+	t.Run("non-listed status fails immediately", func(t *testing.T) {
+		var requestCount int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestCount++
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		f := New(nil, 2, false)
+		f.SetRetryStatuses([]int{http.StatusTooManyRequests, http.StatusServiceUnavailable})
+		_, err := f.GetText(context.Background(), server.URL)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "500")
+		assert.Equal(t, 1, requestCount)
+	})
+
+	t.Run("listed status is retried honoring Retry-After", func(t *testing.T) {
+		var requestCount int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestCount++
+			if requestCount == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		f := NewWithRetry(nil, 2, false, RetryConfig{BaseDelay: 10 * time.Millisecond})
+		f.SetRetryStatuses([]int{http.StatusTooManyRequests})
+
+		start := time.Now()
+		result, err := f.GetText(context.Background(), server.URL)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", result)
+		assert.Equal(t, 2, requestCount)
+		assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+	})
+
+	t.Run("empty slice restores the default", func(t *testing.T) {
+		f := New(nil, 2, false)
+		f.SetRetryStatuses([]int{http.StatusTooManyRequests})
+		assert.False(t, f.isRetryableStatus(http.StatusInternalServerError))
+
+		f.SetRetryStatuses(nil)
+		assert.True(t, f.isRetryableStatus(http.StatusInternalServerError))
+	})
+}
+
+func TestFetch_SetMaxBytes(t *testing.T) {
+	var requestCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"message": "this body is larger than the limit"}`))
+	}))
+	defer server.Close()
+
+	t.Run("body over the limit is rejected", func(t *testing.T) {
+		requestCount = 0
+		f := New(nil, 2, false)
+		f.SetMaxBytes(10)
+
+		_, err := f.GetText(context.Background(), server.URL)
+		assert.Error(t, err)
+		assert.Equal(t, "response exceeded max size of 10 bytes", err.Error())
+		assert.Equal(t, 1, requestCount)
+
+		var result map[string]string
+		_, err = f.GetResult(context.Background(), server.URL, nil, &result)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "response exceeded max size of 10 bytes")
+	})
+
+	t.Run("body within the limit", func(t *testing.T) {
+		f := New(nil, 0, false)
+		f.SetMaxBytes(1024)
+
+		result, err := f.GetText(context.Background(), server.URL)
+		assert.NoError(t, err)
+		assert.Contains(t, result, "larger than the limit")
+	})
+
+	t.Run("zero means unlimited", func(t *testing.T) {
+		f := New(nil, 0, false)
+		f.SetMaxBytes(10)
+		f.SetMaxBytes(0)
+
+		_, err := f.GetText(context.Background(), server.URL)
+		assert.NoError(t, err)
+	})
+}
+
+func TestFetch_SetMaxRedirects(t *testing.T) {
+	// Each /hop/N redirects to /hop/N-1 until /hop/0, which serves the final content
+	newServer := func(t *testing.T) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/hop/{n}", func(w http.ResponseWriter, r *http.Request) {
+			if n := r.PathValue("n"); n != "0" {
+				var hops int
+				fmt.Sscanf(n, "%d", &hops)
+				w.Header().Set("Location", fmt.Sprintf("/hop/%d", hops-1))
+				w.WriteHeader(http.StatusFound)
+				w.Write([]byte("redirecting"))
+				return
+			}
+			w.Write([]byte("final"))
+		})
+
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("follows redirects by default", func(t *testing.T) {
+		server := newServer(t)
+
+		f := New(nil, 0, false)
+		result, err := f.GetText(context.Background(), server.URL+"/hop/3")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "final", result)
+	})
+
+	t.Run("zero returns the redirect response", func(t *testing.T) {
+		server := newServer(t)
+
+		f := New(nil, 0, false)
+		f.SetMaxRedirects(0)
+
+		result, err := f.GetText(context.Background(), server.URL+"/hop/1")
+		assert.Equal(t, "redirecting", result)
+
+		var redirectErr *RedirectError
+		require.ErrorAs(t, err, &redirectErr)
+		assert.Equal(t, http.StatusFound, redirectErr.StatusCode)
+		assert.Equal(t, "/hop/0", redirectErr.Location)
+
+		resp, err := f.GetRaw(context.Background(), server.URL+"/hop/1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusFound, resp.StatusCode())
+		assert.Equal(t, "/hop/0", resp.Header().Get("Location"))
+	})
+
+	t.Run("stops after the limit", func(t *testing.T) {
+		server := newServer(t)
+
+		f := New(nil, 0, false)
+		f.SetMaxRedirects(2)
+
+		// The chain of /hop/1 is two requests long, the original one and its redirect
+		result, err := f.GetText(context.Background(), server.URL+"/hop/1")
+		assert.NoError(t, err)
+		assert.Equal(t, "final", result)
+
+		_, err = f.GetText(context.Background(), server.URL+"/hop/2")
+		assert.ErrorContains(t, err, "stopped after 2 redirects")
+	})
+
+	t.Run("applies to downloads", func(t *testing.T) {
+		server := newServer(t)
+
+		f := New(nil, 0, false)
+		f.SetMaxRedirects(0)
+
+		resp, err := f.httpClient.Get(server.URL + "/hop/1")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+		assert.Equal(t, "/hop/0", resp.Header.Get("Location"))
+	})
+}
+
+func TestFetch_TLS(t *testing.T) {
+	newServer := func() *httptest.Server {
+		return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("secure"))
+		}))
+	}
+
+	t.Run("self-signed certificate is rejected by default", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		_, err := f.GetText(context.Background(), server.URL)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+
+	t.Run("custom CA pool", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		pool := x509.NewCertPool()
+		pool.AddCert(server.Certificate())
+
+		f := New(nil, 0, false)
+		f.SetTLSConfig(&tls.Config{RootCAs: pool})
+
+		result, err := f.GetText(context.Background(), server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "secure", result)
+
+		// Downloads use the same configuration
+		req, err := f.NewRequest(server.URL, filepath.Join(t.TempDir(), "secure.txt"), nil)
+		require.NoError(t, err)
+		assert.NoError(t, f.DownloadFile(req).Error())
+	})
+
+	t.Run("skip verification", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		f := New(nil, 0, true)
+		f.SetAutoDecompress(true)
+		f.SetInsecureSkipVerify(true)
+
+		result, err := f.GetText(context.Background(), server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "secure", result)
+
+		// The shared HTTP/1.1 transport must not be modified
+		assert.Nil(t, http11Transport.TLSClientConfig)
+
+		f.SetInsecureSkipVerify(false)
+		_, err = f.GetText(context.Background(), server.URL)
+		assert.Error(t, err)
+	})
+}
+
 func TestFetch_UserAgent(t *testing.T) {
 	t.Run("default user agent is set", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
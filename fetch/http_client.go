@@ -2,9 +2,11 @@ package fetch
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -12,16 +14,78 @@ const maxRedirects = 5
 
 // safeCheckRedirect caps the redirect chain and refuses https→http downgrade.
 // Used by both the resty client and the idle-timeout client.
-func safeCheckRedirect(req *http.Request, via []*http.Request) error {
-	if len(via) >= maxRedirects {
-		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+var safeCheckRedirect = limitedCheckRedirect(maxRedirects)
+
+// limitedCheckRedirect returns a redirect policy that, like safeCheckRedirect, stops once the redirect chain reaches
+// limit requests and refuses https→http downgrade. A limit of 0 doesn't follow redirects at all, so the 3xx response
+// itself is returned.
+func limitedCheckRedirect(limit int) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if limit == 0 {
+			return http.ErrUseLastResponse
+		}
+
+		if len(via) >= limit {
+			return fmt.Errorf("stopped after %d redirects", limit)
+		}
+
+		if len(via) > 0 && via[0].URL.Scheme == "https" && req.URL.Scheme == "http" {
+			return fmt.Errorf("refusing redirect from https to http: %s", req.URL)
+		}
+
+		return nil
 	}
+}
 
-	if len(via) > 0 && via[0].URL.Scheme == "https" && req.URL.Scheme == "http" {
-		return fmt.Errorf("refusing redirect from https to http: %s", req.URL)
+// maxRetryAfter caps the wait requested by a Retry-After header when there's no RetryConfig.MaxDelay to cap it, so a
+// misbehaving server can't stall a retry for hours.
+var maxRetryAfter = 5 * time.Minute
+
+// retryAfter returns how long the server asked us to wait before retrying, based on the Retry-After header of 429 and
+// 503 responses, capped at limit. The header can be either a number of seconds or an HTTP date. It returns 0 when
+// there's no usable value.
+func retryAfter(status int, header http.Header, limit time.Duration) time.Duration {
+	if status != http.StatusTooManyRequests && status != http.StatusServiceUnavailable {
+		return 0
 	}
 
-	return nil
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds > 0 {
+		// Compare in seconds first, so huge values don't overflow the duration
+		if seconds >= int64(limit/time.Second) {
+			return limit
+		}
+		return min(time.Duration(seconds)*time.Second, limit)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return min(max(time.Until(date), 0), limit)
+	}
+
+	return 0
+}
+
+// withTLSConfig returns a copy of the transport using the given TLS configuration, looking through the transports that
+// wrap another one. Shared transports are never modified in place.
+func withTLSConfig(rt http.RoundTripper, config *tls.Config) http.RoundTripper {
+	switch t := rt.(type) {
+	case *decompressTransport:
+		return &decompressTransport{base: withTLSConfig(t.base, config)}
+	case *http.Transport:
+		clone := t.Clone()
+		clone.TLSClientConfig = config
+		return clone
+	case nil:
+		clone := http.DefaultTransport.(*http.Transport).Clone()
+		clone.TLSClientConfig = config
+		return clone
+	default:
+		return rt
+	}
 }
 
 type timeoutConn struct {
@@ -77,3 +77,50 @@ func TestSafeCheckRedirect(t *testing.T) {
 		assert.NoError(t, safeCheckRedirect(req, via))
 	})
 }
+
+func TestRetryAfter(t *testing.T) {
+	header := func(value string) http.Header {
+		h := http.Header{}
+		h.Set("Retry-After", value)
+		return h
+	}
+
+	t.Run("seconds on 429", func(t *testing.T) {
+		assert.Equal(t, 3*time.Second, retryAfter(http.StatusTooManyRequests, header("3"), maxRetryAfter))
+	})
+
+	t.Run("seconds on 503", func(t *testing.T) {
+		assert.Equal(t, 7*time.Second, retryAfter(http.StatusServiceUnavailable, header("7"), maxRetryAfter))
+	})
+
+	t.Run("HTTP date", func(t *testing.T) {
+		date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+		wait := retryAfter(http.StatusServiceUnavailable, header(date), maxRetryAfter)
+		assert.Greater(t, wait, 8*time.Second)
+		assert.LessOrEqual(t, wait, 10*time.Second)
+	})
+
+	t.Run("date in the past", func(t *testing.T) {
+		date := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+		assert.Equal(t, time.Duration(0), retryAfter(http.StatusServiceUnavailable, header(date), maxRetryAfter))
+	})
+
+	t.Run("ignored on other statuses", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), retryAfter(http.StatusInternalServerError, header("3"), maxRetryAfter))
+	})
+
+	t.Run("capped at the limit", func(t *testing.T) {
+		assert.Equal(t, maxRetryAfter, retryAfter(http.StatusTooManyRequests, header("86400"), maxRetryAfter))
+		assert.Equal(t, 2*time.Second, retryAfter(http.StatusTooManyRequests, header("86400"), 2*time.Second))
+		assert.Equal(t, maxRetryAfter, retryAfter(http.StatusTooManyRequests, header("9223372036854775807"),
+			maxRetryAfter))
+
+		date := time.Now().Add(24 * time.Hour).UTC().Format(http.TimeFormat)
+		assert.Equal(t, maxRetryAfter, retryAfter(http.StatusServiceUnavailable, header(date), maxRetryAfter))
+	})
+
+	t.Run("missing or invalid header", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), retryAfter(http.StatusTooManyRequests, http.Header{}, maxRetryAfter))
+		assert.Equal(t, time.Duration(0), retryAfter(http.StatusTooManyRequests, header("soon"), maxRetryAfter))
+	})
+}
@@ -2,28 +2,106 @@ package fetch
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/zeebo/blake3"
+	"golang.org/x/time/rate"
 )
 
+// RetryConfig
+
+// RetryConfig describes the backoff policy applied between retries. The delay before the n-th retry is
+// BaseDelay * Multiplier^(n-1), capped at MaxDelay.
+type RetryConfig struct {
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between retries; 0 means no cap.
+	MaxDelay time.Duration
+	// Multiplier is the growth factor between consecutive delays; values <= 1 result in a fixed delay.
+	Multiplier float64
+	// Jitter randomizes each delay within [delay/2, delay] so clients don't retry in lockstep.
+	Jitter bool
+}
+
 // Request
 
 type Request struct {
 	Url      string
 	FilePath string
 
-	httpReq *http.Request
+	// Mirrors are fallback URLs for the same file, tried in order when Url (and then each previous mirror) still fails
+	// after exhausting the retries.
+	Mirrors []string
+
+	// HashAlgo selects the algorithm used to compute Response.Hash: "blake3" (the default), "sha256", or "sha512". When
+	// empty, the algorithm given to ExpectHash is used, if it's supported.
+	HashAlgo string
+
+	// OnProgress, when set, is called as bytes arrive with the total downloaded so far (including any resumed bytes)
+	// and the expected size of the file (-1 if unknown).
+	OnProgress func(downloaded, total int64) `json:"-"`
+
+	httpReq      *http.Request
+	expectedAlgo string
+	expectedHash string
+}
+
+// ExpectHash sets the checksum the downloaded file must match. When the computed hash differs, the download fails with
+// a checksum mismatch error and the file is left on disk for inspection.
+//
+// # Parameters:
+//   - algo: the hash algorithm: "blake3", "sha256", or "sha512"; unless HashAlgo is set, the download is hashed with it.
+//   - expected: the expected digest as a hexadecimal string (case-insensitive).
+func (r *Request) ExpectHash(algo, expected string) {
+	r.expectedAlgo = strings.ToLower(algo)
+	r.expectedHash = strings.ToLower(expected)
+}
+
+// hashAlgo returns the algorithm used to hash the download: HashAlgo, or else the supported algorithm of ExpectHash,
+// or else blake3.
+func (r *Request) hashAlgo() string {
+	if r.HashAlgo != "" {
+		return strings.ToLower(r.HashAlgo)
+	}
+	if _, ok := hashAlgos[r.expectedAlgo]; ok {
+		return r.expectedAlgo
+	}
+
+	return "blake3"
+}
+
+// newHasher creates the hasher of the algorithm returned by hashAlgo.
+func (r *Request) newHasher() (hash.Hash, error) {
+	newHash, ok := hashAlgos[r.hashAlgo()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm: %s", r.HashAlgo)
+	}
+
+	return newHash(), nil
+}
+
+var hashAlgos = map[string]func() hash.Hash{
+	"blake3": func() hash.Hash { return blake3.New() },
+	"sha256": sha256.New,
+	"sha512": sha512.New,
 }
 
 // Response
 
 type Response struct {
-	Request    *Request
+	Request *Request
+
+	// Url is the URL the file was downloaded from: the request's Url, or one of its mirrors.
+	Url        string
 	StatusCode int
 	Size       int64
 	Downloaded int64
@@ -102,9 +180,52 @@ func (r *Response) Track(callback func(completed, total int64, progress float64)
 
 // ProgressWriter
 
+// maxSizeError is returned when a response or download grows past the limit set by SetMaxBytes.
+type maxSizeError struct {
+	limit int64
+}
+
+func (e *maxSizeError) Error() string {
+	return fmt.Sprintf("response exceeded max size of %d bytes", e.limit)
+}
+
+func isMaxSizeError(err error) bool {
+	var sizeErr *maxSizeError
+	return errors.As(err, &sizeErr)
+}
+
+// RedirectError is returned by GetText and PostText when the server answers with a redirect that isn't followed,
+// because redirects were disabled with SetMaxRedirects(0). It carries the target of the redirect, e.g. to capture the
+// URL handed out by a signed-URL redirector.
+type RedirectError struct {
+	// StatusCode is the 3xx status of the response.
+	StatusCode int
+	// Location is the value of the Location header, the target of the redirect.
+	Location string
+}
+
+func (e *RedirectError) Error() string {
+	return fmt.Sprintf("redirect not followed: %d to %s", e.StatusCode, e.Location)
+}
+
+// limitWriter aborts a download once the bytes downloaded so far, including any resumed offset, would exceed limit.
+type limitWriter struct {
+	writer   io.Writer
+	response *Response
+	limit    int64
+}
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	if w.response.Downloaded+int64(len(p)) > w.limit {
+		return 0, &maxSizeError{limit: w.limit}
+	}
+
+	return w.writer.Write(p)
+}
+
 type progressWriter struct {
 	file     io.Writer
-	hasher   *blake3.Hasher
+	hasher   hash.Hash
 	callback func(downloaded int64)
 }
 
@@ -128,10 +249,60 @@ func (pw *progressWriter) Write(p []byte) (int, error) {
 	return n, nil
 }
 
+// ThrottledWriter
+
+// throttledWriter paces writes to the underlying writer so they never exceed the rate of any of its limiters.
+type throttledWriter struct {
+	ctx      context.Context
+	writer   io.Writer
+	limiters []*rate.Limiter
+}
+
+func (tw *throttledWriter) Write(p []byte) (int, error) {
+	written := 0
+
+	for len(p) > 0 {
+		size := len(p)
+		for _, limiter := range tw.limiters {
+			size = min(size, limiter.Burst())
+		}
+
+		for _, limiter := range tw.limiters {
+			if err := limiter.WaitN(tw.ctx, size); err != nil {
+				return written, err
+			}
+		}
+
+		n, err := tw.writer.Write(p[:size])
+		written += n
+		if err != nil {
+			return written, err
+		}
+
+		p = p[size:]
+	}
+
+	return written, nil
+}
+
+// newByteLimiter creates a token-bucket limiter for n bytes per second, or nil if n is not positive.
+func newByteLimiter(n int64) *rate.Limiter {
+	if n <= 0 {
+		return nil
+	}
+
+	return rate.NewLimiter(rate.Limit(n), int(min(n, 1<<20)))
+}
+
 // Cookies
 
-// Cookie represents a key-value pair for a typical HTTP cookie.
+// Cookie represents a key-value pair for a typical HTTP cookie. Domain, Path, Expires and Secure are optional and are
+// filled when the source provides them, so cookies can be written back without losing data.
 type Cookie struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
+	Name    string    `json:"name"`
+	Value   string    `json:"value"`
+	Domain  string    `json:"domain,omitempty"`
+	Path    string    `json:"path,omitempty"`
+	Expires time.Time `json:"expires,omitzero"`
+	Secure  bool      `json:"secure,omitempty"`
 }
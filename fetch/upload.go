@@ -0,0 +1,163 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/samber/lo"
+)
+
+// Upload performs a multipart/form-data POST request to the specified URL and unmarshals the response body into the
+// provided result interface. The files are streamed from the disk as the request is sent, so they're never buffered in
+// memory; every retry reads them again from the start.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts.
+//   - url: the URL to send the POST request to.
+//   - fields: the form fields to send, mapping field names to values.
+//   - files: the files to send, mapping form field names to local file paths; the file name sent is the base name of
+//     the path.
+//   - result: a pointer to the variable where the response body will be unmarshalled.
+//
+// Returns:
+//   - *resty.Response: the response from the POST request.
+//   - error: an error if a file can't be read, the request fails, or the response indicates an error.
+func (f *Fetch) Upload(
+	ctx context.Context,
+	url string,
+	fields map[string]string,
+	files map[string]string,
+	result any,
+) (*resty.Response, error) {
+	body, err := newMultipartBody(fields, files)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	headers := map[string]string{"Content-Type": body.contentType()}
+	return f.doRequest(ctx, url, headers, body, result, "POST")
+}
+
+// region - Private functions
+
+// multipartBody is a request body that streams a multipart form through a pipe. Each attempt of the request reads a
+// new pipe, which is fed from the start by its own goroutine.
+type multipartBody struct {
+	fields   map[string]string
+	files    map[string]string
+	boundary string
+
+	mu     sync.Mutex
+	reader *io.PipeReader
+}
+
+func newMultipartBody(fields map[string]string, files map[string]string) (*multipartBody, error) {
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read file: %w", err)
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("could not read file: %s is a directory", path)
+		}
+	}
+
+	return &multipartBody{
+		fields:   fields,
+		files:    files,
+		boundary: multipart.NewWriter(io.Discard).Boundary(),
+	}, nil
+}
+
+func (b *multipartBody) contentType() string {
+	return "multipart/form-data; boundary=" + b.boundary
+}
+
+func (b *multipartBody) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	if b.reader == nil {
+		pr, pw := io.Pipe()
+		go func() { pw.CloseWithError(b.write(pw)) }()
+		b.reader = pr
+	}
+	reader := b.reader
+	b.mu.Unlock()
+
+	return reader.Read(p)
+}
+
+// Close stops the goroutine feeding the current pipe, if any; the next Read starts the form again.
+func (b *multipartBody) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.reader != nil {
+		b.reader.Close()
+		b.reader = nil
+	}
+
+	return nil
+}
+
+func (b *multipartBody) write(w io.Writer) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(b.boundary); err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(b.fields) {
+		if err := mw.WriteField(name, b.fields[name]); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range sortedKeys(b.files) {
+		if err := writeFormFile(mw, name, b.files[name]); err != nil {
+			return err
+		}
+	}
+
+	return mw.Close()
+}
+
+func writeFormFile(mw *multipart.Writer, name, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open file: %w", err)
+	}
+	defer file.Close()
+
+	part, err := mw.CreateFormFile(name, filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, file)
+	return err
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := lo.Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+// resetMultipartBody is a request middleware that rewinds multipart bodies before each attempt, so retries send the
+// whole form again.
+func resetMultipartBody(_ *resty.Client, r *resty.Request) error {
+	if body, ok := r.Body.(*multipartBody); ok {
+		return body.Close()
+	}
+
+	return nil
+}
+
+// endregion
@@ -0,0 +1,160 @@
+package fetch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpload(t *testing.T) {
+	createFile := func(t *testing.T, name, content string) string {
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	// readForm parses the multipart request, failing the test if it's malformed
+	readForm := func(t *testing.T, r *http.Request) (map[string]string, map[string]string) {
+		reader, err := r.MultipartReader()
+		require.NoError(t, err)
+
+		fields := make(map[string]string)
+		files := make(map[string]string)
+		for {
+			part, partErr := reader.NextPart()
+			if partErr == io.EOF {
+				break
+			}
+			require.NoError(t, partErr)
+
+			data, readErr := io.ReadAll(part)
+			require.NoError(t, readErr)
+
+			if part.FileName() != "" {
+				files[part.FormName()] = part.FileName() + ":" + string(data)
+			} else {
+				fields[part.FormName()] = string(data)
+			}
+		}
+
+		return fields, files
+	}
+
+	t.Run("uploads fields and files", func(t *testing.T) {
+		avatar := createFile(t, "avatar.png", "fake image bytes")
+		resume := createFile(t, "resume.pdf", strings.Repeat("pdf ", 10000))
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, userAgent, r.Header.Get("User-Agent"))
+			assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data; boundary="))
+
+			// The body is streamed, so its length isn't known up front
+			assert.Equal(t, int64(-1), r.ContentLength)
+
+			fields, files := readForm(t, r)
+			assert.Equal(t, map[string]string{"name": "John", "role": "admin"}, fields)
+			assert.Equal(t, map[string]string{
+				"avatar": "avatar.png:fake image bytes",
+				"resume": "resume.pdf:" + strings.Repeat("pdf ", 10000),
+			}, files)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": 42}`))
+		}))
+		defer server.Close()
+
+		var result struct {
+			ID int `json:"id"`
+		}
+
+		f := New(nil, 0, false)
+		resp, err := f.Upload(context.Background(), server.URL,
+			map[string]string{"name": "John", "role": "admin"},
+			map[string]string{"avatar": avatar, "resume": resume},
+			&result,
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
+		assert.Equal(t, 42, result.ID)
+	})
+
+	t.Run("retries send the whole form again", func(t *testing.T) {
+		file := createFile(t, "data.txt", "retried content")
+
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, files := readForm(t, r)
+			assert.Equal(t, map[string]string{"file": "data.txt:retried content"}, files)
+
+			if attempts.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		f := NewWithRetry(nil, 2, false, RetryConfig{BaseDelay: 10 * time.Millisecond})
+		_, err := f.Upload(context.Background(), server.URL, nil, map[string]string{"file": file}, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), attempts.Load())
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		file := createFile(t, "data.txt", "content")
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		resp, err := f.Upload(context.Background(), server.URL, nil, map[string]string{"file": file}, nil)
+
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode())
+	})
+
+	t.Run("missing file fails before sending", func(t *testing.T) {
+		var requested atomic.Bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested.Store(true)
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		resp, err := f.Upload(context.Background(), server.URL, nil,
+			map[string]string{"file": filepath.Join(t.TempDir(), "missing.txt")}, nil)
+
+		assert.Nil(t, resp)
+		assert.ErrorContains(t, err, "could not read file")
+		assert.False(t, requested.Load())
+	})
+
+	t.Run("server that doesn't read the body", func(t *testing.T) {
+		file := createFile(t, "large.bin", strings.Repeat("x", 1<<20))
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		f := New(nil, 0, false)
+		_, err := f.Upload(context.Background(), server.URL, nil, map[string]string{"file": file}, nil)
+
+		assert.Error(t, err)
+	})
+}
@@ -4,13 +4,31 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 )
 
+// checkReservedNames controls whether Windows reserved device names are rejected in archive entries; it's a variable so
+// that tests can exercise the check on every platform.
+var checkReservedNames = runtime.GOOS == "windows"
+
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true,
+	"COM9": true, "LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true,
+	"LPT8": true, "LPT9": true,
+}
+
 // sanitizeArchivePath validates an archive entry name against path-traversal attacks and returns the safe on-disk path
 // rooted at targetDir. It rejects absolute paths (Unix/Windows), ".." segments, and any entry whose final resolved
-// location escapes targetDir.
+// location escapes targetDir. Names containing NUL or other control characters are rejected too, as well as Windows
+// reserved device names (CON, PRN, NUL, COM1, etc.) when running on Windows.
 func sanitizeArchivePath(entryName, targetDir string) (string, error) {
+	// Reject NUL and other control characters that can truncate or confuse paths
+	if strings.ContainsFunc(entryName, isControlChar) {
+		return "", fmt.Errorf("illegal file path: %q", entryName)
+	}
+
 	// Normalize separators to forward slashes for analysis
 	name := strings.ReplaceAll(entryName, "\\", "/")
 
@@ -19,9 +37,9 @@ func sanitizeArchivePath(entryName, targetDir string) (string, error) {
 		return "", fmt.Errorf("illegal file path: %s", entryName)
 	}
 
-	// Reject any ".." segment
+	// Reject any ".." segment, and reserved device names on Windows
 	for _, seg := range strings.Split(strings.TrimPrefix(name, "/"), "/") {
-		if seg == ".." {
+		if seg == ".." || (checkReservedNames && isWindowsReservedName(seg)) {
 			return "", fmt.Errorf("illegal file path: %s", entryName)
 		}
 	}
@@ -55,3 +73,19 @@ func sanitizeArchiveSymlink(linkPath, linkTarget, targetDir string) error {
 	}
 	return nil
 }
+
+// region - Private functions
+
+func isControlChar(r rune) bool {
+	return r < 0x20 || r == 0x7F
+}
+
+// isWindowsReservedName reports whether a path segment refers to a Windows device. Windows ignores the extension and
+// any trailing dots or spaces, so "con.txt" and "NUL " are reserved too.
+func isWindowsReservedName(segment string) bool {
+	base, _, _ := strings.Cut(segment, ".")
+	base = strings.TrimRight(base, " ")
+	return windowsReservedNames[strings.ToUpper(base)]
+}
+
+// endregion
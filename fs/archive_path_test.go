@@ -0,0 +1,48 @@
+package fs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeArchivePath(t *testing.T) {
+	targetDir := t.TempDir()
+
+	t.Run("valid names", func(t *testing.T) {
+		for _, name := range []string{"file.txt", "dir/file.txt", "./dir/file.txt", "console.txt", "CONFIG/com10"} {
+			path, err := sanitizeArchivePath(name, targetDir)
+			require.NoError(t, err, name)
+			assert.Equal(t, filepath.Join(targetDir, filepath.FromSlash(name)), path)
+		}
+	})
+
+	t.Run("control characters", func(t *testing.T) {
+		for _, name := range []string{"file\x00.txt", "dir\x01/file.txt", "tab\tname", "del\x7f"} {
+			_, err := sanitizeArchivePath(name, targetDir)
+			assert.ErrorContains(t, err, "illegal file path", name)
+		}
+	})
+
+	t.Run("windows reserved names", func(t *testing.T) {
+		original := checkReservedNames
+		checkReservedNames = true
+		t.Cleanup(func() { checkReservedNames = original })
+
+		for _, name := range []string{"CON", "prn.txt", "dir/aux", "Nul ", "com1.log", "LPT9/file.txt", "dir\\nul.tar.gz"} {
+			_, err := sanitizeArchivePath(name, targetDir)
+			assert.ErrorContains(t, err, "illegal file path", name)
+		}
+	})
+
+	t.Run("reserved names are allowed elsewhere", func(t *testing.T) {
+		original := checkReservedNames
+		checkReservedNames = false
+		t.Cleanup(func() { checkReservedNames = original })
+
+		_, err := sanitizeArchivePath("dir/CON", targetDir)
+		assert.NoError(t, err)
+	})
+}
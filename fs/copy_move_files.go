@@ -1,7 +1,9 @@
 package fs
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"slices"
@@ -16,6 +18,9 @@ type CmFlags uint8
 const (
 	CmRecursive CmFlags = 1 << iota
 	CmPreserveStructure
+	CmNoClobber
+	CmPreserveMeta
+	CmMergeContents
 )
 
 // CopyFiles copies files and/or directories to a destination directory.
@@ -23,6 +28,12 @@ const (
 // The flags parameter controls the copy behavior:
 //   - CmRecursive: Include subdirectories when copying directories
 //   - CmPreserveStructure: Preserve directory structure (if false, all files are flattened to destDir)
+//   - CmNoClobber: When flattening or merging, rename files whose name is already taken to "file (1).txt",
+//     "file (2).txt", etc. instead of overwriting them; files already copied with the same content are skipped
+//   - CmPreserveMeta: Keep the modification time and permission bits of the source files
+//   - CmMergeContents: With CmPreserveStructure, copy the contents of source directories directly into destDir, like
+//     "cp -r src/. dest/", instead of into destDir/<source name>; existing files in destDir are overwritten, unless
+//     CmNoClobber is also set
 //   - 0 (no flags): Non-recursive copy with flattened structure (default behavior)
 //
 // The exts parameter filters files by extension (case-insensitive, e.g., []string{".jpg", ".png"}).
@@ -47,7 +58,33 @@ func CopyFiles(
 	flags CmFlags,
 	exts []string,
 ) error {
-	return transferFiles(sources, destDir, flags, exts, false)
+	return transferFiles(sources, destDir, flags, exts, nil, false)
+}
+
+// CopyFilesExclude copies files and/or directories to a destination directory like CopyFiles, skipping the entries that
+// match any of the exclude patterns.
+//
+// The patterns use the same glob syntax as ListPathGlob and are matched against the path of each entry relative to the
+// source directory it belongs to, e.g. "node_modules", ".git" or "**/testdata/*.json". An excluded directory is pruned
+// with its whole subtree, so it's never traversed.
+//
+// # Example:
+//
+//	// Copy a project recursively, preserving structure, without its dependencies and VCS metadata
+//	err := CopyFilesExclude([]string{"project"}, "dest", CmRecursive|CmPreserveStructure, nil,
+//		[]string{"node_modules", ".git"})
+func CopyFilesExclude(
+	sources []string,
+	destDir string,
+	flags CmFlags,
+	exts []string,
+	exclude []string,
+) error {
+	if err := validateGlobs(exclude); err != nil {
+		return err
+	}
+
+	return transferFiles(sources, destDir, flags, exts, exclude, false)
 }
 
 // MoveFiles moves files and/or directories to a destination directory.
@@ -55,6 +92,11 @@ func CopyFiles(
 // The flags parameter controls the move behavior:
 //   - CmRecursive: Include subdirectories when moving directories
 //   - CmPreserveStructure: Preserve directory structure (if false, all files are flattened to destDir)
+//   - CmNoClobber: When flattening or merging, rename files whose name is already taken to "file (1).txt",
+//     "file (2).txt", etc. instead of overwriting them; files already moved with the same content are skipped
+//   - CmPreserveMeta: Keep the modification time and permission bits of the source files
+//   - CmMergeContents: With CmPreserveStructure, move the contents of source directories directly into destDir instead
+//     of into destDir/<source name>; existing files in destDir are overwritten, unless CmNoClobber is also set
 //   - 0 (no flags): Non-recursive move with flattened structure (default behavior)
 //
 // The exts parameter filters files by extension (case-insensitive, e.g., []string{".jpg", ".png"}).
@@ -79,34 +121,102 @@ func MoveFiles(
 	flags CmFlags,
 	exts []string,
 ) error {
-	return transferFiles(sources, destDir, flags, exts, true)
+	return transferFiles(sources, destDir, flags, exts, nil, true)
+}
+
+// DeleteFiles deletes files and/or directories.
+//
+// The flags parameter controls the delete behavior:
+//   - CmRecursive: Include subdirectories when deleting directories; without an extension filter, the whole directory is
+//     removed
+//   - 0 (no flags): Delete only the first-level files of directories, keeping the directories themselves
+//
+// The exts parameter filters files by extension (case-insensitive, e.g., []string{".tmp", ".log"}). Files that don't
+// match are skipped. If nil or empty, no extension filtering is applied.
+//
+// # Example:
+//
+//	// Delete all .tmp files recursively
+//	err := DeleteFiles([]string{"cache"}, CmRecursive, []string{".tmp"})
+//
+//	// Delete a directory and everything inside it
+//	err := DeleteFiles([]string{"build"}, CmRecursive, nil)
+//
+//	// Delete only first-level files (no flags)
+//	err := DeleteFiles([]string{"logs"}, 0, nil)
+func DeleteFiles(sources []string, flags CmFlags, exts []string) error {
+	recursive := flags&CmRecursive != 0
+	normalizedExts := normalizeExts(exts)
+
+	for _, source := range sources {
+		info, err := os.Stat(source)
+		if err != nil {
+			return fmt.Errorf("failed to stat source %s: %w", source, err)
+		}
+
+		if !info.IsDir() {
+			if !matchesExt(source, normalizedExts) {
+				continue
+			}
+
+			if err := os.Remove(source); err != nil {
+				return fmt.Errorf("failed to remove file %s: %w", source, err)
+			}
+			continue
+		}
+
+		if recursive && len(normalizedExts) == 0 {
+			if err := os.RemoveAll(source); err != nil {
+				return fmt.Errorf("failed to remove directory %s: %w", source, err)
+			}
+			continue
+		}
+
+		if err := removeFilteredFiles(source, recursive, normalizedExts); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // region - Private functions
 
+// normalizeExts converts extensions to lowercase with a leading dot.
+func normalizeExts(exts []string) []string {
+	return lo.Map(exts, func(ext string, _ int) string {
+		ext = strings.ToLower(ext)
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		return ext
+	})
+}
+
+// matchesExt reports whether the file has one of the normalized extensions; any file matches an empty filter.
+func matchesExt(path string, normalizedExts []string) bool {
+	if len(normalizedExts) == 0 {
+		return true
+	}
+
+	return slices.Contains(normalizedExts, strings.ToLower(filepath.Ext(path)))
+}
+
 func transferFiles(
 	sources []string,
 	destDir string,
 	flags CmFlags,
 	exts []string,
+	exclude []string,
 	move bool,
 ) error {
-	recursive := flags&CmRecursive != 0
-	preserveStructure := flags&CmPreserveStructure != 0
-
 	// If sources is empty, just create the destination directory
 	if len(sources) == 0 {
 		return os.MkdirAll(destDir, 0755)
 	}
 
 	// Normalize extensions to lowercase with leading dot
-	normalizedExts := lo.Map(exts, func(ext string, _ int) string {
-		ext = strings.ToLower(ext)
-		if !strings.HasPrefix(ext, ".") {
-			ext = "." + ext
-		}
-		return ext
-	})
+	normalizedExts := normalizeExts(exts)
 
 	// Transfer each source
 	for _, source := range sources {
@@ -116,11 +226,11 @@ func transferFiles(
 		}
 
 		if info.IsDir() {
-			if err := transferDirectory(source, destDir, recursive, preserveStructure, normalizedExts, move); err != nil {
+			if err := transferDirectory(source, destDir, flags, normalizedExts, exclude, move); err != nil {
 				return err
 			}
 		} else {
-			if err := transferSingleFile(source, destDir, normalizedExts, move); err != nil {
+			if err := transferSingleFile(source, destDir, flags, normalizedExts, exclude, move); err != nil {
 				return err
 			}
 		}
@@ -129,15 +239,21 @@ func transferFiles(
 	return nil
 }
 
-func transferDirectory(source, destDir string, recursive, preserveStructure bool, normalizedExts []string, move bool) error {
+func transferDirectory(
+	source, destDir string,
+	flags CmFlags,
+	normalizedExts, exclude []string,
+	move bool,
+) error {
+	recursive := flags&CmRecursive != 0
 	hasExtFilter := len(normalizedExts) > 0
 
-	if preserveStructure {
-		if err := copyWithStructure(source, destDir, recursive, hasExtFilter, normalizedExts); err != nil {
+	if flags&CmPreserveStructure != 0 {
+		if err := copyWithStructure(source, destDir, flags, normalizedExts, exclude); err != nil {
 			return err
 		}
 	} else {
-		if err := copyFlattened(source, destDir, recursive, hasExtFilter, normalizedExts); err != nil {
+		if err := copyFlattened(source, destDir, flags, normalizedExts, exclude); err != nil {
 			return err
 		}
 	}
@@ -186,8 +302,7 @@ func removeFilteredFiles(source string, recursive bool, normalizedExts []string)
 		}
 
 		// Remove files that match the extension filter
-		ext := strings.ToLower(filepath.Ext(path))
-		if slices.Contains(normalizedExts, ext) {
+		if matchesExt(path, normalizedExts) {
 			if err := os.Remove(path); err != nil {
 				return fmt.Errorf("failed to remove file %s: %w", path, err)
 			}
@@ -197,29 +312,52 @@ func removeFilteredFiles(source string, recursive bool, normalizedExts []string)
 	})
 }
 
-func copyWithStructure(source, destDir string, recursive bool, hasExtFilter bool, normalizedExts []string) error {
+func copyWithStructure(source, destDir string, flags CmFlags, normalizedExts, exclude []string) error {
+	recursive := flags&CmRecursive != 0
+	hasExtFilter := len(normalizedExts) > 0
+
 	// Ensure destination directory exists
 	if err := os.MkdirAll(destDir, 0755); err != nil {
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
+	// Merging copies the children of source into destDir, instead of nesting them under its name
+	merge := flags&CmMergeContents != 0
 	destPath := filepath.Join(destDir, filepath.Base(source))
+	if merge {
+		destPath = destDir
+	}
 
-	opts := copy.Options{
-		Skip: func(srcInfo os.FileInfo, src, dest string) (bool, error) {
-			// Skip subdirectories if not recursive
-			if !recursive && srcInfo.IsDir() && src != source {
-				return true, nil
-			}
+	opts := copyOptions(flags)
+	opts.Skip = func(srcInfo os.FileInfo, src, dest string) (bool, error) {
+		// Skip excluded entries; for directories, this prunes their whole subtree
+		if isExcludedFrom(source, src, exclude) {
+			return true, nil
+		}
 
-			// Apply extension filter to files only
-			if hasExtFilter && !srcInfo.IsDir() {
-				ext := strings.ToLower(filepath.Ext(src))
-				return !slices.Contains(normalizedExts, ext), nil
-			}
+		// Skip subdirectories if not recursive
+		if !recursive && srcInfo.IsDir() && src != source {
+			return true, nil
+		}
 
+		if srcInfo.IsDir() {
 			return false, nil
-		},
+		}
+
+		// Apply extension filter to files only
+		if hasExtFilter && !slices.Contains(normalizedExts, strings.ToLower(filepath.Ext(src))) {
+			return true, nil
+		}
+
+		// When merging without clobbering, a file whose name is already taken is copied aside (or skipped if it's
+		// identical) by copyFileTo, and left out of the directory copy
+		if merge && flags&CmNoClobber != 0 {
+			if _, err := os.Lstat(dest); err == nil {
+				return true, copyFileTo(src, filepath.Dir(dest), flags)
+			}
+		}
+
+		return false, nil
 	}
 
 	if err := copy.Copy(source, destPath, opts); err != nil {
@@ -228,7 +366,10 @@ func copyWithStructure(source, destDir string, recursive bool, hasExtFilter bool
 	return nil
 }
 
-func copyFlattened(source, destDir string, recursive bool, hasExtFilter bool, normalizedExts []string) error {
+func copyFlattened(source, destDir string, flags CmFlags, normalizedExts, exclude []string) error {
+	recursive := flags&CmRecursive != 0
+	hasExtFilter := len(normalizedExts) > 0
+
 	// Ensure destination directory exists
 	if err := os.MkdirAll(destDir, 0755); err != nil {
 		return fmt.Errorf("failed to create destination directory: %w", err)
@@ -239,6 +380,14 @@ func copyFlattened(source, destDir string, recursive bool, hasExtFilter bool, no
 			return err
 		}
 
+		// Skip excluded entries; for directories, this prunes their whole subtree
+		if isExcludedFrom(source, path, exclude) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
 		// Handle directories
 		if info.IsDir() {
 			if !recursive && path != source {
@@ -256,16 +405,151 @@ func copyFlattened(source, destDir string, recursive bool, hasExtFilter bool, no
 		}
 
 		// Copy file
-		destPath := filepath.Join(destDir, filepath.Base(path))
-		if err := copy.Copy(path, destPath); err != nil {
-			return fmt.Errorf("failed to copy file %s: %w", path, err)
+		return copyFileTo(path, destDir, flags)
+	})
+}
+
+// isExcludedFrom reports whether the path, relative to the source directory, matches any of the exclude patterns. The
+// source directory itself is never excluded.
+func isExcludedFrom(source, path string, exclude []string) bool {
+	if len(exclude) == 0 {
+		return false
+	}
+
+	rel, err := filepath.Rel(source, path)
+	if err != nil || rel == "." {
+		return false
+	}
+
+	return isExcluded(exclude, filepath.ToSlash(rel))
+}
+
+// copyOptions returns the options used to copy files and directories with the given flags.
+func copyOptions(flags CmFlags) copy.Options {
+	opts := copy.Options{}
+
+	if flags&CmPreserveMeta != 0 {
+		opts.PermissionControl = copy.PerservePermission
+		opts.PreserveTimes = true
+	}
+
+	return opts
+}
+
+// copyFileTo copies a file into destDir, keeping its name. With CmNoClobber, a name that is already taken by a different
+// file gets a " (n)" suffix, and the copy is skipped if one of the candidates already has the same content.
+func copyFileTo(source, destDir string, flags CmFlags) error {
+	destPath := filepath.Join(destDir, filepath.Base(source))
+
+	if flags&CmNoClobber != 0 {
+		path, copied, err := freeDestPath(source, destPath)
+		if err != nil {
+			return err
+		}
+		if copied {
+			return nil
 		}
 
-		return nil
-	})
+		destPath = path
+	}
+
+	if err := copy.Copy(source, destPath, copyOptions(flags)); err != nil {
+		return fmt.Errorf("failed to copy file %s: %w", source, err)
+	}
+
+	return nil
 }
 
-func transferSingleFile(source, destDir string, normalizedExts []string, move bool) error {
+// freeDestPath finds the first of "name.ext", "name (1).ext", "name (2).ext", ... that is either free or already holds a
+// copy of source, reporting which of the two it is.
+func freeDestPath(source, destPath string) (string, bool, error) {
+	dir := filepath.Dir(destPath)
+	ext := filepath.Ext(destPath)
+	name := strings.TrimSuffix(filepath.Base(destPath), ext)
+
+	for i := 0; ; i++ {
+		candidate := destPath
+		if i > 0 {
+			candidate = filepath.Join(dir, fmt.Sprintf("%s (%d)%s", name, i, ext))
+		}
+
+		if _, err := os.Lstat(candidate); os.IsNotExist(err) {
+			return candidate, false, nil
+		} else if err != nil {
+			return "", false, fmt.Errorf("failed to stat destination %s: %w", candidate, err)
+		}
+
+		same, err := sameContent(source, candidate)
+		if err != nil {
+			return "", false, err
+		}
+		if same {
+			return candidate, true, nil
+		}
+	}
+}
+
+// sameContent reports whether two files have identical contents.
+func sameContent(a, b string) (bool, error) {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat file %s: %w", a, err)
+	}
+
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat file %s: %w", b, err)
+	}
+
+	if !infoB.Mode().IsRegular() || infoA.Size() != infoB.Size() {
+		return false, nil
+	}
+
+	fileA, err := os.Open(a)
+	if err != nil {
+		return false, fmt.Errorf("failed to open file %s: %w", a, err)
+	}
+	defer fileA.Close()
+
+	fileB, err := os.Open(b)
+	if err != nil {
+		return false, fmt.Errorf("failed to open file %s: %w", b, err)
+	}
+	defer fileB.Close()
+
+	bufA := make([]byte, 32*1024)
+	bufB := make([]byte, 32*1024)
+
+	for {
+		n, errA := io.ReadFull(fileA, bufA)
+		_, errB := io.ReadFull(fileB, bufB[:n])
+
+		if errB != nil && errB != io.EOF && errB != io.ErrUnexpectedEOF {
+			return false, fmt.Errorf("failed to read file %s: %w", b, errB)
+		}
+		if !bytes.Equal(bufA[:n], bufB[:n]) {
+			return false, nil
+		}
+
+		if errA == io.EOF || errA == io.ErrUnexpectedEOF {
+			return true, nil
+		}
+		if errA != nil {
+			return false, fmt.Errorf("failed to read file %s: %w", a, errA)
+		}
+	}
+}
+
+func transferSingleFile(
+	source, destDir string,
+	flags CmFlags,
+	normalizedExts, exclude []string,
+	move bool,
+) error {
+	if isExcluded(exclude, filepath.Base(source)) {
+		return nil
+	}
+
 	if len(normalizedExts) > 0 {
 		ext := strings.ToLower(filepath.Ext(source))
 		if matched := slices.Contains(normalizedExts, ext); !matched {
@@ -278,9 +562,8 @@ func transferSingleFile(source, destDir string, normalizedExts []string, move bo
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
-	destPath := filepath.Join(destDir, filepath.Base(source))
-	if err := copy.Copy(source, destPath); err != nil {
-		return fmt.Errorf("failed to copy file %s: %w", source, err)
+	if err := copyFileTo(source, destDir, flags); err != nil {
+		return err
 	}
 
 	// Remove source if moving
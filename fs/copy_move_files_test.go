@@ -3,7 +3,9 @@ package fs
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -150,6 +152,53 @@ func TestCopyFiles(t *testing.T) {
 		assert.FileExists(t, filepath.Join(destDir, "src", "subdir", "file2.txt"))
 	})
 
+	t.Run("copy directory contents merged into existing destination", func(t *testing.T) {
+		tempDir := t.TempDir()
+		srcDir := filepath.Join(tempDir, "src")
+		destDir := filepath.Join(tempDir, "dest")
+
+		require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "subdir"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "file1.txt"), []byte("new file1"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "subdir", "file2.txt"), []byte("file2"), 0644))
+
+		require.NoError(t, os.MkdirAll(filepath.Join(destDir, "subdir"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, "file1.txt"), []byte("old file1"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, "subdir", "existing.txt"), []byte("existing"), 0644))
+
+		err := CopyFiles([]string{srcDir}, destDir, CmRecursive|CmPreserveStructure|CmMergeContents, nil)
+
+		require.NoError(t, err)
+		assertFileExists(t, filepath.Join(destDir, "file1.txt"), "new file1")
+		assertFileExists(t, filepath.Join(destDir, "subdir", "file2.txt"), "file2")
+		assertFileExists(t, filepath.Join(destDir, "subdir", "existing.txt"), "existing")
+		assert.NoDirExists(t, filepath.Join(destDir, "src"))
+	})
+
+	t.Run("copy directory contents merged without clobbering existing files", func(t *testing.T) {
+		tempDir := t.TempDir()
+		srcDir := filepath.Join(tempDir, "src")
+		destDir := filepath.Join(tempDir, "dest")
+
+		require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "subdir"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "file1.txt"), []byte("new file1"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "subdir", "file2.txt"), []byte("file2"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "subdir", "file3.txt"), []byte("file3"), 0644))
+
+		require.NoError(t, os.MkdirAll(filepath.Join(destDir, "subdir"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, "file1.txt"), []byte("old file1"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, "subdir", "file2.txt"), []byte("file2"), 0644))
+
+		err := CopyFiles([]string{srcDir}, destDir,
+			CmRecursive|CmPreserveStructure|CmMergeContents|CmNoClobber, nil)
+
+		require.NoError(t, err)
+		assertFileExists(t, filepath.Join(destDir, "file1.txt"), "old file1")
+		assertFileExists(t, filepath.Join(destDir, "file1 (1).txt"), "new file1")
+		assertFileExists(t, filepath.Join(destDir, "subdir", "file2.txt"), "file2")
+		assert.NoFileExists(t, filepath.Join(destDir, "subdir", "file2 (1).txt"))
+		assertFileExists(t, filepath.Join(destDir, "subdir", "file3.txt"), "file3")
+	})
+
 	t.Run("copy directory with extension filter flattened", func(t *testing.T) {
 		tempDir := t.TempDir()
 		srcDir := filepath.Join(tempDir, "src")
@@ -308,6 +357,57 @@ func TestCopyFiles(t *testing.T) {
 		// Note: One file will overwrite the other in flattened mode
 	})
 
+	t.Run("file name collision with no clobber", func(t *testing.T) {
+		tempDir := t.TempDir()
+		srcDir := filepath.Join(tempDir, "src")
+		destDir := filepath.Join(tempDir, "dest")
+
+		for i, dir := range []string{"dir1", "dir2", "dir3"} {
+			require.NoError(t, os.MkdirAll(filepath.Join(srcDir, dir), 0755))
+			content := []byte("content" + string(rune('1'+i)))
+			require.NoError(t, os.WriteFile(filepath.Join(srcDir, dir, "file.txt"), content, 0644))
+		}
+
+		require.NoError(t, CopyFiles([]string{srcDir}, destDir, CmRecursive|CmNoClobber, nil))
+
+		for name, expected := range map[string]string{
+			"file.txt":     "content1",
+			"file (1).txt": "content2",
+			"file (2).txt": "content3",
+		} {
+			content, err := os.ReadFile(filepath.Join(destDir, name))
+			require.NoError(t, err)
+			assert.Equal(t, expected, string(content))
+		}
+
+		// Running it again doesn't create new copies
+		require.NoError(t, CopyFiles([]string{srcDir}, destDir, CmRecursive|CmNoClobber, nil))
+
+		entries, err := os.ReadDir(destDir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 3)
+	})
+
+	t.Run("single file with no clobber", func(t *testing.T) {
+		tempDir := t.TempDir()
+		srcFile := filepath.Join(tempDir, "source.txt")
+		destDir := filepath.Join(tempDir, "dest")
+
+		require.NoError(t, os.MkdirAll(destDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, "source.txt"), []byte("existing"), 0644))
+		require.NoError(t, os.WriteFile(srcFile, []byte("new"), 0644))
+
+		require.NoError(t, CopyFiles([]string{srcFile}, destDir, CmNoClobber, nil))
+
+		content, err := os.ReadFile(filepath.Join(destDir, "source.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "existing", string(content))
+
+		content, err = os.ReadFile(filepath.Join(destDir, "source (1).txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(content))
+	})
+
 	t.Run("empty sources slice", func(t *testing.T) {
 		tempDir := t.TempDir()
 		destDir := filepath.Join(tempDir, "dest")
@@ -487,6 +587,26 @@ func TestMoveFiles(t *testing.T) {
 		assert.NoFileExists(t, filepath.Join(srcDir, "subdir", "file2.txt"))
 	})
 
+	t.Run("move directory contents merged into destination", func(t *testing.T) {
+		tempDir := t.TempDir()
+		srcDir := filepath.Join(tempDir, "src")
+		destDir := filepath.Join(tempDir, "dest")
+
+		require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "subdir"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "file1.txt"), []byte("file1"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "subdir", "file2.txt"), []byte("file2"), 0644))
+		require.NoError(t, os.MkdirAll(destDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, "existing.txt"), []byte("existing"), 0644))
+
+		err := MoveFiles([]string{srcDir}, destDir, CmRecursive|CmPreserveStructure|CmMergeContents, nil)
+
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(destDir, "file1.txt"))
+		assert.FileExists(t, filepath.Join(destDir, "subdir", "file2.txt"))
+		assert.FileExists(t, filepath.Join(destDir, "existing.txt"))
+		assert.NoDirExists(t, srcDir)
+	})
+
 	t.Run("move directory with extension filter flattened", func(t *testing.T) {
 		tempDir := t.TempDir()
 		srcDir := filepath.Join(tempDir, "src")
@@ -686,3 +806,228 @@ func TestMoveFiles(t *testing.T) {
 		assert.FileExists(t, srcFile)
 	})
 }
+
+func TestDeleteFiles(t *testing.T) {
+	setup := func(t *testing.T) string {
+		tempDir := t.TempDir()
+		srcDir := filepath.Join(tempDir, "src")
+		require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "sub"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "keep.txt"), []byte("keep"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "cache.tmp"), []byte("tmp"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "sub", "nested.tmp"), []byte("tmp"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "sub", "nested.txt"), []byte("keep"), 0644))
+		return srcDir
+	}
+
+	t.Run("delete single file", func(t *testing.T) {
+		srcDir := setup(t)
+		file := filepath.Join(srcDir, "keep.txt")
+
+		require.NoError(t, DeleteFiles([]string{file}, 0, nil))
+		assert.NoFileExists(t, file)
+	})
+
+	t.Run("skip single file with non-matching extension filter", func(t *testing.T) {
+		srcDir := setup(t)
+		file := filepath.Join(srcDir, "keep.txt")
+
+		require.NoError(t, DeleteFiles([]string{file}, 0, []string{".tmp"}))
+		assert.FileExists(t, file)
+	})
+
+	t.Run("delete directory non-recursively", func(t *testing.T) {
+		srcDir := setup(t)
+
+		require.NoError(t, DeleteFiles([]string{srcDir}, 0, nil))
+		assert.DirExists(t, srcDir)
+		assert.NoFileExists(t, filepath.Join(srcDir, "keep.txt"))
+		assert.NoFileExists(t, filepath.Join(srcDir, "cache.tmp"))
+		assert.FileExists(t, filepath.Join(srcDir, "sub", "nested.tmp"))
+	})
+
+	t.Run("delete directory recursively", func(t *testing.T) {
+		srcDir := setup(t)
+
+		require.NoError(t, DeleteFiles([]string{srcDir}, CmRecursive, nil))
+		assert.NoDirExists(t, srcDir)
+	})
+
+	t.Run("delete directory with extension filter", func(t *testing.T) {
+		srcDir := setup(t)
+
+		require.NoError(t, DeleteFiles([]string{srcDir}, 0, []string{"TMP"}))
+		assert.NoFileExists(t, filepath.Join(srcDir, "cache.tmp"))
+		assert.FileExists(t, filepath.Join(srcDir, "keep.txt"))
+		assert.FileExists(t, filepath.Join(srcDir, "sub", "nested.tmp"))
+	})
+
+	t.Run("delete directory recursively with extension filter", func(t *testing.T) {
+		srcDir := setup(t)
+
+		require.NoError(t, DeleteFiles([]string{srcDir}, CmRecursive, []string{".tmp"}))
+		assert.NoFileExists(t, filepath.Join(srcDir, "cache.tmp"))
+		assert.NoFileExists(t, filepath.Join(srcDir, "sub", "nested.tmp"))
+		assert.FileExists(t, filepath.Join(srcDir, "keep.txt"))
+		assert.FileExists(t, filepath.Join(srcDir, "sub", "nested.txt"))
+	})
+
+	t.Run("error when source does not exist", func(t *testing.T) {
+		err := DeleteFiles([]string{filepath.Join(t.TempDir(), "missing")}, 0, nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to stat source")
+	})
+
+	t.Run("error identifies the file that could not be removed", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("Skipping permission test when running as root")
+		}
+
+		srcDir := setup(t)
+		require.NoError(t, os.Chmod(srcDir, 0555))
+		defer os.Chmod(srcDir, 0755)
+
+		err := DeleteFiles([]string{srcDir}, 0, []string{".tmp"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to remove file "+filepath.Join(srcDir, "cache.tmp"))
+	})
+
+	t.Run("empty sources slice", func(t *testing.T) {
+		assert.NoError(t, DeleteFiles(nil, CmRecursive, nil))
+	})
+}
+
+func TestCopyFilesExclude(t *testing.T) {
+	setup := func(t *testing.T) (string, string) {
+		tempDir := t.TempDir()
+		srcDir := filepath.Join(tempDir, "project")
+
+		for _, name := range []string{
+			"main.js",
+			"lib/util.js",
+			"node_modules/dep/index.js",
+			".git/config",
+			"lib/node_modules/nested.js",
+		} {
+			path := filepath.Join(srcDir, filepath.FromSlash(name))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, []byte(name), 0644))
+		}
+
+		return srcDir, filepath.Join(tempDir, "dest")
+	}
+
+	t.Run("exclude directories preserving structure", func(t *testing.T) {
+		srcDir, destDir := setup(t)
+
+		err := CopyFilesExclude([]string{srcDir}, destDir, CmRecursive|CmPreserveStructure, nil,
+			[]string{"node_modules", ".git"})
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(destDir, "project", "main.js"))
+		assert.FileExists(t, filepath.Join(destDir, "project", "lib", "util.js"))
+		assert.NoDirExists(t, filepath.Join(destDir, "project", "node_modules"))
+		assert.NoDirExists(t, filepath.Join(destDir, "project", "lib", "node_modules"))
+		assert.NoDirExists(t, filepath.Join(destDir, "project", ".git"))
+	})
+
+	t.Run("exclude directories flattened", func(t *testing.T) {
+		srcDir, destDir := setup(t)
+
+		err := CopyFilesExclude([]string{srcDir}, destDir, CmRecursive, []string{".js"}, []string{"node_modules"})
+		require.NoError(t, err)
+
+		entries, err := os.ReadDir(destDir)
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		assert.ElementsMatch(t, []string{"main.js", "util.js"}, names)
+	})
+
+	t.Run("exclude single file", func(t *testing.T) {
+		srcDir, destDir := setup(t)
+
+		err := CopyFilesExclude([]string{filepath.Join(srcDir, "main.js")}, destDir, 0, nil, []string{"*.js"})
+		require.NoError(t, err)
+		assert.NoFileExists(t, filepath.Join(destDir, "main.js"))
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		srcDir, destDir := setup(t)
+
+		err := CopyFilesExclude([]string{srcDir}, destDir, CmRecursive, nil, []string{"[invalid"})
+		assert.Error(t, err)
+	})
+}
+
+func TestCopyFilesPreserveMeta(t *testing.T) {
+	mtime := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	setup := func(t *testing.T) (string, string) {
+		tempDir := t.TempDir()
+		srcDir := filepath.Join(tempDir, "src")
+
+		for name, mode := range map[string]os.FileMode{"script.sh": 0750, "sub/data.txt": 0640} {
+			path := filepath.Join(srcDir, filepath.FromSlash(name))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, []byte(name), mode))
+			require.NoError(t, os.Chmod(path, mode))
+			require.NoError(t, os.Chtimes(path, mtime, mtime))
+		}
+
+		return srcDir, filepath.Join(tempDir, "dest")
+	}
+
+	assertSameMeta := func(t *testing.T, src, dest string) {
+		srcInfo, err := os.Stat(src)
+		require.NoError(t, err)
+		destInfo, err := os.Stat(dest)
+		require.NoError(t, err)
+
+		assert.True(t, srcInfo.ModTime().Equal(destInfo.ModTime()), "mtime of %s", dest)
+		if runtime.GOOS != "windows" {
+			assert.Equal(t, srcInfo.Mode(), destInfo.Mode(), "mode of %s", dest)
+		}
+	}
+
+	t.Run("preserve structure", func(t *testing.T) {
+		srcDir, destDir := setup(t)
+
+		require.NoError(t, CopyFiles([]string{srcDir}, destDir, CmRecursive|CmPreserveStructure|CmPreserveMeta, nil))
+
+		assertSameMeta(t, filepath.Join(srcDir, "script.sh"), filepath.Join(destDir, "src", "script.sh"))
+		assertSameMeta(t, filepath.Join(srcDir, "sub", "data.txt"), filepath.Join(destDir, "src", "sub", "data.txt"))
+	})
+
+	t.Run("flattened", func(t *testing.T) {
+		srcDir, destDir := setup(t)
+
+		require.NoError(t, CopyFiles([]string{srcDir}, destDir, CmRecursive|CmPreserveMeta, nil))
+
+		assertSameMeta(t, filepath.Join(srcDir, "script.sh"), filepath.Join(destDir, "script.sh"))
+		assertSameMeta(t, filepath.Join(srcDir, "sub", "data.txt"), filepath.Join(destDir, "data.txt"))
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		srcDir, destDir := setup(t)
+		srcFile := filepath.Join(srcDir, "script.sh")
+
+		require.NoError(t, CopyFiles([]string{srcFile}, destDir, CmPreserveMeta, nil))
+
+		assertSameMeta(t, srcFile, filepath.Join(destDir, "script.sh"))
+	})
+
+	t.Run("modification time is not preserved by default", func(t *testing.T) {
+		srcDir, destDir := setup(t)
+
+		require.NoError(t, CopyFiles([]string{srcDir}, destDir, CmRecursive, nil))
+
+		info, err := os.Stat(filepath.Join(destDir, "script.sh"))
+		require.NoError(t, err)
+		assert.False(t, info.ModTime().Equal(mtime))
+	})
+}
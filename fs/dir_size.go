@@ -0,0 +1,131 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DirSize returns the total size, in bytes, of the regular files inside a directory and all its subdirectories.
+// Symbolic links are not followed, which avoids counting the same files twice or looping forever on cyclic links.
+//
+// # Parameters:
+//   - path: The directory whose size will be computed
+//
+// Returns the total size in bytes and an error if the directory, or any entry inside it, can't be read.
+func DirSize(path string) (int64, error) {
+	_, bytes, err := dirSize(path, false)
+	return bytes, err
+}
+
+// DirSizeDetailed returns the number of regular files inside a directory and all its subdirectories, along with their
+// total size in bytes. Like DirSize, symbolic links are not followed.
+//
+// # Parameters:
+//   - path: The directory whose size will be computed
+//
+// Returns the number of files, the total size in bytes and an error if the directory, or any entry inside it, can't be
+// read.
+func DirSizeDetailed(path string) (files int, bytes int64, err error) {
+	return dirSize(path, false)
+}
+
+// DirSizeFollowSymlinks works like DirSizeDetailed, but follows symbolic links to files and directories. Every file and
+// directory is visited at most once, tracked by its inode (or resolved path on Windows), so cyclic links don't loop
+// forever and files reachable through several links (or hard links) are only counted once. Broken links are ignored.
+//
+// # Parameters:
+//   - path: The directory whose size will be computed
+//
+// Returns the number of files, the total size in bytes and an error if the directory, or any entry inside it, can't be
+// read.
+func DirSizeFollowSymlinks(path string) (files int, bytes int64, err error) {
+	return dirSize(path, true)
+}
+
+// region - Private functions
+
+func dirSize(root string, follow bool) (int, int64, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !info.IsDir() {
+		return 0, 0, fmt.Errorf("not a directory: %s", root)
+	}
+
+	var files int
+	var bytes int64
+	visited := make(map[fileKey]struct{})
+
+	// firstVisit reports whether the entry is being visited for the first time; it's only needed when following links
+	firstVisit := func(path string, info os.FileInfo) bool {
+		if !follow {
+			return true
+		}
+
+		key, ok := fileKeyOf(path, info)
+		if !ok {
+			return true
+		}
+		if _, seen := visited[key]; seen {
+			return false
+		}
+
+		visited[key] = struct{}{}
+		return true
+	}
+
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+
+			if info.Mode()&os.ModeSymlink != 0 {
+				if !follow {
+					continue
+				}
+
+				// Ignore broken links
+				if info, err = os.Stat(path); err != nil {
+					continue
+				}
+			}
+
+			if !firstVisit(path, info) {
+				continue
+			}
+
+			switch {
+			case info.IsDir():
+				if err = walk(path); err != nil {
+					return err
+				}
+			case info.Mode().IsRegular():
+				files++
+				bytes += info.Size()
+			}
+		}
+
+		return nil
+	}
+
+	firstVisit(root, info)
+	if err = walk(root); err != nil {
+		return 0, 0, err
+	}
+
+	return files, bytes, nil
+}
+
+// endregion
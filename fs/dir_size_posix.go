@@ -0,0 +1,23 @@
+//go:build !windows
+
+package fs
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileKey identifies a file by its device and inode numbers.
+type fileKey struct {
+	dev uint64
+	ino uint64
+}
+
+func fileKeyOf(_ string, info os.FileInfo) (fileKey, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileKey{}, false
+	}
+
+	return fileKey{dev: uint64(stat.Dev), ino: uint64(stat.Ino)}, true
+}
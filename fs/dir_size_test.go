@@ -0,0 +1,110 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirSize(t *testing.T) {
+	setup := func(t *testing.T) string {
+		root := t.TempDir()
+
+		files := map[string]int{
+			"a.txt":            10,
+			"sub/b.txt":        20,
+			"sub/deeper/c.txt": 30,
+			"other/empty.txt":  0,
+			"other/d.bin":      40,
+		}
+
+		for name, size := range files {
+			path := filepath.Join(root, filepath.FromSlash(name))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("x", size)), 0644))
+		}
+
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "empty"), 0755))
+		return root
+	}
+
+	t.Run("sums file sizes recursively", func(t *testing.T) {
+		root := setup(t)
+
+		size, err := DirSize(root)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), size)
+	})
+
+	t.Run("detailed counts files", func(t *testing.T) {
+		root := setup(t)
+
+		files, bytes, err := DirSizeDetailed(root)
+		require.NoError(t, err)
+		assert.Equal(t, 5, files)
+		assert.Equal(t, int64(100), bytes)
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		files, bytes, err := DirSizeDetailed(t.TempDir())
+		require.NoError(t, err)
+		assert.Zero(t, files)
+		assert.Zero(t, bytes)
+	})
+
+	t.Run("symlinks are not followed by default", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping symlink test on Windows")
+		}
+
+		root := setup(t)
+		external := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(external, "big.bin"), make([]byte, 1000), 0644))
+		require.NoError(t, os.Symlink(external, filepath.Join(root, "external")))
+		require.NoError(t, os.Symlink("a.txt", filepath.Join(root, "link.txt")))
+
+		files, bytes, err := DirSizeDetailed(root)
+		require.NoError(t, err)
+		assert.Equal(t, 5, files)
+		assert.Equal(t, int64(100), bytes)
+	})
+
+	t.Run("follow symlinks", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping symlink test on Windows")
+		}
+
+		root := setup(t)
+		external := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(external, "big.bin"), make([]byte, 1000), 0644))
+		require.NoError(t, os.Symlink(external, filepath.Join(root, "external")))
+
+		// Links to files already counted, a cycle, and a broken link are counted once or ignored
+		require.NoError(t, os.Symlink("a.txt", filepath.Join(root, "link.txt")))
+		require.NoError(t, os.Symlink("..", filepath.Join(root, "sub", "cycle")))
+		require.NoError(t, os.Symlink("missing.txt", filepath.Join(root, "broken.txt")))
+
+		files, bytes, err := DirSizeFollowSymlinks(root)
+		require.NoError(t, err)
+		assert.Equal(t, 6, files)
+		assert.Equal(t, int64(1100), bytes)
+	})
+
+	t.Run("not a directory", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file.txt")
+		require.NoError(t, os.WriteFile(file, []byte("content"), 0644))
+
+		_, err := DirSize(file)
+		assert.Error(t, err)
+	})
+
+	t.Run("non-existent directory returns error", func(t *testing.T) {
+		_, err := DirSize(filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+	})
+}
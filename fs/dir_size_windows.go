@@ -0,0 +1,22 @@
+//go:build windows
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// fileKey identifies a file by its path, with every symbolic link resolved.
+type fileKey struct {
+	path string
+}
+
+func fileKeyOf(path string, _ os.FileInfo) (fileKey, bool) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return fileKey{}, false
+	}
+
+	return fileKey{path: resolved}, true
+}
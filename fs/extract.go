@@ -0,0 +1,149 @@
+package fs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type archiveFormat uint8
+
+const (
+	formatUnknown archiveFormat = iota
+	formatZip
+	format7z
+	formatTar
+	formatTarXz
+	formatTarGz
+)
+
+var (
+	magicZip  = []byte("PK")
+	magic7z   = []byte{'7', 'z', 0xBC, 0xAF, 0x27, 0x1C}
+	magicXz   = []byte{0xFD, '7', 'z', 'X', 'Z', 0x00}
+	magicGzip = []byte{0x1F, 0x8B}
+	magicTar  = []byte("ustar")
+)
+
+// Extract extracts all files and directories from an archive to a target directory, choosing the right extractor
+// automatically. The format is detected from the file's magic bytes, falling back to its extension when they are
+// inconclusive.
+//
+// Supported formats:
+//   - ZIP (.zip), extracted with Unzip
+//   - 7z (.7z), extracted with Un7zip
+//   - TAR.XZ (.tar.xz, .txz), extracted with UntarXz
+//   - TAR.GZ (.tar.gz, .tgz) and plain TAR (.tar), extracted with the same security checks as UntarXz
+//
+// # Parameters:
+//   - archivePath: Path to the archive to extract
+//   - targetDirectory: Destination directory where files will be extracted
+//
+// # Returns an error if:
+//   - The archive cannot be opened or read
+//   - The archive format is not supported
+//   - The extraction fails; see the extractor of each format for details
+func Extract(archivePath, targetDirectory string) error {
+	format, err := detectArchiveFormat(archivePath)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case formatZip:
+		return Unzip(archivePath, targetDirectory)
+	case format7z:
+		return Un7zip(archivePath, targetDirectory)
+	case formatTarXz:
+		return UntarXz(archivePath, targetDirectory)
+	case formatTarGz:
+		return untarGz(archivePath, targetDirectory)
+	case formatTar:
+		return untarFile(archivePath, targetDirectory)
+	default:
+		return fmt.Errorf("unsupported archive format: %s", archivePath)
+	}
+}
+
+// region - Private functions
+
+func detectArchiveFormat(archivePath string) (archiveFormat, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return formatUnknown, err
+	}
+	defer f.Close()
+
+	// The tar magic is at offset 257, so read enough to cover it
+	header := make([]byte, 262)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return formatUnknown, err
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, magicZip):
+		return formatZip, nil
+	case bytes.HasPrefix(header, magic7z):
+		return format7z, nil
+	case bytes.HasPrefix(header, magicXz):
+		return formatTarXz, nil
+	case bytes.HasPrefix(header, magicGzip):
+		return formatTarGz, nil
+	case len(header) >= 262 && bytes.Equal(header[257:262], magicTar):
+		return formatTar, nil
+	}
+
+	return formatFromExtension(archivePath), nil
+}
+
+func formatFromExtension(archivePath string) archiveFormat {
+	name := strings.ToLower(archivePath)
+
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return formatZip
+	case strings.HasSuffix(name, ".7z"):
+		return format7z
+	case strings.HasSuffix(name, ".tar.xz"), strings.HasSuffix(name, ".txz"):
+		return formatTarXz
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return formatTarGz
+	case strings.HasSuffix(name, ".tar"):
+		return formatTar
+	default:
+		return formatUnknown
+	}
+}
+
+func untarGz(tarGzPath, targetDirectory string) error {
+	f, err := os.Open(tarGzPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gzReader, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gzReader.Close()
+
+	return untar(gzReader, targetDirectory, &extraction{preserveTimes: true})
+}
+
+func untarFile(tarPath, targetDirectory string) error {
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return untar(f, targetDirectory, &extraction{preserveTimes: true})
+}
+
+// endregion
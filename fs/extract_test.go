@@ -0,0 +1,142 @@
+package fs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestTar(t *testing.T, w io.Writer, files map[string]string) {
+	tarWriter := tar.NewWriter(w)
+
+	for name, content := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+			Format:   tar.FormatUSTAR,
+		}))
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tarWriter.Close())
+}
+
+func TestExtract(t *testing.T) {
+	files := map[string]string{"file1.txt": "content1", "dir1/file2.txt": "content2"}
+
+	t.Run("zip", func(t *testing.T) {
+		sourceDir := createTestTree(t)
+		archivePath := filepath.Join(t.TempDir(), "archive.zip")
+		require.NoError(t, Zip(sourceDir, archivePath, 0))
+
+		targetDir := t.TempDir()
+		require.NoError(t, Extract(archivePath, targetDir))
+		assert.Equal(t, readTree(t, sourceDir), readTree(t, targetDir))
+	})
+
+	t.Run("tar.xz without extension", func(t *testing.T) {
+		sourceDir := createTestTree(t)
+		archivePath := filepath.Join(t.TempDir(), "archive")
+		require.NoError(t, TarXz(sourceDir, archivePath))
+
+		targetDir := t.TempDir()
+		require.NoError(t, Extract(archivePath, targetDir))
+		assert.Equal(t, readTree(t, sourceDir), readTree(t, targetDir))
+	})
+
+	t.Run("tar.gz", func(t *testing.T) {
+		archivePath := filepath.Join(t.TempDir(), "archive.tar.gz")
+		f, err := os.Create(archivePath)
+		require.NoError(t, err)
+
+		gzWriter := gzip.NewWriter(f)
+		createTestTar(t, gzWriter, files)
+		require.NoError(t, gzWriter.Close())
+		require.NoError(t, f.Close())
+
+		targetDir := t.TempDir()
+		require.NoError(t, Extract(archivePath, targetDir))
+		assertFileExists(t, filepath.Join(targetDir, "file1.txt"), "content1")
+		assertFileExists(t, filepath.Join(targetDir, "dir1", "file2.txt"), "content2")
+	})
+
+	t.Run("tar", func(t *testing.T) {
+		archivePath := filepath.Join(t.TempDir(), "archive.tar")
+		f, err := os.Create(archivePath)
+		require.NoError(t, err)
+
+		createTestTar(t, f, files)
+		require.NoError(t, f.Close())
+
+		targetDir := t.TempDir()
+		require.NoError(t, Extract(archivePath, targetDir))
+		assertFileExists(t, filepath.Join(targetDir, "file1.txt"), "content1")
+	})
+
+	t.Run("tar.gz rejects path traversal", func(t *testing.T) {
+		archivePath := filepath.Join(t.TempDir(), "malicious.tgz")
+		f, err := os.Create(archivePath)
+		require.NoError(t, err)
+
+		gzWriter := gzip.NewWriter(f)
+		createTestTar(t, gzWriter, map[string]string{"../escape.txt": "malicious"})
+		require.NoError(t, gzWriter.Close())
+		require.NoError(t, f.Close())
+
+		err = Extract(archivePath, t.TempDir())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "illegal file path")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		archivePath := filepath.Join(t.TempDir(), "archive.rar")
+		require.NoError(t, os.WriteFile(archivePath, []byte("not an archive"), 0644))
+
+		err := Extract(archivePath, t.TempDir())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported archive format")
+	})
+
+	t.Run("archive does not exist", func(t *testing.T) {
+		err := Extract(filepath.Join(t.TempDir(), "missing.zip"), t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func TestDetectArchiveFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  []byte
+		expected archiveFormat
+	}{
+		{"archive.bin", []byte("PK\x03\x04rest"), formatZip},
+		{"archive.bin", []byte{'7', 'z', 0xBC, 0xAF, 0x27, 0x1C, 0x00}, format7z},
+		{"archive.bin", []byte{0xFD, '7', 'z', 'X', 'Z', 0x00, 0x00}, formatTarXz},
+		{"archive.bin", []byte{0x1F, 0x8B, 0x08}, formatTarGz},
+		{"archive.ZIP", []byte("inconclusive"), formatZip},
+		{"archive.7z", []byte("inconclusive"), format7z},
+		{"archive.txz", []byte("inconclusive"), formatTarXz},
+		{"archive.tgz", []byte("inconclusive"), formatTarGz},
+		{"archive.tar", []byte("inconclusive"), formatTar},
+		{"archive.bin", []byte("inconclusive"), formatUnknown},
+		{"empty.bin", []byte{}, formatUnknown},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), tt.name)
+		require.NoError(t, os.WriteFile(path, tt.content, 0644))
+
+		format, err := detectArchiveFormat(path)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, format, tt.name)
+	}
+}
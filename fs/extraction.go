@@ -0,0 +1,181 @@
+package fs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+// ExtractPolicy controls what happens when an archive entry would be extracted over a file that already exists.
+// Existing directories are never a conflict; the archive's content is merged into them.
+type ExtractPolicy uint8
+
+const (
+	// OverwriteAlways replaces existing files with the ones in the archive.
+	OverwriteAlways ExtractPolicy = iota
+	// SkipExisting leaves existing files untouched and doesn't extract the entries that would replace them.
+	SkipExisting
+	// FailOnConflict aborts the extraction with an error naming the first existing file.
+	FailOnConflict
+)
+
+// ExtractOptions configures an archive extraction for UnzipWithOptions and UntarXzWithOptions, so the limits, the
+// preservation of times and the conflict policy can be combined. The zero value extracts everything, overwriting
+// existing files, without restoring the modification times.
+type ExtractOptions struct {
+	// MaxBytes is the maximum total uncompressed size of the extracted files; 0 means unlimited.
+	MaxBytes int64
+	// MaxEntries is the maximum number of entries in the archive; 0 means unlimited.
+	MaxEntries int
+	// PreserveTimes sets the modification time of the extracted files and directories to the one in the archive.
+	PreserveTimes bool
+	// Policy decides what happens to the entries that would replace existing files.
+	Policy ExtractPolicy
+}
+
+// extraction holds the limits of an archive extraction and keeps track of what has been extracted so far, so they can
+// be enforced while the archive is streamed.
+type extraction struct {
+	maxBytes   int64 // 0 means unlimited
+	maxEntries int   // 0 means unlimited
+
+	// preserveTimes sets the modification time of the extracted files and directories to the one in the archive
+	preserveTimes bool
+	policy        ExtractPolicy
+
+	bytes    int64
+	entries  int
+	written  []string
+	dirTimes []dirTime
+	// rollback is set when a limit was exceeded or a conflict was found, so abort removes the files created so far
+	rollback bool
+}
+
+type dirTime struct {
+	path    string
+	modTime time.Time
+}
+
+// region - Private functions
+
+// newExtraction returns the state of an extraction configured with the options.
+func newExtraction(opts ExtractOptions) *extraction {
+	return &extraction{
+		maxBytes:      opts.MaxBytes,
+		maxEntries:    opts.MaxEntries,
+		preserveTimes: opts.PreserveTimes,
+		policy:        opts.Policy,
+	}
+}
+
+// addEntry counts a new archive entry, failing if it goes over the entry limit.
+func (e *extraction) addEntry() error {
+	e.entries++
+	if e.maxEntries > 0 && e.entries > e.maxEntries {
+		e.rollback = true
+		return fmt.Errorf("archive exceeds the limit of %d entries", e.maxEntries)
+	}
+
+	return nil
+}
+
+// copy copies the content of an entry, failing as soon as the total uncompressed size goes over the byte limit.
+func (e *extraction) copy(dst io.Writer, src io.Reader) error {
+	if e.maxBytes <= 0 {
+		n, err := io.Copy(dst, src)
+		e.bytes += n
+		return err
+	}
+
+	// Read one byte past the remaining budget to detect that the limit was exceeded
+	n, err := io.Copy(dst, io.LimitReader(src, e.maxBytes-e.bytes+1))
+	e.bytes += n
+	if err != nil {
+		return err
+	}
+
+	if e.bytes > e.maxBytes {
+		e.rollback = true
+		return fmt.Errorf("archive exceeds the limit of %d uncompressed bytes", e.maxBytes)
+	}
+
+	return nil
+}
+
+// skip checks the extraction policy against a file about to be extracted, reporting whether it must be skipped because
+// it already exists, or failing when conflicts aren't allowed.
+func (e *extraction) skip(path string) (bool, error) {
+	if e.policy == OverwriteAlways {
+		return false, nil
+	}
+
+	if _, err := os.Lstat(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if e.policy == SkipExisting {
+		return true, nil
+	}
+
+	e.rollback = true
+	return false, fmt.Errorf("file already exists: %s", path)
+}
+
+// track records a file or symlink written by the extraction, if it didn't exist before. Files that were overwritten
+// belong to the user, so they aren't tracked and aborting never removes them.
+func (e *extraction) track(path string, existed bool) {
+	if !existed {
+		e.written = append(e.written, path)
+	}
+}
+
+// exists reports whether there's already a file, directory or symlink at path.
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// setTimes applies the modification time of an archive entry to the extracted file, when times are preserved. The times
+// of directories are only applied by finish, since extracting their children would change them again.
+func (e *extraction) setTimes(path string, modTime time.Time, isDir bool) error {
+	if !e.preserveTimes || modTime.IsZero() {
+		return nil
+	}
+
+	if isDir {
+		e.dirTimes = append(e.dirTimes, dirTime{path: path, modTime: modTime})
+		return nil
+	}
+
+	return os.Chtimes(path, modTime, modTime)
+}
+
+// finish completes a successful extraction, applying the modification times of the directories, deepest first.
+func (e *extraction) finish() error {
+	for _, dir := range slices.Backward(e.dirTimes) {
+		if err := os.Chtimes(dir.path, dir.modTime, dir.modTime); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// abort removes the files created so far when the extraction failed because a limit was exceeded or a conflict was
+// found, and returns the original error.
+func (e *extraction) abort(err error) error {
+	if e.rollback {
+		for _, path := range slices.Backward(e.written) {
+			os.Remove(path)
+		}
+	}
+
+	return err
+}
+
+// endregion
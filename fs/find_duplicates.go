@@ -0,0 +1,111 @@
+package fs
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/zeebo/blake3"
+)
+
+// FindDuplicates traverses a directory and groups the files that have identical content. To stay fast, files are first
+// grouped by size, and only files whose sizes collide are hashed with BLAKE3.
+//
+// Only regular files are considered; symbolic links are not followed, and empty files are ignored.
+//
+// # Parameters:
+//   - directory: The root directory to search for duplicates
+//   - recursive: Whether subdirectories should be searched too
+//
+// Returns a map from the BLAKE3 hash (hex-encoded) of each duplicated content to the paths of the files that have it,
+// in traversal order, and an error if the directory can't be traversed or a file can't be hashed.
+//
+// # Example:
+//
+//	duplicates, err := FindDuplicates("/photos", true)
+//	for hash, paths := range duplicates {
+//	    fmt.Println(hash, paths)
+//	}
+func FindDuplicates(directory string, recursive bool) (map[string][]string, error) {
+	bySize := make(map[int64][]string)
+
+	err := filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if !recursive && path != directory {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if info.Size() > 0 {
+			bySize[info.Size()] = append(bySize[info.Size()], path)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := make(map[string][]string)
+
+	for _, paths := range bySize {
+		if len(paths) < 2 {
+			continue
+		}
+
+		byHash := make(map[string][]string)
+		for _, path := range paths {
+			hash, hashErr := blake3File(path)
+			if hashErr != nil {
+				return nil, fmt.Errorf("failed to hash file %s: %w", path, hashErr)
+			}
+
+			byHash[hash] = append(byHash[hash], path)
+		}
+
+		for hash, group := range byHash {
+			if len(group) > 1 {
+				duplicates[hash] = group
+			}
+		}
+	}
+
+	return duplicates, nil
+}
+
+// region - Private functions
+
+func blake3File(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hasher := blake3.New()
+	if _, err = io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// endregion
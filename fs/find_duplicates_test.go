@@ -0,0 +1,104 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/blake3"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	hashOf := func(content string) string {
+		hasher := blake3.New()
+		hasher.Write([]byte(content))
+		return fmt.Sprintf("%x", hasher.Sum(nil))
+	}
+
+	setup := func(t *testing.T) string {
+		root := t.TempDir()
+
+		files := map[string]string{
+			"a.txt":         "duplicated",
+			"b.txt":         "duplicated",
+			"c.txt":         "different!",
+			"unique.txt":    "only one of its size",
+			"empty1.txt":    "",
+			"empty2.txt":    "",
+			"sub/d.txt":     "duplicated",
+			"sub/other.bin": "another pair",
+			"sub/copy.bin":  "another pair",
+		}
+
+		for name, content := range files {
+			path := filepath.Join(root, filepath.FromSlash(name))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		}
+
+		return root
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		root := setup(t)
+
+		duplicates, err := FindDuplicates(root, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{
+			hashOf("duplicated"): {
+				filepath.Join(root, "a.txt"),
+				filepath.Join(root, "b.txt"),
+				filepath.Join(root, "sub", "d.txt"),
+			},
+			hashOf("another pair"): {
+				filepath.Join(root, "sub", "copy.bin"),
+				filepath.Join(root, "sub", "other.bin"),
+			},
+		}, duplicates)
+	})
+
+	t.Run("non-recursive", func(t *testing.T) {
+		root := setup(t)
+
+		duplicates, err := FindDuplicates(root, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{
+			hashOf("duplicated"): {filepath.Join(root, "a.txt"), filepath.Join(root, "b.txt")},
+		}, duplicates)
+	})
+
+	t.Run("symlinks are not followed", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping symlink test on Windows")
+		}
+
+		root := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(root, "file.txt"), []byte("content"), 0644))
+		require.NoError(t, os.Symlink("file.txt", filepath.Join(root, "link.txt")))
+
+		duplicates, err := FindDuplicates(root, true)
+		require.NoError(t, err)
+		assert.Empty(t, duplicates)
+	})
+
+	t.Run("no duplicates", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte("aaa"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(root, "b.txt"), []byte("bbb"), 0644))
+
+		duplicates, err := FindDuplicates(root, true)
+		require.NoError(t, err)
+		assert.Empty(t, duplicates)
+	})
+
+	t.Run("non-existent directory returns error", func(t *testing.T) {
+		_, err := FindDuplicates(filepath.Join(t.TempDir(), "missing"), true)
+		assert.Error(t, err)
+	})
+}
@@ -1,7 +1,10 @@
 package fs
 
 import (
+	"fmt"
 	"io/fs"
+	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -16,6 +19,12 @@ const (
 	LpRecursive
 )
 
+// PathInfo is an entry returned by ListPathInfo: its path and the file info gathered while traversing the directory.
+type PathInfo struct {
+	Path string
+	Info os.FileInfo
+}
+
 // ListPath traverses a directory and returns a list of paths based on the specified flags and file extensions. The
 // directory parameter specifies the root directory to start the traversal from.
 //
@@ -41,12 +50,124 @@ const (
 //	// List both files and directories recursively, filtering for .txt and .md files
 //	paths, err := ListPath("/docs", LpFile|LpDir|LpRecursive, []string{".txt", ".md"})
 func ListPath(directory string, flags ListFlags, fileExt []string) ([]string, error) {
-	entries := make([]string, 0)
+	return listPaths(directory, flags, depthFromFlags(flags), nil, matchExt(fileExt))
+}
 
-	includeDir := flags&LpDir != 0
-	includeFile := flags&LpFile != 0
-	recursive := flags&LpRecursive != 0
+// ListPathInfo traverses a directory like ListPath, but returns the file info of each entry along with its path. The
+// info is gathered during the traversal, so there's no need to call os.Stat on each result to get its size, mode or
+// modification time. Like os.Lstat, the info of a symbolic link describes the link itself.
+//
+// # Example:
+//
+//	// List all .log files recursively and sort them by size
+//	infos, err := ListPathInfo("/var/log", LpFile|LpRecursive, []string{".log"})
+//	sort.Slice(infos, func(i, j int) bool { return infos[i].Info.Size() < infos[j].Info.Size() })
+func ListPathInfo(directory string, flags ListFlags, fileExt []string) ([]PathInfo, error) {
+	return listPath(directory, flags, depthFromFlags(flags), nil, matchExt(fileExt))
+}
 
+// ListPathDepth traverses a directory like ListPath, but limits how deep the traversal goes. A maxDepth of 0 lists only
+// the entries directly inside the directory (equivalent to a non-recursive ListPath), 1 also lists the entries of its
+// subdirectories, and so on; -1 means unlimited (equivalent to a recursive ListPath).
+//
+// The maxDepth parameter replaces the LpRecursive flag, which is ignored; the other flags and the fileExt parameter work
+// the same as in ListPath.
+//
+// # Example:
+//
+//	// List the Go files of the top-level modules, without walking deeper
+//	paths, err := ListPathDepth("/repo", LpFile, []string{".go"}, 1)
+func ListPathDepth(directory string, flags ListFlags, fileExt []string, maxDepth int) ([]string, error) {
+	return listPaths(directory, flags, maxDepth, nil, matchExt(fileExt))
+}
+
+// ListPathGlob traverses a directory like ListPath, but filters the results with shell glob patterns instead of file
+// extensions. Patterns use path.Match semantics and are matched against the base name of each entry, e.g. "*_test.go"
+// or "file?.txt". Patterns that contain "**" are matched against the path relative to the directory instead, using
+// forward slashes, where "**" matches any number of directories, e.g. "**/test_*.txt" or "src/**/*.go".
+//
+// An entry is included when it matches any of the patterns. If patterns is empty, every entry is included. The flags
+// parameter works the same as in ListPath.
+//
+// Returns a slice of file/directory paths and any error encountered during traversal, or an error if any pattern is
+// malformed.
+//
+// # Example:
+//
+//	// List all test files recursively
+//	paths, err := ListPathGlob("/src", LpFile|LpRecursive, []string{"*_test.go"})
+//
+//	// List text files starting with "test_" in any subdirectory of "data"
+//	paths, err := ListPathGlob("/project", LpFile|LpRecursive, []string{"data/**/test_*.txt"})
+func ListPathGlob(directory string, flags ListFlags, patterns []string) ([]string, error) {
+	if err := validateGlobs(patterns); err != nil {
+		return nil, err
+	}
+
+	return listPaths(directory, flags, depthFromFlags(flags), nil, func(_, rel string, _ fs.DirEntry) bool {
+		return len(patterns) == 0 || matchAnyGlob(patterns, rel)
+	})
+}
+
+// ListPathExclude traverses a directory like ListPath, skipping the entries that match any of the exclude patterns. The
+// patterns use the same glob syntax as ListPathGlob, e.g. "node_modules", ".git" or "**/testdata/*.json". An excluded
+// directory is pruned with its whole subtree, so it's never traversed.
+//
+// Returns a slice of file/directory paths and any error encountered during traversal, or an error if any pattern is
+// malformed.
+//
+// # Example:
+//
+//	// List all .js files recursively, without walking node_modules and .git
+//	paths, err := ListPathExclude("/project", LpFile|LpRecursive, []string{".js"}, []string{"node_modules", ".git"})
+func ListPathExclude(directory string, flags ListFlags, fileExt []string, exclude []string) ([]string, error) {
+	if err := validateGlobs(exclude); err != nil {
+		return nil, err
+	}
+
+	return listPaths(directory, flags, depthFromFlags(flags), exclude, matchExt(fileExt))
+}
+
+// WalkPath traverses a directory like ListPath, but calls fn for each matching entry as soon as it's found instead of
+// collecting all the paths in a slice. This keeps memory usage flat when walking huge directory trees, and lets the
+// caller process the results incrementally.
+//
+// The flags and exts parameters work the same as the flags and fileExt parameters of ListPath, and the entries are
+// visited in the same order. Like in ListPath, entries that can't be read are skipped.
+//
+// Returning an error from fn stops the walk, and WalkPath returns that error. Returning filepath.SkipAll stops the walk
+// without an error, and returning filepath.SkipDir for a directory skips its contents.
+//
+// # Example:
+//
+//	// Print all .go files recursively, stopping after the first 100
+//	count := 0
+//	err := WalkPath("/src", LpFile|LpRecursive, []string{".go"}, func(path string) error {
+//	    fmt.Println(path)
+//	    if count++; count == 100 {
+//	        return filepath.SkipAll
+//	    }
+//	    return nil
+//	})
+func WalkPath(root string, flags ListFlags, exts []string, fn func(path string) error) error {
+	return walkPath(root, flags, depthFromFlags(flags), nil, matchExt(exts), func(path string, _ fs.DirEntry) error {
+		return fn(path)
+	})
+}
+
+// region - Private functions
+
+// depthFromFlags returns the max depth of a traversal based on the LpRecursive flag.
+func depthFromFlags(flags ListFlags) int {
+	if flags&LpRecursive != 0 {
+		return -1
+	}
+
+	return 0
+}
+
+// matchExt returns a match function for listPath that accepts directories and files with one of the extensions.
+func matchExt(fileExt []string) func(path, rel string, d fs.DirEntry) bool {
 	// Prepare extension set for O(1) lookup
 	fileExt = lo.Map(fileExt, func(ext string, _ int) string {
 		return strings.ToLower(ext)
@@ -57,7 +178,72 @@ func ListPath(directory string, flags ListFlags, fileExt []string) ([]string, er
 		extSet[ext] = struct{}{}
 	}
 
-	err := filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
+	return func(path, _ string, d fs.DirEntry) bool {
+		if d.IsDir() || len(extSet) == 0 {
+			return true
+		}
+
+		_, ok := extSet[strings.ToLower(filepath.Ext(path))]
+		return ok
+	}
+}
+
+// listPaths walks the directory like walkPath and returns the paths of the visited entries. Unlike listPath, it doesn't
+// read the file info of the entries.
+func listPaths(
+	directory string,
+	flags ListFlags,
+	maxDepth int,
+	exclude []string,
+	match func(path, rel string, d fs.DirEntry) bool,
+) ([]string, error) {
+	paths := make([]string, 0)
+
+	err := walkPath(directory, flags, maxDepth, exclude, match, func(path string, _ fs.DirEntry) error {
+		paths = append(paths, path)
+		return nil
+	})
+
+	return paths, err
+}
+
+// listPath walks the directory like walkPath and returns the visited entries with their file info.
+func listPath(
+	directory string,
+	flags ListFlags,
+	maxDepth int,
+	exclude []string,
+	match func(path, rel string, d fs.DirEntry) bool,
+) ([]PathInfo, error) {
+	entries := make([]PathInfo, 0)
+
+	err := walkPath(directory, flags, maxDepth, exclude, match, func(path string, d fs.DirEntry) error {
+		// Entries whose info can't be read are skipped, like any other entry that fails
+		if info, err := d.Info(); err == nil {
+			entries = append(entries, PathInfo{Path: path, Info: info})
+		}
+		return nil
+	})
+
+	return entries, err
+}
+
+// walkPath walks the directory down to maxDepth (-1 for unlimited), pruning the entries that match the exclude
+// patterns, and calls fn for the entries allowed by the flags for which match returns true. The match function receives
+// the entry's path, its path relative to the directory (with forward slashes) and the entry itself. The LpRecursive flag
+// is ignored in favor of maxDepth. The walk stops at the first error returned by fn.
+func walkPath(
+	directory string,
+	flags ListFlags,
+	maxDepth int,
+	exclude []string,
+	match func(path, rel string, d fs.DirEntry) bool,
+	fn func(path string, d fs.DirEntry) error,
+) error {
+	includeDir := flags&LpDir != 0
+	includeFile := flags&LpFile != 0
+
+	return filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			// If this is the root directory, and it doesn't exist, return the error
 			if path == directory {
@@ -71,42 +257,96 @@ func ListPath(directory string, flags ListFlags, fileExt []string) ([]string, er
 			return nil
 		}
 
-		// Non-recursive: include the first-level directory (if requested) but don't descend
-		if !recursive && d.IsDir() {
-			if includeDir {
-				entries = append(entries, path)
+		rel, relErr := filepath.Rel(directory, path)
+		if relErr != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if isExcluded(exclude, rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
 			}
-			return filepath.SkipDir
+			return nil
 		}
 
-		// Directory handling
+		// Directory handling; include the directory (if requested) but don't descend past maxDepth
 		if d.IsDir() {
-			if includeDir {
-				entries = append(entries, path)
+			if includeDir && match(path, rel, d) {
+				if fnErr := fn(path, d); fnErr != nil {
+					return fnErr
+				}
+			}
+			if maxDepth >= 0 && strings.Count(rel, "/") >= maxDepth {
+				return filepath.SkipDir
 			}
 			return nil
 		}
 
 		// File handling
-		if !includeFile {
-			return nil
+		if includeFile && match(path, rel, d) {
+			return fn(path, d)
 		}
 
-		if len(extSet) == 0 {
-			entries = append(entries, path)
-			return nil
+		return nil
+	})
+}
+
+// validateGlobs returns an error if any of the glob patterns is malformed.
+func validateGlobs(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %s: %w", pattern, err)
 		}
+	}
 
-		if _, ok := extSet[strings.ToLower(filepath.Ext(path))]; ok {
-			entries = append(entries, path)
+	return nil
+}
+
+// isExcluded reports whether the relative path matches any of the exclude patterns.
+func isExcluded(exclude []string, rel string) bool {
+	return len(exclude) > 0 && matchAnyGlob(exclude, rel)
+}
+
+// matchAnyGlob reports whether the relative path matches any of the glob patterns.
+func matchAnyGlob(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, "**") {
+			if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+				return true
+			}
+			continue
 		}
 
-		return nil
-	})
+		if matchGlobSegments(strings.Split(pattern, "/"), strings.Split(rel, "/")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchGlobSegments matches path segments against pattern segments, where a "**" segment matches zero or more segments.
+func matchGlobSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchGlobSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
 
-	if err != nil {
-		return entries, err
+	if len(segments) == 0 {
+		return false
 	}
 
-	return entries, nil
+	ok, _ := path.Match(pattern[0], segments[0])
+	return ok && matchGlobSegments(pattern[1:], segments[1:])
 }
+
+// endregion
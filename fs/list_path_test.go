@@ -1,6 +1,7 @@
 package fs
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"sort"
@@ -296,3 +297,293 @@ func TestListPathEdgeCases(t *testing.T) {
 		assert.Len(t, paths, 2, "Should find all files when extension filter is empty")
 	})
 }
+
+// createListPathTree creates the following structure and returns its root:
+//
+//	root/
+//	  ├── main.go
+//	  ├── main_test.go
+//	  ├── test_root.txt
+//	  ├── src/
+//	  │   ├── lib.go
+//	  │   ├── lib_test.go
+//	  │   └── data/
+//	  │       ├── test_data.txt
+//	  │       └── other.txt
+//	  └── test_dir/
+//	      └── notes.md
+func createListPathTree(t *testing.T) string {
+	root := t.TempDir()
+
+	for _, name := range []string{
+		"main.go",
+		"main_test.go",
+		"test_root.txt",
+		"src/lib.go",
+		"src/lib_test.go",
+		"src/data/test_data.txt",
+		"src/data/other.txt",
+		"test_dir/notes.md",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(name), 0644))
+	}
+
+	return root
+}
+
+// relPaths converts the paths to sorted, slash-separated paths relative to root.
+func relPaths(t *testing.T, root string, paths []string) []string {
+	rels := make([]string, 0, len(paths))
+	for _, path := range paths {
+		rel, err := filepath.Rel(root, path)
+		require.NoError(t, err)
+		rels = append(rels, filepath.ToSlash(rel))
+	}
+
+	sort.Strings(rels)
+	return rels
+}
+
+func TestListPathGlob(t *testing.T) {
+	root := createListPathTree(t)
+
+	t.Run("match base name, non-recursive", func(t *testing.T) {
+		paths, err := ListPathGlob(root, LpFile, []string{"*_test.go"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main_test.go"}, relPaths(t, root, paths))
+	})
+
+	t.Run("match base name recursively", func(t *testing.T) {
+		paths, err := ListPathGlob(root, LpFile|LpRecursive, []string{"*_test.go"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main_test.go", "src/lib_test.go"}, relPaths(t, root, paths))
+	})
+
+	t.Run("multiple patterns", func(t *testing.T) {
+		paths, err := ListPathGlob(root, LpFile|LpRecursive, []string{"lib.go", "*.md"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"src/lib.go", "test_dir/notes.md"}, relPaths(t, root, paths))
+	})
+
+	t.Run("double star matches any depth", func(t *testing.T) {
+		paths, err := ListPathGlob(root, LpFile|LpRecursive, []string{"**/test_*.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"src/data/test_data.txt", "test_root.txt"}, relPaths(t, root, paths))
+	})
+
+	t.Run("double star in the middle", func(t *testing.T) {
+		paths, err := ListPathGlob(root, LpFile|LpRecursive, []string{"src/**/*.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"src/data/other.txt", "src/data/test_data.txt"}, relPaths(t, root, paths))
+	})
+
+	t.Run("match directories", func(t *testing.T) {
+		paths, err := ListPathGlob(root, LpDir|LpRecursive, []string{"test_*", "da?a"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"src/data", "test_dir"}, relPaths(t, root, paths))
+	})
+
+	t.Run("empty patterns include everything", func(t *testing.T) {
+		paths, err := ListPathGlob(root, LpFile|LpRecursive, nil)
+		require.NoError(t, err)
+		assert.Len(t, paths, 8)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := ListPathGlob(root, LpFile, []string{"[invalid"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid pattern")
+	})
+}
+
+func TestListPathDepth(t *testing.T) {
+	root := createListPathTree(t)
+
+	t.Run("depth 0 lists the root level only", func(t *testing.T) {
+		paths, err := ListPathDepth(root, LpFile|LpDir, nil, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main.go", "main_test.go", "src", "test_dir", "test_root.txt"}, relPaths(t, root, paths))
+
+		nonRecursive, err := ListPath(root, LpFile|LpDir, nil)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, nonRecursive, paths)
+	})
+
+	t.Run("depth 1 lists one level down", func(t *testing.T) {
+		paths, err := ListPathDepth(root, LpFile, []string{".go", ".md"}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"main.go", "main_test.go", "src/lib.go", "src/lib_test.go", "test_dir/notes.md",
+		}, relPaths(t, root, paths))
+	})
+
+	t.Run("depth 1 includes the directories at that level", func(t *testing.T) {
+		paths, err := ListPathDepth(root, LpDir, nil, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"src", "src/data", "test_dir"}, relPaths(t, root, paths))
+	})
+
+	t.Run("-1 means unlimited", func(t *testing.T) {
+		paths, err := ListPathDepth(root, LpFile, nil, -1)
+		require.NoError(t, err)
+
+		recursive, err := ListPath(root, LpFile|LpRecursive, nil)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, recursive, paths)
+		assert.Len(t, paths, 8)
+	})
+
+	t.Run("LpRecursive is ignored", func(t *testing.T) {
+		paths, err := ListPathDepth(root, LpFile|LpRecursive, nil, 0)
+		require.NoError(t, err)
+		assert.Len(t, paths, 3)
+	})
+}
+
+func TestListPathInfo(t *testing.T) {
+	root := createListPathTree(t)
+
+	t.Run("returns the same entries as ListPath", func(t *testing.T) {
+		infos, err := ListPathInfo(root, LpFile|LpDir|LpRecursive, []string{".go"})
+		require.NoError(t, err)
+
+		paths, err := ListPath(root, LpFile|LpDir|LpRecursive, []string{".go"})
+		require.NoError(t, err)
+
+		infoPaths := make([]string, 0, len(infos))
+		for _, info := range infos {
+			infoPaths = append(infoPaths, info.Path)
+		}
+		assert.Equal(t, paths, infoPaths)
+	})
+
+	t.Run("info matches os.Stat", func(t *testing.T) {
+		infos, err := ListPathInfo(root, LpFile|LpDir|LpRecursive, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, infos)
+
+		for _, info := range infos {
+			stat, err := os.Stat(info.Path)
+			require.NoError(t, err)
+
+			assert.Equal(t, stat.Name(), info.Info.Name())
+			assert.Equal(t, stat.IsDir(), info.Info.IsDir())
+			assert.Equal(t, stat.Size(), info.Info.Size())
+			assert.Equal(t, stat.Mode(), info.Info.Mode())
+			assert.Equal(t, stat.ModTime(), info.Info.ModTime())
+		}
+	})
+
+	t.Run("non-existent directory returns error", func(t *testing.T) {
+		_, err := ListPathInfo(filepath.Join(root, "missing"), LpFile, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestListPathExclude(t *testing.T) {
+	root := createListPathTree(t)
+
+	t.Run("excluded directory is pruned", func(t *testing.T) {
+		paths, err := ListPathExclude(root, LpFile|LpDir|LpRecursive, nil, []string{"src"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"main.go", "main_test.go", "test_dir", "test_dir/notes.md", "test_root.txt",
+		}, relPaths(t, root, paths))
+	})
+
+	t.Run("exclude files by pattern", func(t *testing.T) {
+		paths, err := ListPathExclude(root, LpFile|LpRecursive, []string{".go"}, []string{"*_test.go"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main.go", "src/lib.go"}, relPaths(t, root, paths))
+	})
+
+	t.Run("exclude with double star", func(t *testing.T) {
+		paths, err := ListPathExclude(root, LpFile|LpRecursive, []string{".txt"}, []string{"**/data/*"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"test_root.txt"}, relPaths(t, root, paths))
+	})
+
+	t.Run("empty exclude behaves like ListPath", func(t *testing.T) {
+		paths, err := ListPathExclude(root, LpFile|LpRecursive, nil, nil)
+		require.NoError(t, err)
+
+		expected, err := ListPath(root, LpFile|LpRecursive, nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, paths)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := ListPathExclude(root, LpFile, nil, []string{"[invalid"})
+		assert.Error(t, err)
+	})
+}
+
+func TestWalkPath(t *testing.T) {
+	t.Run("visits the same entries as ListPath", func(t *testing.T) {
+		root := createListPathTree(t)
+
+		var walked []string
+		err := WalkPath(root, LpFile|LpDir|LpRecursive, []string{".go"}, func(path string) error {
+			walked = append(walked, path)
+			return nil
+		})
+		require.NoError(t, err)
+
+		listed, err := ListPath(root, LpFile|LpDir|LpRecursive, []string{".go"})
+		require.NoError(t, err)
+		assert.Equal(t, listed, walked)
+	})
+
+	t.Run("returning an error aborts the walk", func(t *testing.T) {
+		root := createListPathTree(t)
+		errStop := errors.New("stop")
+
+		count := 0
+		err := WalkPath(root, LpFile|LpRecursive, nil, func(path string) error {
+			if count++; count == 2 {
+				return errStop
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("SkipAll stops the walk without an error", func(t *testing.T) {
+		root := createListPathTree(t)
+
+		count := 0
+		err := WalkPath(root, LpFile|LpRecursive, nil, func(path string) error {
+			count++
+			return filepath.SkipAll
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("SkipDir skips the directory contents", func(t *testing.T) {
+		root := createListPathTree(t)
+
+		var walked []string
+		err := WalkPath(root, LpFile|LpDir|LpRecursive, nil, func(path string) error {
+			walked = append(walked, path)
+			if filepath.Base(path) == "src" {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"main.go", "main_test.go", "src", "test_dir", "test_dir/notes.md", "test_root.txt",
+		}, relPaths(t, root, walked))
+	})
+
+	t.Run("non-existent directory returns error", func(t *testing.T) {
+		err := WalkPath(filepath.Join(t.TempDir(), "missing"), LpFile, nil, func(path string) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
@@ -1,6 +1,9 @@
 package fs
 
-import "os"
+import (
+	"os"
+	"sync"
+)
 
 // MkTempDir creates a temporary directory with the given pattern and returns the directory path along with a cleanup
 // function.
@@ -24,12 +27,74 @@ import "os"
 // Note: The cleanup function uses os.RemoveAll, which will recursively remove the directory and all its contents
 // without error even if some files are missing.
 func MkTempDir(pattern string) (string, func(), error) {
+	return MkTempDirPerm(pattern, 0o700)
+}
+
+// MkTempDirPerm creates a temporary directory like MkTempDir, but with the given permissions instead of the default
+// 0o700, e.g. 0o755 for a directory that must be readable by other processes.
+//
+// # Parameters:
+//   - pattern: The prefix of the temporary directory name; empty for a system-generated name
+//   - perm: The permissions of the created directory, applied regardless of the umask
+//
+// It returns the same values as MkTempDir; the directory is removed if its permissions can't be changed.
+func MkTempDirPerm(pattern string, perm os.FileMode) (string, func(), error) {
+	tempDir, cleanup, err := mkTempDir(pattern, perm)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return tempDir, func() { _ = cleanup() }, nil
+}
+
+// MkTempDirE creates a temporary directory like MkTempDir, but its cleanup function returns the error of removing the
+// directory, so leaked temporary directories can be detected and logged.
+//
+// The cleanup function is safe to call multiple times; only the first call removes the directory, and the subsequent
+// calls return nil.
+//
+// # Example:
+//
+//	tempDir, cleanup, err := MkTempDirE("myapp-")
+//	if err != nil {
+//	    return err
+//	}
+//	defer func() {
+//	    if err := cleanup(); err != nil {
+//	        log.Printf("failed to remove %s: %v", tempDir, err)
+//	    }
+//	}()
+func MkTempDirE(pattern string) (string, func() error, error) {
+	return mkTempDir(pattern, 0o700)
+}
+
+// region - Private functions
+
+func mkTempDir(pattern string, perm os.FileMode) (string, func() error, error) {
 	tempDir, err := os.MkdirTemp("", pattern)
 	if err != nil {
 		return "", nil, err
 	}
 
-	return tempDir, func() {
+	if err = os.Chmod(tempDir, perm); err != nil {
 		os.RemoveAll(tempDir)
-	}, nil
+		return "", nil, err
+	}
+
+	return tempDir, onceCleanup(func() error {
+		return os.RemoveAll(tempDir)
+	}), nil
+}
+
+// onceCleanup wraps a cleanup function so it only runs on the first call; the subsequent calls return nil.
+func onceCleanup(cleanup func() error) func() error {
+	var once sync.Once
+
+	return func() error {
+		var err error
+		once.Do(func() { err = cleanup() })
+		return err
+	}
 }
+
+// endregion
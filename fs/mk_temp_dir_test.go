@@ -1,8 +1,10 @@
 package fs
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -188,3 +190,68 @@ func TestMkTempDir(t *testing.T) {
 		assert.True(t, strings.HasPrefix(tempDir, systemTempDir))
 	})
 }
+
+func TestMkTempDirPerm(t *testing.T) {
+	t.Run("applies the permissions", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping permission test on Windows")
+		}
+
+		tempDir, cleanup, err := MkTempDirPerm("perm-test-", 0o755)
+		require.NoError(t, err)
+		defer cleanup()
+
+		info, err := os.Stat(tempDir)
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+		assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+	})
+
+	t.Run("MkTempDir keeps the default permissions", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping permission test on Windows")
+		}
+
+		tempDir, cleanup, err := MkTempDir("perm-test-")
+		require.NoError(t, err)
+		defer cleanup()
+
+		info, err := os.Stat(tempDir)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+	})
+
+	t.Run("cleanup removes the directory", func(t *testing.T) {
+		tempDir, cleanup, err := MkTempDirPerm("perm-test-", 0o755)
+		require.NoError(t, err)
+
+		cleanup()
+		assert.NoDirExists(t, tempDir)
+	})
+}
+
+func TestMkTempDirE(t *testing.T) {
+	t.Run("cleanup removes the directory once", func(t *testing.T) {
+		tempDir, cleanup, err := MkTempDirE("cleanup-test-")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "file.txt"), []byte("content"), 0644))
+
+		assert.NoError(t, cleanup())
+		assert.NoDirExists(t, tempDir)
+
+		// Subsequent calls are no-ops
+		assert.NoError(t, cleanup())
+	})
+
+	t.Run("cleanup reports the first error only", func(t *testing.T) {
+		calls := 0
+		cleanup := onceCleanup(func() error {
+			calls++
+			return errors.New("permission denied")
+		})
+
+		assert.EqualError(t, cleanup(), "permission denied")
+		assert.NoError(t, cleanup())
+		assert.Equal(t, 1, calls)
+	})
+}
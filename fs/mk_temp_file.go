@@ -21,12 +21,55 @@ import "os"
 //	}
 //	defer cleanup()
 func MkTempFile(directory string, patten string) (*os.File, func(), error) {
-	tmpFile, err := os.CreateTemp(directory, patten)
+	return MkTempFilePerm(directory, patten, 0o600)
+}
+
+// MkTempFilePerm creates a temporary file like MkTempFile, but with the given permissions instead of the default 0o600.
+//
+// # Parameters:
+//   - directory: The directory where the file is created; empty for the default temporary directory
+//   - pattern: The pattern of the temporary file name, as in os.CreateTemp
+//   - perm: The permissions of the created file, applied regardless of the umask
+//
+// It returns the same values as MkTempFile; the file is removed if its permissions can't be changed.
+func MkTempFilePerm(directory string, pattern string, perm os.FileMode) (*os.File, func(), error) {
+	tmpFile, cleanup, err := mkTempFile(directory, pattern, perm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tmpFile, func() { _ = cleanup() }, nil
+}
+
+// MkTempFileE creates a temporary file like MkTempFile, but its cleanup function returns the error of removing the
+// file, so leaked temporary files can be detected and logged. A file that was already removed isn't an error.
+//
+// The cleanup function is safe to call multiple times; only the first call removes the file, and the subsequent calls
+// return nil.
+func MkTempFileE(directory string, pattern string) (*os.File, func() error, error) {
+	return mkTempFile(directory, pattern, 0o600)
+}
+
+// region - Private functions
+
+func mkTempFile(directory string, pattern string, perm os.FileMode) (*os.File, func() error, error) {
+	tmpFile, err := os.CreateTemp(directory, pattern)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return tmpFile, func() {
+	if err = tmpFile.Chmod(perm); err != nil {
+		tmpFile.Close()
 		os.Remove(tmpFile.Name())
-	}, nil
+		return nil, nil, err
+	}
+
+	return tmpFile, onceCleanup(func() error {
+		if err := os.Remove(tmpFile.Name()); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}), nil
 }
+
+// endregion
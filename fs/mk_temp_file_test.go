@@ -3,6 +3,7 @@ package fs
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -176,3 +177,61 @@ func TestMkTempFile(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestMkTempFilePerm(t *testing.T) {
+	t.Run("applies the permissions", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping permission test on Windows")
+		}
+
+		file, cleanup, err := MkTempFilePerm(t.TempDir(), "perm-*.txt", 0o644)
+		require.NoError(t, err)
+		defer cleanup()
+		defer file.Close()
+
+		info, err := os.Stat(file.Name())
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+	})
+
+	t.Run("MkTempFile keeps the default permissions", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping permission test on Windows")
+		}
+
+		file, cleanup, err := MkTempFile(t.TempDir(), "perm-*.txt")
+		require.NoError(t, err)
+		defer cleanup()
+		defer file.Close()
+
+		info, err := os.Stat(file.Name())
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("invalid directory returns error", func(t *testing.T) {
+		_, _, err := MkTempFilePerm(filepath.Join(t.TempDir(), "missing"), "perm-*.txt", 0o644)
+		assert.Error(t, err)
+	})
+}
+
+func TestMkTempFileE(t *testing.T) {
+	t.Run("cleanup removes the file once", func(t *testing.T) {
+		file, cleanup, err := MkTempFileE(t.TempDir(), "cleanup-*.txt")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		assert.NoError(t, cleanup())
+		assert.NoFileExists(t, file.Name())
+		assert.NoError(t, cleanup())
+	})
+
+	t.Run("already removed file is not an error", func(t *testing.T) {
+		file, cleanup, err := MkTempFileE(t.TempDir(), "cleanup-*.txt")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		require.NoError(t, os.Remove(file.Name()))
+
+		assert.NoError(t, cleanup())
+	})
+}
@@ -0,0 +1,25 @@
+package fs
+
+import "os"
+
+// MkUserCacheDir creates a directory within the user's cache directory, like MkUserConfigDir does for the configuration
+// directory. Use it for data that can be regenerated, such as caches, instead of settings.
+//
+// The function uses os.UserCacheDir() to get the platform-specific user cache directory and creates the full directory
+// path by joining the name and any additional parts. The directory is created with permissions 0o755 (rwxr-xr-x).
+//
+// # Parameters:
+//   - name: The primary directory name (cannot be empty)
+//   - parts: Optional additional path segments to create nested subdirectories
+//
+// # Returns:
+//   - string: The full path to the created directory
+//   - error: Any error that occurred during directory creation or if name is empty
+//
+// # Example:
+//
+//	dir, err := MkUserCacheDir("myapp")
+//	// Creates: ~/.cache/myapp (on Linux), ~/Library/Caches/myapp (on macOS) or %LocalAppData%\myapp (on Windows)
+func MkUserCacheDir(name string, parts ...string) (string, error) {
+	return mkUserDir(os.UserCacheDir, name, parts)
+}
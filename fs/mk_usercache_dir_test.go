@@ -0,0 +1,53 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withTestCacheDir points the user cache directory to a temporary directory and returns it
+func withTestCacheDir(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	t.Setenv("LocalAppData", filepath.Join(home, "AppData", "Local"))
+
+	cacheDir, err := os.UserCacheDir()
+	require.NoError(t, err)
+	return cacheDir
+}
+
+func TestMkUserCacheDir(t *testing.T) {
+	t.Run("creates directory with nested parts", func(t *testing.T) {
+		cacheDir := withTestCacheDir(t)
+
+		dir, err := MkUserCacheDir("test-app", "memo", "v1")
+		require.NoError(t, err)
+
+		assert.Equal(t, filepath.Join(cacheDir, "test-app", "memo", "v1"), dir)
+		assert.DirExists(t, dir)
+	})
+
+	t.Run("succeeds when directory already exists", func(t *testing.T) {
+		withTestCacheDir(t)
+
+		dir1, err := MkUserCacheDir("test-app")
+		require.NoError(t, err)
+
+		dir2, err := MkUserCacheDir("test-app")
+		require.NoError(t, err)
+		assert.Equal(t, dir1, dir2)
+	})
+
+	t.Run("error when name is empty", func(t *testing.T) {
+		dir, err := MkUserCacheDir("")
+		assert.EqualError(t, err, "name cannot be empty")
+		assert.Empty(t, dir)
+	})
+}
@@ -0,0 +1,26 @@
+package fs
+
+import "os"
+
+// MkUserCacheFile creates a file in the user's cache directory, like MkUserConfigFile does for the configuration
+// directory. It creates all necessary parent directories if they don't exist and opens the file for read/write
+// operations.
+//
+// # Parameters:
+//   - name: The application or service name that will be used as the top-level directory within the user's cache
+//     directory. Cannot be empty.
+//   - parts: Variable number of path components where the last element is the filename and preceding elements are
+//     subdirectory names. At least one component must be provided.
+//
+// # Returns:
+//   - *os.File: An opened file handle with read/write permissions (0o644), or nil on error
+//   - error: An error if the operation fails, including cases where name is empty, no parts are provided, user cache
+//     directory cannot be determined, directory creation fails, or file opening fails
+//
+// # Example:
+//
+//	// Creates ~/.cache/myapp/memo/entries.db on Linux
+//	file, err := MkUserCacheFile("myapp", "memo", "entries.db")
+func MkUserCacheFile(name string, parts ...string) (*os.File, error) {
+	return mkUserFile(os.UserCacheDir, "cache", name, os.O_RDWR|os.O_CREATE, 0o644, parts)
+}
@@ -0,0 +1,34 @@
+package fs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMkUserCacheFile(t *testing.T) {
+	t.Run("creates file with intermediate directories", func(t *testing.T) {
+		cacheDir := withTestCacheDir(t)
+
+		file, err := MkUserCacheFile("test-app", "memo", "entries.db")
+		require.NoError(t, err)
+		defer file.Close()
+
+		assert.Equal(t, filepath.Join(cacheDir, "test-app", "memo", "entries.db"), file.Name())
+		assert.FileExists(t, file.Name())
+	})
+
+	t.Run("error when name is empty", func(t *testing.T) {
+		file, err := MkUserCacheFile("", "entries.db")
+		assert.EqualError(t, err, "name cannot be empty")
+		assert.Nil(t, file)
+	})
+
+	t.Run("error when no path components provided", func(t *testing.T) {
+		file, err := MkUserCacheFile("test-app")
+		assert.EqualError(t, err, "no path components provided")
+		assert.Nil(t, file)
+	})
+}
@@ -29,16 +29,23 @@ import (
 //	dir, err := MkUserConfigDir("myapp", "settings", "cache")
 //	// Creates: ~/.config/myapp/settings/cache
 func MkUserConfigDir(name string, parts ...string) (string, error) {
+	return mkUserDir(os.UserConfigDir, name, parts)
+}
+
+// region - Private functions
+
+// mkUserDir creates a directory with the given name and parts within the base directory returned by baseDir.
+func mkUserDir(baseDir func() (string, error), name string, parts []string) (string, error) {
 	if name == "" {
 		return "", fmt.Errorf("name cannot be empty")
 	}
 
-	configDir, err := os.UserConfigDir()
+	base, err := baseDir()
 	if err != nil {
 		return "", err
 	}
 
-	allParts := append([]string{configDir, name}, parts...)
+	allParts := append([]string{base, name}, parts...)
 	fullPath := filepath.Join(allParts...)
 
 	if mErr := os.MkdirAll(fullPath, 0o755); mErr != nil {
@@ -47,3 +54,5 @@ func MkUserConfigDir(name string, parts ...string) (string, error) {
 
 	return fullPath, nil
 }
+
+// endregion
@@ -34,34 +34,117 @@ import (
 //	file, err := MkUserConfigFile("myapp", "db", "settings.json")
 //
 // The created directories have permissions 0o755 and the file has permissions 0o644.
+//
+// The file is opened with os.O_RDWR|os.O_CREATE: an existing file is neither truncated nor appended to, so its content
+// is preserved and writes start at the beginning, overwriting it in place. Use MkUserConfigFileMode to choose another
+// open mode, or MkUserConfigFileAtomic to replace the content safely.
 func MkUserConfigFile(name string, parts ...string) (*os.File, error) {
+	return MkUserConfigFileMode(name, os.O_RDWR|os.O_CREATE, 0o644, parts...)
+}
+
+// MkUserConfigFileMode creates or opens a file in the user's configuration directory like MkUserConfigFile, but with
+// explicit flags and permissions, removing any ambiguity about what happens to an existing file's content. The parent
+// directories are always created.
+//
+// # Parameters:
+//   - name: The application or service name used as the top-level directory; cannot be empty
+//   - flag: The flags used to open the file, as in os.OpenFile, e.g. os.O_RDONLY to read an existing file,
+//     os.O_WRONLY|os.O_CREATE|os.O_TRUNC to replace its content or os.O_WRONLY|os.O_CREATE|os.O_APPEND to append to it
+//   - perm: The permissions of the file if it's created
+//   - parts: Path components where the last element is the filename; at least one must be provided
+//
+// It returns the opened file, or the same errors as MkUserConfigFile.
+//
+// # Example:
+//
+//	// Appends to ~/.config/myapp/history.log
+//	file, err := MkUserConfigFileMode("myapp", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600, "history.log")
+func MkUserConfigFileMode(name string, flag int, perm os.FileMode, parts ...string) (*os.File, error) {
+	return mkUserFile(os.UserConfigDir, "config", name, flag, perm, parts)
+}
+
+// MkUserConfigFileAtomic creates a file in the user's configuration directory like MkUserConfigFile, but returns an
+// AtomicFile: the writes go to a temporary file next to it, and Close atomically replaces the config file, so a crash
+// mid-write never leaves a corrupted config behind.
+//
+// The returned file starts empty, and its content replaces the whole config file when it's closed. The permissions of
+// an existing config file are kept; new files get 0o644.
+//
+// # Example:
+//
+//	file, err := MkUserConfigFileAtomic("myapp", "config.json")
+//	if err != nil {
+//	    return err
+//	}
+//	if err = json.NewEncoder(file).Encode(config); err != nil {
+//	    file.Abort()
+//	    return err
+//	}
+//	return file.Close()
+func MkUserConfigFileAtomic(name string, parts ...string) (*AtomicFile, error) {
+	fullPath, err := mkUserFilePath(os.UserConfigDir, "config", name, parts)
+	if err != nil {
+		return nil, err
+	}
+
+	perm := os.FileMode(0o644)
+	if info, statErr := os.Stat(fullPath); statErr == nil {
+		perm = info.Mode().Perm()
+	}
+
+	return CreateAtomic(fullPath, perm)
+}
+
+// region - Private functions
+
+// mkUserFile opens a file with the given name and parts within the base directory returned by baseDir; kind describes
+// the base directory in error messages.
+func mkUserFile(
+	baseDir func() (string, error),
+	kind string,
+	name string,
+	flag int,
+	perm os.FileMode,
+	parts []string,
+) (*os.File, error) {
+	fullPath, err := mkUserFilePath(baseDir, kind, name, parts)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(fullPath, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// mkUserFilePath validates the name and parts of a file within the base directory returned by baseDir, creating its
+// parent directories, and returns the full path of the file.
+func mkUserFilePath(baseDir func() (string, error), kind string, name string, parts []string) (string, error) {
 	if name == "" {
-		return nil, fmt.Errorf("name cannot be empty")
+		return "", fmt.Errorf("name cannot be empty")
 	}
 
 	if len(parts) == 0 {
-		return nil, fmt.Errorf("no path components provided")
+		return "", fmt.Errorf("no path components provided")
 	}
 
-	configDir, err := os.UserConfigDir()
+	base, err := baseDir()
 	if err != nil {
-		return nil, fmt.Errorf("error getting the user config dir: %w", err)
+		return "", fmt.Errorf("error getting the user %s dir: %w", kind, err)
 	}
 
-	dirParts := append([]string{configDir, name}, parts[:len(parts)-1]...)
+	dirParts := append([]string{base, name}, parts[:len(parts)-1]...)
 	dirPath := filepath.Join(dirParts...)
 
 	if mErr := os.MkdirAll(dirPath, 0o755); mErr != nil {
-		return nil, mErr
+		return "", mErr
 	}
 
 	filePath := parts[len(parts)-1]
-	fullPath := filepath.Join(dirPath, filePath)
-
-	file, err := os.OpenFile(fullPath, os.O_RDWR|os.O_CREATE, 0o644)
-	if err != nil {
-		return nil, err
-	}
-
-	return file, nil
+	return filepath.Join(dirPath, filePath), nil
 }
+
+// endregion
@@ -3,6 +3,7 @@ package fs
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -218,3 +219,109 @@ func TestMkUserConfigFile_EdgeCases(t *testing.T) {
 		assert.Equal(t, "config", stat.Name())
 	})
 }
+
+func TestMkUserConfigFileAtomic(t *testing.T) {
+	t.Run("commits the content on Close", func(t *testing.T) {
+		defer cleanupTestConfig(t, "test-app")
+
+		file, err := MkUserConfigFile("test-app", "atomic.json")
+		require.NoError(t, err)
+		_, err = file.WriteString("old")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		require.NoError(t, os.Chmod(file.Name(), 0o600))
+
+		atomic, err := MkUserConfigFileAtomic("test-app", "atomic.json")
+		require.NoError(t, err)
+
+		_, err = atomic.WriteString("new")
+		require.NoError(t, err)
+		assertFileExists(t, file.Name(), "old")
+
+		require.NoError(t, atomic.Close())
+		assertFileExists(t, file.Name(), "new")
+
+		// The permissions of the existing file are kept
+		if runtime.GOOS != "windows" {
+			info, statErr := os.Stat(file.Name())
+			require.NoError(t, statErr)
+			assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+		}
+	})
+
+	t.Run("error when no path components provided", func(t *testing.T) {
+		file, err := MkUserConfigFileAtomic("test-app")
+		assert.EqualError(t, err, "no path components provided")
+		assert.Nil(t, file)
+	})
+}
+
+func TestMkUserConfigFileMode(t *testing.T) {
+	setup := func(t *testing.T) string {
+		file, err := MkUserConfigFile("test-app", "mode.txt")
+		require.NoError(t, err)
+		_, err = file.WriteString("existing")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		return file.Name()
+	}
+
+	t.Run("truncate", func(t *testing.T) {
+		defer cleanupTestConfig(t, "test-app")
+		path := setup(t)
+
+		file, err := MkUserConfigFileMode("test-app", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644, "mode.txt")
+		require.NoError(t, err)
+		_, err = file.WriteString("new")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		assertFileExists(t, path, "new")
+	})
+
+	t.Run("append", func(t *testing.T) {
+		defer cleanupTestConfig(t, "test-app")
+		path := setup(t)
+
+		file, err := MkUserConfigFileMode("test-app", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644, "mode.txt")
+		require.NoError(t, err)
+		_, err = file.WriteString(" appended")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		assertFileExists(t, path, "existing appended")
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		defer cleanupTestConfig(t, "test-app")
+		setup(t)
+
+		file, err := MkUserConfigFileMode("test-app", os.O_RDONLY, 0, "mode.txt")
+		require.NoError(t, err)
+		defer file.Close()
+
+		_, err = file.WriteString("not allowed")
+		assert.Error(t, err)
+	})
+
+	t.Run("read-only missing file returns error", func(t *testing.T) {
+		defer cleanupTestConfig(t, "test-app")
+
+		file, err := MkUserConfigFileMode("test-app", os.O_RDONLY, 0, "missing.txt")
+		assert.Error(t, err)
+		assert.Nil(t, file)
+	})
+
+	t.Run("default mode overwrites in place", func(t *testing.T) {
+		defer cleanupTestConfig(t, "test-app")
+		path := setup(t)
+
+		file, err := MkUserConfigFile("test-app", "mode.txt")
+		require.NoError(t, err)
+		_, err = file.WriteString("EX")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		assertFileExists(t, path, "EXisting")
+	})
+}
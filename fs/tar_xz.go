@@ -0,0 +1,185 @@
+package fs
+
+import (
+	"archive/tar"
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ulikunitz/xz"
+)
+
+// xzDictCaps maps each xz compression preset to its dictionary size, following the presets of the xz command line tool.
+var xzDictCaps = [...]int{
+	256 << 10, 1 << 20, 2 << 20, 4 << 20, 4 << 20, 8 << 20, 8 << 20, 16 << 20, 32 << 20, 64 << 20,
+}
+
+// TarXz creates a TAR.XZ archive with all files and directories inside a source directory, using the default xz
+// compression preset (6). Entries are stored with their paths relative to the source directory, so the archive can be
+// extracted with UntarXz to reproduce the same tree.
+//
+// See TarXzWithPreset for more details.
+//
+// # Parameters:
+//   - sourceDir: Directory whose contents will be archived
+//   - tarXzPath: Path of the TAR.XZ file to create
+func TarXz(sourceDir, tarXzPath string) error {
+	return TarXzWithPreset(sourceDir, tarXzPath, 6)
+}
+
+// TarXzWithPreset creates a TAR.XZ archive with all files and directories inside a source directory, using the given xz
+// compression preset. Like the xz command line tool, higher presets use a larger dictionary, which compresses better at
+// the cost of more memory.
+//
+// File modes are preserved, symbolic links are written as symlink entries instead of being followed, and an empty source
+// directory produces a valid empty archive. If the TAR.XZ file is created inside the source directory, it is not added
+// to itself.
+//
+// # Parameters:
+//   - sourceDir: Directory whose contents will be archived
+//   - tarXzPath: Path of the TAR.XZ file to create
+//   - preset: The xz compression preset, from 0 (fastest) to 9 (best compression)
+//
+// # Returns an error if:
+//   - The preset is out of range
+//   - The source directory cannot be read
+//   - The TAR.XZ file cannot be created or written
+//   - Any symbolic link has an illegal target (absolute or outside the source directory)
+//
+// A partially written TAR.XZ file is removed when archiving fails.
+func TarXzWithPreset(sourceDir, tarXzPath string, preset int) (err error) {
+	if preset < 0 || preset >= len(xzDictCaps) {
+		return fmt.Errorf("invalid xz preset: %d", preset)
+	}
+
+	info, err := os.Stat(sourceDir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", sourceDir)
+	}
+
+	// Resolve the archive path so it can be skipped if it's inside the source directory
+	absTarXzPath, err := filepath.Abs(tarXzPath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(tarXzPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		f.Close()
+		if err != nil {
+			os.Remove(tarXzPath)
+		}
+	}()
+
+	// Use buffered writer for better performance with large archives
+	bufWriter := bufio.NewWriterSize(f, 1024*1024) // 1MB buffer
+
+	config := xz.WriterConfig{DictCap: xzDictCaps[preset]}
+	xzWriter, err := config.NewWriter(bufWriter)
+	if err != nil {
+		return err
+	}
+
+	tarWriter := tar.NewWriter(xzWriter)
+
+	err = filepath.WalkDir(sourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if absPath, absErr := filepath.Abs(path); absErr == nil && absPath == absTarXzPath {
+			return nil
+		}
+
+		rel, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+
+		// Skip the source directory itself
+		if rel == "." {
+			return nil
+		}
+
+		return addTarEntry(tarWriter, sourceDir, path, filepath.ToSlash(rel))
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+	if err = xzWriter.Close(); err != nil {
+		return err
+	}
+	if err = bufWriter.Flush(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// region - Private functions
+
+func addTarEntry(tarWriter *tar.Writer, sourceDir, path, name string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	linkTarget := ""
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		if linkTarget, err = os.Readlink(path); err != nil {
+			return err
+		}
+
+		if err = sanitizeArchiveSymlink(path, linkTarget, sourceDir); err != nil {
+			return fmt.Errorf("illegal symlink target: %s -> %s", name, linkTarget)
+		}
+
+	case !info.IsDir() && !info.Mode().IsRegular():
+		// Skip other types (devices, FIFOs, sockets, etc.)
+		return nil
+	}
+
+	header, err := tar.FileInfoHeader(info, linkTarget)
+	if err != nil {
+		return err
+	}
+
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+
+	if err = tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
+
+// endregion
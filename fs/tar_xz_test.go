@@ -0,0 +1,161 @@
+package fs
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
+)
+
+func readTarXzNames(t *testing.T, tarXzPath string) []string {
+	f, err := os.Open(tarXzPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	xzReader, err := xz.NewReader(f)
+	require.NoError(t, err)
+
+	names := make([]string, 0)
+	tarReader := tar.NewReader(xzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, header.Name)
+	}
+
+	return names
+}
+
+func TestTarXz(t *testing.T) {
+	t.Run("round trip with UntarXz", func(t *testing.T) {
+		sourceDir := createTestTree(t)
+		tarXzPath := filepath.Join(t.TempDir(), "archive.tar.xz")
+
+		require.NoError(t, TarXz(sourceDir, tarXzPath))
+
+		targetDir := filepath.Join(t.TempDir(), "target")
+		require.NoError(t, UntarXz(tarXzPath, targetDir))
+
+		assert.Equal(t, readTree(t, sourceDir), readTree(t, targetDir))
+		assert.DirExists(t, filepath.Join(targetDir, "empty"))
+	})
+
+	t.Run("round trip preserves permissions", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping permission test on Windows")
+		}
+
+		sourceDir := t.TempDir()
+		modes := map[string]os.FileMode{
+			"executable.sh": 0755,
+			"private.txt":   0600,
+			"regular.txt":   0644,
+		}
+
+		for name, mode := range modes {
+			path := filepath.Join(sourceDir, name)
+			require.NoError(t, os.WriteFile(path, []byte(name), mode))
+			require.NoError(t, os.Chmod(path, mode))
+		}
+
+		tarXzPath := filepath.Join(t.TempDir(), "archive.tar.xz")
+		require.NoError(t, TarXz(sourceDir, tarXzPath))
+
+		targetDir := t.TempDir()
+		require.NoError(t, UntarXz(tarXzPath, targetDir))
+
+		for name, mode := range modes {
+			info, err := os.Stat(filepath.Join(targetDir, name))
+			require.NoError(t, err)
+			assert.Equal(t, mode, info.Mode().Perm(), name)
+		}
+	})
+
+	t.Run("round trip preserves symlinks", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping symlink test on Windows")
+		}
+
+		sourceDir := createTestTree(t)
+		require.NoError(t, os.Symlink("dir1/file1.txt", filepath.Join(sourceDir, "link.txt")))
+		require.NoError(t, os.Symlink("../root.txt", filepath.Join(sourceDir, "dir1", "up.txt")))
+		tarXzPath := filepath.Join(t.TempDir(), "archive.tar.xz")
+
+		require.NoError(t, TarXz(sourceDir, tarXzPath))
+
+		targetDir := filepath.Join(t.TempDir(), "target")
+		require.NoError(t, UntarXz(tarXzPath, targetDir))
+
+		assert.Equal(t, readTree(t, sourceDir), readTree(t, targetDir))
+
+		target, err := os.Readlink(filepath.Join(targetDir, "dir1", "up.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "../root.txt", target)
+	})
+
+	t.Run("symlink outside the source directory", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping symlink test on Windows")
+		}
+
+		sourceDir := createTestTree(t)
+		require.NoError(t, os.Symlink("/etc/passwd", filepath.Join(sourceDir, "link.txt")))
+
+		archivePath := filepath.Join(t.TempDir(), "archive.tar.xz")
+		err := TarXz(sourceDir, archivePath)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "illegal symlink target")
+		assert.NoFileExists(t, archivePath)
+	})
+
+	t.Run("empty source directory", func(t *testing.T) {
+		tarXzPath := filepath.Join(t.TempDir(), "empty.tar.xz")
+
+		require.NoError(t, TarXz(t.TempDir(), tarXzPath))
+		assert.Empty(t, readTarXzNames(t, tarXzPath))
+
+		require.NoError(t, UntarXz(tarXzPath, t.TempDir()))
+	})
+
+	t.Run("archive inside the source directory", func(t *testing.T) {
+		sourceDir := createTestTree(t)
+		tarXzPath := filepath.Join(sourceDir, "archive.tar.xz")
+
+		require.NoError(t, TarXz(sourceDir, tarXzPath))
+		assert.NotContains(t, readTarXzNames(t, tarXzPath), "archive.tar.xz")
+	})
+
+	t.Run("custom presets", func(t *testing.T) {
+		sourceDir := createTestTree(t)
+		tempDir := t.TempDir()
+
+		for _, preset := range []int{0, 9} {
+			tarXzPath := filepath.Join(tempDir, "archive.tar.xz")
+			require.NoError(t, TarXzWithPreset(sourceDir, tarXzPath, preset))
+
+			targetDir := t.TempDir()
+			require.NoError(t, UntarXz(tarXzPath, targetDir))
+			assert.Equal(t, readTree(t, sourceDir), readTree(t, targetDir))
+		}
+	})
+
+	t.Run("invalid preset", func(t *testing.T) {
+		err := TarXzWithPreset(createTestTree(t), filepath.Join(t.TempDir(), "archive.tar.xz"), 10)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid xz preset")
+	})
+
+	t.Run("source does not exist", func(t *testing.T) {
+		err := TarXz(filepath.Join(t.TempDir(), "missing"), filepath.Join(t.TempDir(), "archive.tar.xz"))
+		assert.Error(t, err)
+	})
+}
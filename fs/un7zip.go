@@ -15,6 +15,7 @@ import (
 // The function implements security measures to prevent Zip Slip attacks by:
 //   - Rejecting absolute paths in archive entries
 //   - Preventing path traversal attacks using ".." segments
+//   - Rejecting names with NUL or other control characters, and Windows reserved device names on Windows
 //   - Normalizing path separators to handle both forward slashes and backslashes
 //   - Validating that extracted files remain within the target directory
 //   - Validating that symbolic link targets remain within the target directory
@@ -17,6 +17,7 @@ import (
 // The function implements security measures to prevent path traversal attacks by:
 //   - Rejecting absolute paths in archive entries
 //   - Preventing path traversal attacks using ".." segments
+//   - Rejecting names with NUL or other control characters, and Windows reserved device names on Windows
 //   - Normalizing path separators to handle both forward slashes and backslashes
 //   - Validating that extracted files remain within the target directory
 //   - Validating that symbolic link targets remain within the target directory
@@ -31,8 +32,73 @@ import (
 //   - Any archive entry contains an illegal path (absolute or traversal)
 //   - File extraction fails due to I/O errors or permission issues
 //
-// All extracted files preserve their original permissions from the archive.
+// All extracted files and directories preserve their original permissions and modification times from the archive.
 func UntarXz(tarXzPath, targetDirectory string) error {
+	return UntarXzLimited(tarXzPath, targetDirectory, 0, 0)
+}
+
+// UntarXzLimited extracts a TAR.XZ archive like UntarXz, but refuses to extract more than maxBytes of uncompressed data
+// or more than maxEntries entries, protecting against decompression bombs when extracting untrusted archives.
+//
+// TAR archives have no index, so the limits are enforced while the archive is streamed. When a limit is exceeded, the
+// extraction is aborted and the files extracted so far are removed.
+//
+// # Parameters:
+//   - tarXzPath: Path to the TAR.XZ file to extract
+//   - targetDirectory: Destination directory where files will be extracted
+//   - maxBytes: Maximum total uncompressed size of the extracted files; 0 means unlimited
+//   - maxEntries: Maximum number of entries in the archive; 0 means unlimited
+//
+// It returns the same errors as UntarXz, and an error if any of the limits is exceeded.
+func UntarXzLimited(tarXzPath, targetDirectory string, maxBytes int64, maxEntries int) error {
+	return UntarXzWithOptions(tarXzPath, targetDirectory, ExtractOptions{
+		MaxBytes:      maxBytes,
+		MaxEntries:    maxEntries,
+		PreserveTimes: true,
+	})
+}
+
+// UntarXzWithPolicy extracts a TAR.XZ archive like UntarXz, resolving the entries that would replace existing files
+// with the given policy. This allows safely extracting into an existing directory, e.g. an install directory.
+//
+// # Parameters:
+//   - tarXzPath: Path to the TAR.XZ file to extract
+//   - targetDirectory: Destination directory where files will be extracted
+//   - policy: OverwriteAlways to replace existing files (like UntarXz), SkipExisting to leave them untouched, or
+//     FailOnConflict to return an error naming the first existing file
+//
+// TAR archives have no index, so with FailOnConflict the conflict is only found while the archive is streamed; the
+// files extracted before it are then removed.
+//
+// It returns the same errors as UntarXz, and an error on the first conflict when the policy is FailOnConflict.
+func UntarXzWithPolicy(tarXzPath, targetDirectory string, policy ExtractPolicy) error {
+	return UntarXzWithOptions(tarXzPath, targetDirectory, ExtractOptions{PreserveTimes: true, Policy: policy})
+}
+
+// UntarXzWithOptions extracts a TAR.XZ archive like UntarXz, combining the limits of UntarXzLimited and the conflict
+// policy of UntarXzWithPolicy. Unlike UntarXz, the modification times are only restored when opts.PreserveTimes is set.
+//
+// # Parameters:
+//   - tarXzPath: Path to the TAR.XZ file to extract
+//   - targetDirectory: Destination directory where files will be extracted
+//   - opts: The limits, whether to preserve the modification times, and the conflict policy of the extraction
+//
+// It returns the same errors as UntarXz, UntarXzLimited and UntarXzWithPolicy.
+//
+// # Example:
+//
+//	err := UntarXzWithOptions("upload.tar.xz", "/srv/app", ExtractOptions{
+//	    MaxBytes:      100 << 20,
+//	    PreserveTimes: true,
+//	    Policy:        SkipExisting,
+//	})
+func UntarXzWithOptions(tarXzPath, targetDirectory string, opts ExtractOptions) error {
+	return untarXz(tarXzPath, targetDirectory, newExtraction(opts))
+}
+
+// region - Private functions
+
+func untarXz(tarXzPath, targetDirectory string, e *extraction) error {
 	// Open the tar.xz file
 	f, err := os.Open(tarXzPath)
 	if err != nil {
@@ -46,11 +112,22 @@ func UntarXz(tarXzPath, targetDirectory string) error {
 		return err
 	}
 
+	return untar(xzReader, targetDirectory, e)
+}
+
+// untar extracts an uncompressed TAR stream to a target directory, applying the same security checks as UntarXz.
+func untar(reader io.Reader, targetDirectory string, e *extraction) (err error) {
+	defer func() {
+		if err != nil {
+			err = e.abort(err)
+		}
+	}()
+
 	// Create a tar reader
-	tarReader := tar.NewReader(xzReader)
+	tarReader := tar.NewReader(reader)
 
 	// Ensure the destination directory exists
-	if err = os.MkdirAll(targetDirectory, 0o755); err != nil {
+	if err := os.MkdirAll(targetDirectory, 0o755); err != nil {
 		return err
 	}
 
@@ -64,11 +141,26 @@ func UntarXz(tarXzPath, targetDirectory string) error {
 			return err
 		}
 
+		if err = e.addEntry(); err != nil {
+			return err
+		}
+
 		fpath, err := sanitizeArchivePath(header.Name, targetDirectory)
 		if err != nil {
 			return err
 		}
 
+		// Apply the extraction policy to entries that would replace an existing file
+		if header.Typeflag == tar.TypeReg || header.Typeflag == tar.TypeSymlink {
+			skip, skipErr := e.skip(fpath)
+			if skipErr != nil {
+				return skipErr
+			}
+			if skip {
+				continue
+			}
+		}
+
 		// Handle different file types
 		switch header.Typeflag {
 		case tar.TypeDir:
@@ -77,6 +169,10 @@ func UntarXz(tarXzPath, targetDirectory string) error {
 				return err
 			}
 
+			if err = e.setTimes(fpath, header.ModTime, true); err != nil {
+				return err
+			}
+
 		case tar.TypeReg:
 			// Ensure the parent directory exists
 			if err = os.MkdirAll(filepath.Dir(fpath), 0o755); err != nil {
@@ -84,16 +180,18 @@ func UntarXz(tarXzPath, targetDirectory string) error {
 			}
 
 			// Create the destination file
+			existed := exists(fpath)
 			outFile, fErr := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(header.Mode))
 			if fErr != nil {
 				return fErr
 			}
+			e.track(fpath, existed)
 
 			// Use buffered writer for better performance with large files
 			bufWriter := bufio.NewWriterSize(outFile, 1024*1024) // 1MB buffer
 
 			// Copy file contents from the tar archive to destination file
-			if _, err = io.Copy(bufWriter, tarReader); err != nil {
+			if err = e.copy(bufWriter, tarReader); err != nil {
 				outFile.Close()
 				return err
 			}
@@ -105,14 +203,20 @@ func UntarXz(tarXzPath, targetDirectory string) error {
 			}
 			outFile.Close()
 
+			if err = e.setTimes(fpath, header.ModTime, false); err != nil {
+				return err
+			}
+
 		case tar.TypeSymlink:
 			if err = sanitizeArchiveSymlink(fpath, header.Linkname, targetDirectory); err != nil {
 				return fmt.Errorf("illegal symlink target: %s -> %s", header.Name, header.Linkname)
 			}
 
+			existed := exists(fpath)
 			if err = os.Symlink(header.Linkname, fpath); err != nil {
 				return err
 			}
+			e.track(fpath, existed)
 
 		default:
 			// Skip other types (block devices, char devices, FIFOs, etc.)
@@ -120,5 +224,7 @@ func UntarXz(tarXzPath, targetDirectory string) error {
 		}
 	}
 
-	return nil
+	return e.finish()
 }
+
+// endregion
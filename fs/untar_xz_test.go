@@ -4,7 +4,9 @@ import (
 	"archive/tar"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,6 +38,29 @@ func TestUntarXz(t *testing.T) {
 		assertFileExists(t, filepath.Join(targetDir, "dir1", "dir2", "file3.txt"), "content3")
 	})
 
+	t.Run("preserves modification times", func(t *testing.T) {
+		fileTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		dirTime := time.Date(2019, 6, 7, 8, 9, 10, 0, time.UTC)
+
+		tarXzPath := createTestTarXz(t, map[string]testEntry{
+			"dir1/":          {isDir: true, mode: 0755, modTime: dirTime},
+			"dir1/file1.txt": {content: "content1", mode: 0644, modTime: fileTime},
+		})
+		defer os.Remove(tarXzPath)
+
+		targetDir := t.TempDir()
+		require.NoError(t, UntarXz(tarXzPath, targetDir))
+
+		info, err := os.Stat(filepath.Join(targetDir, "dir1", "file1.txt"))
+		require.NoError(t, err)
+		assert.True(t, fileTime.Equal(info.ModTime()), info.ModTime())
+
+		// The directory keeps its time even though a file was written inside it
+		info, err = os.Stat(filepath.Join(targetDir, "dir1"))
+		require.NoError(t, err)
+		assert.True(t, dirTime.Equal(info.ModTime()), info.ModTime())
+	})
+
 	t.Run("successful extraction with leading ./ in paths", func(t *testing.T) {
 		tarXzPath := createTestTarXz(t, map[string]testEntry{
 			"./file1.txt":      {content: "content1", isDir: false, mode: 0644},
@@ -247,6 +272,7 @@ type testEntry struct {
 	content string
 	isDir   bool
 	mode    os.FileMode
+	modTime time.Time
 }
 
 // createTestTarXz creates a temporary tar.xz file with the specified entries
@@ -270,6 +296,7 @@ func createTestTarXz(t *testing.T, entries map[string]testEntry) string {
 			header = &tar.Header{
 				Name:     name,
 				Mode:     int64(entry.mode),
+				ModTime:  entry.modTime,
 				Typeflag: tar.TypeDir,
 			}
 		} else {
@@ -277,6 +304,7 @@ func createTestTarXz(t *testing.T, entries map[string]testEntry) string {
 				Name:     name,
 				Mode:     int64(entry.mode),
 				Size:     int64(len(entry.content)),
+				ModTime:  entry.modTime,
 				Typeflag: tar.TypeReg,
 			}
 		}
@@ -354,3 +382,144 @@ func assertFileExists(t *testing.T, path, expectedContent string) {
 	require.NoError(t, err)
 	assert.Equal(t, expectedContent, string(content))
 }
+
+func TestUntarXzLimited(t *testing.T) {
+	entries := map[string]testEntry{
+		"dir":       {isDir: true, mode: 0755},
+		"a.txt":     {content: strings.Repeat("a", 100), mode: 0644},
+		"b.txt":     {content: strings.Repeat("b", 100), mode: 0644},
+		"dir/c.txt": {content: strings.Repeat("c", 100), mode: 0644},
+	}
+
+	t.Run("within the limits", func(t *testing.T) {
+		tarXzPath := createTestTarXz(t, entries)
+		defer os.Remove(tarXzPath)
+
+		targetDir := t.TempDir()
+		require.NoError(t, UntarXzLimited(tarXzPath, targetDir, 300, 4))
+		assertFileExists(t, filepath.Join(targetDir, "dir", "c.txt"), strings.Repeat("c", 100))
+	})
+
+	t.Run("too many bytes abort and clean up", func(t *testing.T) {
+		tarXzPath := createTestTarXz(t, entries)
+		defer os.Remove(tarXzPath)
+
+		targetDir := t.TempDir()
+		err := UntarXzLimited(tarXzPath, targetDir, 250, 0)
+		assert.ErrorContains(t, err, "archive exceeds the limit of 250 uncompressed bytes")
+
+		for _, name := range []string{"a.txt", "b.txt", filepath.Join("dir", "c.txt")} {
+			assert.NoFileExists(t, filepath.Join(targetDir, name))
+		}
+	})
+
+	t.Run("too many entries abort and clean up", func(t *testing.T) {
+		tarXzPath := createTestTarXz(t, entries)
+		defer os.Remove(tarXzPath)
+
+		targetDir := t.TempDir()
+		err := UntarXzLimited(tarXzPath, targetDir, 0, 3)
+		assert.ErrorContains(t, err, "archive exceeds the limit of 3 entries")
+
+		for _, name := range []string{"a.txt", "b.txt", filepath.Join("dir", "c.txt")} {
+			assert.NoFileExists(t, filepath.Join(targetDir, name))
+		}
+	})
+
+	t.Run("abort keeps the files that already existed", func(t *testing.T) {
+		tarXzPath := createTestTarXz(t, entries)
+		defer os.Remove(tarXzPath)
+
+		targetDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(targetDir, "a.txt"), []byte("on disk"), 0644))
+
+		err := UntarXzLimited(tarXzPath, targetDir, 0, 3)
+		assert.ErrorContains(t, err, "archive exceeds the limit of 3 entries")
+
+		assert.FileExists(t, filepath.Join(targetDir, "a.txt"))
+		assert.NoFileExists(t, filepath.Join(targetDir, "b.txt"))
+		assert.NoFileExists(t, filepath.Join(targetDir, "dir", "c.txt"))
+	})
+}
+
+func TestUntarXzWithPolicy(t *testing.T) {
+	setup := func(t *testing.T) (string, string) {
+		tarXzPath := createTestTarXz(t, map[string]testEntry{
+			"dir/":         {isDir: true, mode: 0755},
+			"existing.txt": {content: "from archive", mode: 0644},
+			"dir/new.txt":  {content: "new content", mode: 0644},
+		})
+		t.Cleanup(func() { os.Remove(tarXzPath) })
+
+		targetDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(targetDir, "dir"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(targetDir, "existing.txt"), []byte("on disk"), 0644))
+		return tarXzPath, targetDir
+	}
+
+	t.Run("overwrite always", func(t *testing.T) {
+		tarXzPath, targetDir := setup(t)
+
+		require.NoError(t, UntarXzWithPolicy(tarXzPath, targetDir, OverwriteAlways))
+		assertFileExists(t, filepath.Join(targetDir, "existing.txt"), "from archive")
+		assertFileExists(t, filepath.Join(targetDir, "dir", "new.txt"), "new content")
+	})
+
+	t.Run("skip existing", func(t *testing.T) {
+		tarXzPath, targetDir := setup(t)
+
+		require.NoError(t, UntarXzWithPolicy(tarXzPath, targetDir, SkipExisting))
+		assertFileExists(t, filepath.Join(targetDir, "existing.txt"), "on disk")
+		assertFileExists(t, filepath.Join(targetDir, "dir", "new.txt"), "new content")
+	})
+
+	t.Run("fail on conflict", func(t *testing.T) {
+		tarXzPath, targetDir := setup(t)
+
+		err := UntarXzWithPolicy(tarXzPath, targetDir, FailOnConflict)
+		assert.ErrorContains(t, err, "file already exists: "+filepath.Join(targetDir, "existing.txt"))
+		assertFileExists(t, filepath.Join(targetDir, "existing.txt"), "on disk")
+		assert.NoFileExists(t, filepath.Join(targetDir, "dir", "new.txt"))
+	})
+}
+
+func TestUntarXzWithOptions(t *testing.T) {
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := map[string]testEntry{
+		"existing.txt": {content: "from archive", mode: 0644, modTime: modTime},
+		"new.txt":      {content: "new content", mode: 0644, modTime: modTime},
+	}
+
+	t.Run("combines the limits, times and policy", func(t *testing.T) {
+		tarXzPath := createTestTarXz(t, entries)
+		defer os.Remove(tarXzPath)
+
+		targetDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(targetDir, "existing.txt"), []byte("on disk"), 0644))
+
+		err := UntarXzWithOptions(tarXzPath, targetDir, ExtractOptions{
+			MaxBytes:      100,
+			PreserveTimes: true,
+			Policy:        SkipExisting,
+		})
+		require.NoError(t, err)
+		assertFileExists(t, filepath.Join(targetDir, "existing.txt"), "on disk")
+		assertFileExists(t, filepath.Join(targetDir, "new.txt"), "new content")
+
+		info, err := os.Stat(filepath.Join(targetDir, "new.txt"))
+		require.NoError(t, err)
+		assert.True(t, info.ModTime().Equal(modTime))
+	})
+
+	t.Run("times aren't preserved unless asked", func(t *testing.T) {
+		tarXzPath := createTestTarXz(t, entries)
+		defer os.Remove(tarXzPath)
+
+		targetDir := t.TempDir()
+		require.NoError(t, UntarXzWithOptions(tarXzPath, targetDir, ExtractOptions{}))
+
+		info, err := os.Stat(filepath.Join(targetDir, "new.txt"))
+		require.NoError(t, err)
+		assert.False(t, info.ModTime().Equal(modTime))
+	})
+}
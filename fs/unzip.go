@@ -9,12 +9,19 @@ import (
 	"strings"
 )
 
+// ZIP "version made by" values of the systems that record Unix permissions in the archive
+const (
+	creatorUnix   = 3
+	creatorMacOSX = 19
+)
+
 // Unzip extracts all files and directories from a ZIP archive to a target directory. It creates the target directory if
 // it doesn't exist and preserves the directory structure from the archive, including symbolic links.
 //
 // The function implements security measures to prevent Zip Slip attacks by:
 //   - Rejecting absolute paths in archive entries
 //   - Preventing path traversal attacks using ".." segments
+//   - Rejecting names with NUL or other control characters, and Windows reserved device names on Windows
 //   - Normalizing path separators to handle both forward slashes and backslashes
 //   - Validating that extracted files remain within the target directory
 //   - Validating that symbolic link targets remain within the target directory
@@ -29,8 +36,98 @@ import (
 //   - Any archive entry contains an illegal path (absolute or traversal)
 //   - File extraction fails due to I/O errors or permission issues
 //
-// All extracted regular files are set to executable mode (0o755).
+// Extracted files and directories keep the permissions recorded in the archive. Archives created on systems without
+// Unix permissions (e.g. Windows) record no meaningful mode, so their files get 0o644 and their directories 0o755.
 func Unzip(zipPath, targetDirectory string) error {
+	return UnzipProgress(zipPath, targetDirectory, nil)
+}
+
+// UnzipProgress extracts a ZIP archive like Unzip, calling the callback after each entry is extracted. This is useful to
+// drive a progress bar while extracting large archives.
+//
+// # Parameters:
+//   - zipPath: Path to the ZIP file to extract
+//   - targetDirectory: Destination directory where files will be extracted
+//   - callback: Function that receives the name of the extracted entry, its 0-based index and the total number of
+//     entries in the archive; it can be nil
+//
+// It returns the same errors as Unzip.
+func UnzipProgress(zipPath, targetDirectory string, callback func(entry string, index, total int)) error {
+	return unzip(zipPath, targetDirectory, callback, &extraction{})
+}
+
+// UnzipLimited extracts a ZIP archive like Unzip, but refuses to extract more than maxBytes of uncompressed data or more
+// than maxEntries entries, protecting against decompression bombs when extracting untrusted archives.
+//
+// The sizes declared in the archive are checked before anything is extracted, and the limits are enforced again while
+// the entries are written, in case the declared sizes are forged. When a limit is exceeded mid-extraction, the files
+// extracted so far are removed.
+//
+// # Parameters:
+//   - zipPath: Path to the ZIP file to extract
+//   - targetDirectory: Destination directory where files will be extracted
+//   - maxBytes: Maximum total uncompressed size of the extracted files; 0 means unlimited
+//   - maxEntries: Maximum number of entries in the archive; 0 means unlimited
+//
+// It returns the same errors as Unzip, and an error if any of the limits is exceeded.
+func UnzipLimited(zipPath, targetDirectory string, maxBytes int64, maxEntries int) error {
+	return UnzipWithOptions(zipPath, targetDirectory, ExtractOptions{MaxBytes: maxBytes, MaxEntries: maxEntries})
+}
+
+// UnzipPreserveTimes extracts a ZIP archive like Unzip, and also sets the modification time of each extracted file and
+// directory to the one recorded in the archive, which makes the extraction reproducible for build caches and
+// content-addressed storage.
+//
+// # Parameters:
+//   - zipPath: Path to the ZIP file to extract
+//   - targetDirectory: Destination directory where files will be extracted
+//
+// It returns the same errors as Unzip, and an error if a modification time can't be set.
+func UnzipPreserveTimes(zipPath, targetDirectory string) error {
+	return UnzipWithOptions(zipPath, targetDirectory, ExtractOptions{PreserveTimes: true})
+}
+
+// UnzipWithPolicy extracts a ZIP archive like Unzip, resolving the entries that would replace existing files with the
+// given policy. This allows safely extracting into an existing directory, e.g. an install directory.
+//
+// # Parameters:
+//   - zipPath: Path to the ZIP file to extract
+//   - targetDirectory: Destination directory where files will be extracted
+//   - policy: OverwriteAlways to replace existing files (like Unzip), SkipExisting to leave them untouched, or
+//     FailOnConflict to return an error naming the first existing file
+//
+// With FailOnConflict, every entry is checked before anything is extracted, so a conflict leaves the target directory
+// untouched.
+//
+// It returns the same errors as Unzip, and an error on the first conflict when the policy is FailOnConflict.
+func UnzipWithPolicy(zipPath, targetDirectory string, policy ExtractPolicy) error {
+	return UnzipWithOptions(zipPath, targetDirectory, ExtractOptions{Policy: policy})
+}
+
+// UnzipWithOptions extracts a ZIP archive like Unzip, combining the limits of UnzipLimited, the modification times of
+// UnzipPreserveTimes and the conflict policy of UnzipWithPolicy.
+//
+// # Parameters:
+//   - zipPath: Path to the ZIP file to extract
+//   - targetDirectory: Destination directory where files will be extracted
+//   - opts: The limits, whether to preserve the modification times, and the conflict policy of the extraction
+//
+// It returns the same errors as Unzip, UnzipLimited, UnzipPreserveTimes and UnzipWithPolicy.
+//
+// # Example:
+//
+//	err := UnzipWithOptions("upload.zip", "/srv/app", ExtractOptions{
+//	    MaxBytes:   100 << 20,
+//	    MaxEntries: 1000,
+//	    Policy:     FailOnConflict,
+//	})
+func UnzipWithOptions(zipPath, targetDirectory string, opts ExtractOptions) error {
+	return unzip(zipPath, targetDirectory, nil, newExtraction(opts))
+}
+
+// region - Private functions
+
+func unzip(zipPath, targetDirectory string, callback func(entry string, index, total int), e *extraction) error {
 	// Open the zip file specified by zipPath
 	r, err := zip.OpenReader(zipPath)
 	if err != nil {
@@ -38,95 +135,174 @@ func Unzip(zipPath, targetDirectory string) error {
 	}
 	defer r.Close()
 
+	// Check the declared sizes up front, so an obvious bomb isn't even partially extracted
+	if e.maxEntries > 0 && len(r.File) > e.maxEntries {
+		return fmt.Errorf("archive exceeds the limit of %d entries", e.maxEntries)
+	}
+
+	if e.maxBytes > 0 {
+		var total uint64
+		for _, f := range r.File {
+			total += f.UncompressedSize64
+			if total > uint64(e.maxBytes) {
+				return fmt.Errorf("archive exceeds the limit of %d uncompressed bytes", e.maxBytes)
+			}
+		}
+	}
+
+	// Look for conflicts up front too, so a conflicting archive isn't even partially extracted
+	if e.policy == FailOnConflict {
+		for _, f := range r.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+
+			// Illegal paths are reported when the entry is extracted
+			fpath, pathErr := sanitizeArchivePath(f.Name, targetDirectory)
+			if pathErr != nil {
+				continue
+			}
+
+			if _, err = e.skip(fpath); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Ensure the destination directory exists
 	if err = os.MkdirAll(targetDirectory, 0o755); err != nil {
 		return err
 	}
 
 	// Iterate through each file in the zip archive
-	for _, f := range r.File {
-		fpath, err := sanitizeArchivePath(f.Name, targetDirectory)
-		if err != nil {
-			return err
+	for i, f := range r.File {
+		if err = unzipEntry(f, targetDirectory, e); err != nil {
+			return e.abort(err)
 		}
 
-		if f.FileInfo().IsDir() {
-			// Create a directory if it doesn't exist
-			if err = os.MkdirAll(fpath, f.Mode()); err != nil {
-				return err
-			}
-			continue
+		if callback != nil {
+			callback(f.Name, i, len(r.File))
 		}
+	}
 
-		// Check if this is a symbolic link
-		if f.Mode()&os.ModeSymlink != 0 {
-			// Ensure the parent directory exists
-			if err = os.MkdirAll(filepath.Dir(fpath), 0o755); err != nil {
-				return err
-			}
-
-			// Read the symlink target from the zip file
-			rc, fErr := f.Open()
-			if fErr != nil {
-				return fErr
-			}
+	return e.finish()
+}
 
-			linkTarget, fErr := io.ReadAll(rc)
-			rc.Close()
-			if fErr != nil {
-				return fErr
-			}
+// unzipEntry extracts a single entry of a ZIP archive to the target directory.
+func unzipEntry(f *zip.File, targetDirectory string, e *extraction) error {
+	if err := e.addEntry(); err != nil {
+		return err
+	}
 
-			linkTargetStr := string(linkTarget)
+	fpath, err := sanitizeArchivePath(f.Name, targetDirectory)
+	if err != nil {
+		return err
+	}
 
-			if err = sanitizeArchiveSymlink(fpath, linkTargetStr, targetDirectory); err != nil {
-				return fmt.Errorf("illegal symlink target: %s -> %s", f.Name, linkTargetStr)
-			}
+	mode := zipEntryMode(f)
 
-			// Create the symbolic link
-			if err = os.Symlink(linkTargetStr, fpath); err != nil {
-				return err
-			}
-			continue
+	if f.FileInfo().IsDir() {
+		// Create a directory if it doesn't exist
+		if err = os.MkdirAll(fpath, mode); err != nil {
+			return err
 		}
 
+		return e.setTimes(fpath, f.Modified, true)
+	}
+
+	if skip, skipErr := e.skip(fpath); skip || skipErr != nil {
+		return skipErr
+	}
+
+	// Check if this is a symbolic link
+	if f.Mode()&os.ModeSymlink != 0 {
 		// Ensure the parent directory exists
 		if err = os.MkdirAll(filepath.Dir(fpath), 0o755); err != nil {
 			return err
 		}
 
-		// Create the destination file
-		outFile, fErr := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
+		// Read the symlink target from the zip file
+		rc, fErr := f.Open()
 		if fErr != nil {
 			return fErr
 		}
 
-		// Make the file executable
-		if err = os.Chmod(fpath, 0o755); err != nil {
-			return err
-		}
-
-		// Open the file inside the zip archive
-		rc, fErr := f.Open()
+		linkTarget, fErr := io.ReadAll(rc)
+		rc.Close()
 		if fErr != nil {
-			outFile.Close()
 			return fErr
 		}
 
-		// Copy file contents from the zip archive to a destination file
-		_, err = io.Copy(outFile, rc)
-		outFile.Close()
-		rc.Close()
+		linkTargetStr := string(linkTarget)
+
+		if err = sanitizeArchiveSymlink(fpath, linkTargetStr, targetDirectory); err != nil {
+			return fmt.Errorf("illegal symlink target: %s -> %s", f.Name, linkTargetStr)
+		}
 
-		if err != nil {
+		// Create the symbolic link
+		existed := exists(fpath)
+		if err = os.Symlink(linkTargetStr, fpath); err != nil {
 			return err
 		}
+
+		e.track(fpath, existed)
+		return nil
+	}
+
+	// Ensure the parent directory exists
+	if err = os.MkdirAll(filepath.Dir(fpath), 0o755); err != nil {
+		return err
+	}
+
+	// Create the destination file
+	existed := exists(fpath)
+	outFile, fErr := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if fErr != nil {
+		return fErr
+	}
+	e.track(fpath, existed)
+
+	// Apply the exact mode, which isn't affected by the umask nor by the mode of a file being overwritten
+	if err = os.Chmod(fpath, mode); err != nil {
+		outFile.Close()
+		return err
 	}
 
-	return nil
+	// Open the file inside the zip archive
+	rc, fErr := f.Open()
+	if fErr != nil {
+		outFile.Close()
+		return fErr
+	}
+
+	// Copy file contents from the zip archive to a destination file
+	err = e.copy(outFile, rc)
+	outFile.Close()
+	rc.Close()
+
+	if err != nil {
+		return err
+	}
+
+	return e.setTimes(fpath, f.Modified, false)
 }
 
-// region - Private functions
+// zipEntryMode returns the permissions to extract a ZIP entry with. Only archives created on Unix-like systems record
+// real permissions; for the others, and for entries without any permission bits, it falls back to 0o644 for files and
+// 0o755 for directories.
+func zipEntryMode(f *zip.File) os.FileMode {
+	isDir := f.FileInfo().IsDir()
+	perm := f.Mode().Perm()
+
+	if creator := f.CreatorVersion >> 8; (creator != creatorUnix && creator != creatorMacOSX) || perm == 0 {
+		if isDir {
+			return 0o755
+		}
+		return 0o644
+	}
+
+	return perm
+}
 
 func isAbsolutePath(path string) bool {
 	if strings.HasPrefix(path, "/") || strings.HasPrefix(path, "\\") {
@@ -4,8 +4,10 @@ import (
 	"archive/zip"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -89,10 +91,10 @@ func TestUnzip(t *testing.T) {
 			require.NoError(t, err)
 			assert.Equal(t, expectedContent, string(content))
 
-			// Check file permissions (should be 0755 as set by the function)
+			// The archive records no Unix permissions, so files get the default mode
 			info, err := os.Stat(fullPath)
 			require.NoError(t, err)
-			assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+			assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
 		}
 
 		// Verify extracted directories
@@ -106,6 +108,39 @@ func TestUnzip(t *testing.T) {
 		assert.DirExists(t, filepath.Join(targetDir, "subdir", "nested"))
 	})
 
+	t.Run("PreservesUnixModes", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping permission test on Windows")
+		}
+
+		zipPath := filepath.Join(t.TempDir(), "modes.zip")
+		zipFile, err := os.Create(zipPath)
+		require.NoError(t, err)
+
+		zipWriter := zip.NewWriter(zipFile)
+		entries := map[string]os.FileMode{"secret.key": 0600, "script.sh": 0755, "private/": 0700 | os.ModeDir}
+		for name, mode := range entries {
+			header := &zip.FileHeader{Name: name}
+			header.SetMode(mode)
+			_, err = zipWriter.CreateHeader(header)
+			require.NoError(t, err)
+		}
+		require.NoError(t, zipWriter.Close())
+		require.NoError(t, zipFile.Close())
+
+		// An existing file doesn't keep its looser mode when it's overwritten
+		targetDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(targetDir, "secret.key"), []byte("old"), 0644))
+
+		require.NoError(t, Unzip(zipPath, targetDir))
+
+		for name, mode := range entries {
+			info, statErr := os.Stat(filepath.Join(targetDir, name))
+			require.NoError(t, statErr)
+			assert.Equal(t, mode.Perm(), info.Mode().Perm(), name)
+		}
+	})
+
 	t.Run("NonExistentZipFile", func(t *testing.T) {
 		targetDir, err := os.MkdirTemp("", "unzip_test*")
 		require.NoError(t, err)
@@ -173,6 +208,14 @@ func TestUnzip(t *testing.T) {
 				name:     "windows path traversal",
 				filename: "..\\..\\windows\\system32\\config\\sam",
 			},
+			{
+				name:     "NUL byte",
+				filename: "innocent.txt\x00.exe",
+			},
+			{
+				name:     "control character",
+				filename: "dir/line\nbreak.txt",
+			},
 		}
 
 		for _, tc := range testCases {
@@ -283,3 +326,218 @@ func TestUnzip(t *testing.T) {
 		}
 	})
 }
+
+func TestUnzipProgress(t *testing.T) {
+	t.Run("ReportsEveryEntry", func(t *testing.T) {
+		files := map[string]string{
+			"file1.txt":      "content1",
+			"dir1/file2.txt": "content2",
+		}
+		zipPath := createTestZip(t, files, []string{"dir1"})
+		defer os.Remove(zipPath)
+
+		targetDir := t.TempDir()
+
+		var entries []string
+		var indexes []int
+		err := UnzipProgress(zipPath, targetDir, func(entry string, index, total int) {
+			assert.Equal(t, 3, total)
+			_, statErr := os.Stat(filepath.Join(targetDir, filepath.FromSlash(entry)))
+			assert.NoError(t, statErr, "entry should be extracted before it's reported")
+			entries = append(entries, entry)
+			indexes = append(indexes, index)
+		})
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"dir1/", "file1.txt", "dir1/file2.txt"}, entries)
+		assert.Equal(t, []int{0, 1, 2}, indexes)
+	})
+
+	t.Run("StopsOnError", func(t *testing.T) {
+		zipPath := createMaliciousZip(t, "../escape.txt")
+		defer os.Remove(zipPath)
+
+		var calls int
+		err := UnzipProgress(zipPath, t.TempDir(), func(entry string, index, total int) {
+			calls++
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("NilCallback", func(t *testing.T) {
+		zipPath := createTestZip(t, map[string]string{"file.txt": "content"}, nil)
+		defer os.Remove(zipPath)
+
+		assert.NoError(t, UnzipProgress(zipPath, t.TempDir(), nil))
+	})
+}
+
+func TestUnzipLimited(t *testing.T) {
+	files := map[string]string{
+		"a.txt":     strings.Repeat("a", 100),
+		"b.txt":     strings.Repeat("b", 100),
+		"dir/c.txt": strings.Repeat("c", 100),
+	}
+
+	t.Run("within the limits", func(t *testing.T) {
+		zipPath := createTestZip(t, files, []string{"dir"})
+		defer os.Remove(zipPath)
+
+		targetDir := t.TempDir()
+		require.NoError(t, UnzipLimited(zipPath, targetDir, 300, 4))
+		assertFileExists(t, filepath.Join(targetDir, "dir", "c.txt"), strings.Repeat("c", 100))
+	})
+
+	t.Run("zero means unlimited", func(t *testing.T) {
+		zipPath := createTestZip(t, files, nil)
+		defer os.Remove(zipPath)
+
+		require.NoError(t, UnzipLimited(zipPath, t.TempDir(), 0, 0))
+	})
+
+	t.Run("too many bytes are rejected up front", func(t *testing.T) {
+		zipPath := createTestZip(t, files, nil)
+		defer os.Remove(zipPath)
+
+		targetDir := filepath.Join(t.TempDir(), "target")
+		err := UnzipLimited(zipPath, targetDir, 299, 0)
+		assert.ErrorContains(t, err, "archive exceeds the limit of 299 uncompressed bytes")
+		assert.NoDirExists(t, targetDir)
+	})
+
+	t.Run("too many entries are rejected up front", func(t *testing.T) {
+		zipPath := createTestZip(t, files, []string{"dir"})
+		defer os.Remove(zipPath)
+
+		targetDir := filepath.Join(t.TempDir(), "target")
+		err := UnzipLimited(zipPath, targetDir, 0, 3)
+		assert.ErrorContains(t, err, "archive exceeds the limit of 3 entries")
+		assert.NoDirExists(t, targetDir)
+	})
+}
+
+func TestUnzipPreserveTimes(t *testing.T) {
+	fileTime := time.Date(2020, 1, 2, 3, 4, 6, 0, time.UTC)
+	dirTime := time.Date(2019, 6, 7, 8, 9, 10, 0, time.UTC)
+
+	createZip := func(t *testing.T) string {
+		zipPath := filepath.Join(t.TempDir(), "times.zip")
+		zipFile, err := os.Create(zipPath)
+		require.NoError(t, err)
+
+		zipWriter := zip.NewWriter(zipFile)
+
+		dirHeader := &zip.FileHeader{Name: "dir/", Modified: dirTime}
+		dirHeader.SetMode(0755 | os.ModeDir)
+		_, err = zipWriter.CreateHeader(dirHeader)
+		require.NoError(t, err)
+
+		writer, err := zipWriter.CreateHeader(&zip.FileHeader{Name: "dir/file.txt", Method: zip.Deflate, Modified: fileTime})
+		require.NoError(t, err)
+		_, err = writer.Write([]byte("content"))
+		require.NoError(t, err)
+
+		require.NoError(t, zipWriter.Close())
+		require.NoError(t, zipFile.Close())
+		return zipPath
+	}
+
+	t.Run("sets the archive times", func(t *testing.T) {
+		targetDir := t.TempDir()
+		require.NoError(t, UnzipPreserveTimes(createZip(t), targetDir))
+
+		info, err := os.Stat(filepath.Join(targetDir, "dir", "file.txt"))
+		require.NoError(t, err)
+		assert.True(t, fileTime.Equal(info.ModTime()), info.ModTime())
+
+		info, err = os.Stat(filepath.Join(targetDir, "dir"))
+		require.NoError(t, err)
+		assert.True(t, dirTime.Equal(info.ModTime()), info.ModTime())
+	})
+
+	t.Run("Unzip doesn't preserve times", func(t *testing.T) {
+		targetDir := t.TempDir()
+		require.NoError(t, Unzip(createZip(t), targetDir))
+
+		info, err := os.Stat(filepath.Join(targetDir, "dir", "file.txt"))
+		require.NoError(t, err)
+		assert.False(t, fileTime.Equal(info.ModTime()))
+	})
+}
+
+func TestUnzipWithPolicy(t *testing.T) {
+	files := map[string]string{"existing.txt": "from archive", "dir/new.txt": "new content"}
+
+	setup := func(t *testing.T) (string, string) {
+		zipPath := createTestZip(t, files, []string{"dir"})
+		t.Cleanup(func() { os.Remove(zipPath) })
+
+		targetDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(targetDir, "dir"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(targetDir, "existing.txt"), []byte("on disk"), 0644))
+		return zipPath, targetDir
+	}
+
+	t.Run("overwrite always", func(t *testing.T) {
+		zipPath, targetDir := setup(t)
+
+		require.NoError(t, UnzipWithPolicy(zipPath, targetDir, OverwriteAlways))
+		assertFileExists(t, filepath.Join(targetDir, "existing.txt"), "from archive")
+		assertFileExists(t, filepath.Join(targetDir, "dir", "new.txt"), "new content")
+	})
+
+	t.Run("skip existing", func(t *testing.T) {
+		zipPath, targetDir := setup(t)
+
+		require.NoError(t, UnzipWithPolicy(zipPath, targetDir, SkipExisting))
+		assertFileExists(t, filepath.Join(targetDir, "existing.txt"), "on disk")
+		assertFileExists(t, filepath.Join(targetDir, "dir", "new.txt"), "new content")
+	})
+
+	t.Run("fail on conflict", func(t *testing.T) {
+		zipPath, targetDir := setup(t)
+
+		err := UnzipWithPolicy(zipPath, targetDir, FailOnConflict)
+		assert.ErrorContains(t, err, "file already exists: "+filepath.Join(targetDir, "existing.txt"))
+		assertFileExists(t, filepath.Join(targetDir, "existing.txt"), "on disk")
+		assert.NoFileExists(t, filepath.Join(targetDir, "dir", "new.txt"))
+	})
+
+	t.Run("existing directories are merged", func(t *testing.T) {
+		zipPath := createTestZip(t, map[string]string{"dir/new.txt": "new content"}, []string{"dir"})
+		defer os.Remove(zipPath)
+
+		targetDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(targetDir, "dir"), 0755))
+
+		require.NoError(t, UnzipWithPolicy(zipPath, targetDir, FailOnConflict))
+		assertFileExists(t, filepath.Join(targetDir, "dir", "new.txt"), "new content")
+	})
+}
+
+func TestUnzipWithOptions(t *testing.T) {
+	files := map[string]string{"existing.txt": "from archive", "dir/new.txt": "new content"}
+
+	t.Run("combines the limits and the policy", func(t *testing.T) {
+		zipPath := createTestZip(t, files, []string{"dir"})
+		defer os.Remove(zipPath)
+
+		targetDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(targetDir, "existing.txt"), []byte("on disk"), 0644))
+
+		err := UnzipWithOptions(zipPath, targetDir, ExtractOptions{MaxEntries: 3, Policy: SkipExisting})
+		require.NoError(t, err)
+		assertFileExists(t, filepath.Join(targetDir, "existing.txt"), "on disk")
+		assertFileExists(t, filepath.Join(targetDir, "dir", "new.txt"), "new content")
+	})
+
+	t.Run("limits still apply", func(t *testing.T) {
+		zipPath := createTestZip(t, files, []string{"dir"})
+		defer os.Remove(zipPath)
+
+		err := UnzipWithOptions(zipPath, t.TempDir(), ExtractOptions{MaxEntries: 2, Policy: SkipExisting})
+		assert.ErrorContains(t, err, "archive exceeds the limit of 2 entries")
+	})
+}
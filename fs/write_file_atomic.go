@@ -0,0 +1,101 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// AtomicFile is a file whose content only replaces its destination when it's closed. The writes go to a temporary file
+// in the same directory, which is renamed over the destination by Close, so readers never see a partial file.
+//
+// The embedded *os.File is the temporary file, so Name returns its path instead of the destination's.
+type AtomicFile struct {
+	*os.File
+	path string
+	perm os.FileMode
+	done bool
+}
+
+// WriteFileAtomic writes data to a file like os.WriteFile, but atomically: the data is written to a temporary file in
+// the same directory, flushed to disk, and renamed into place. Readers see either the old or the new content, never a
+// partial file, even if the process crashes mid-write.
+//
+// # Parameters:
+//   - path: The path of the file to write
+//   - data: The content of the file
+//   - perm: The permissions of the file
+//
+// # Returns an error if:
+//   - The temporary file can't be created in the file's directory
+//   - Writing, flushing or renaming the temporary file fails; the temporary file is removed and the original file is
+//     left untouched
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	file, err := CreateAtomic(path, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err = file.Write(data); err != nil {
+		file.Abort()
+		return err
+	}
+
+	return file.Close()
+}
+
+// CreateAtomic creates an AtomicFile that replaces the file at path with the given permissions when it's closed. Until
+// then, the file at path is left untouched.
+//
+// # Parameters:
+//   - path: The path of the destination file
+//   - perm: The permissions of the destination file
+//
+// Returns the AtomicFile, or an error if the temporary file can't be created in the destination's directory.
+func CreateAtomic(path string, perm os.FileMode) (*AtomicFile, error) {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &AtomicFile{File: file, path: path, perm: perm}, nil
+}
+
+// Close flushes the content to disk and atomically replaces the destination file with it. If anything fails, the
+// temporary file is removed and the destination is left untouched. Calling Close or Abort again does nothing.
+func (f *AtomicFile) Close() error {
+	if f.done {
+		return nil
+	}
+	f.done = true
+
+	err := f.File.Chmod(f.perm)
+	if err == nil {
+		err = f.File.Sync()
+	}
+	if closeErr := f.File.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(f.File.Name(), f.path)
+	}
+
+	if err != nil {
+		os.Remove(f.File.Name())
+		return err
+	}
+
+	return nil
+}
+
+// Abort discards the content written so far, removing the temporary file and leaving the destination untouched.
+// Calling Close or Abort again does nothing.
+func (f *AtomicFile) Abort() error {
+	if f.done {
+		return nil
+	}
+	f.done = true
+
+	f.File.Close()
+	return os.Remove(f.File.Name())
+}
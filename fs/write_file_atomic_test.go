@@ -0,0 +1,90 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Run("creates a new file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+
+		require.NoError(t, WriteFileAtomic(path, []byte(`{"a": 1}`), 0o644))
+		assertFileExists(t, path, `{"a": 1}`)
+	})
+
+	t.Run("replaces an existing file without leftovers", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.json")
+		require.NoError(t, os.WriteFile(path, []byte("old content that is longer"), 0o644))
+
+		require.NoError(t, WriteFileAtomic(path, []byte("new"), 0o644))
+		assertFileExists(t, path, "new")
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("applies the permissions", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping permission test on Windows")
+		}
+
+		path := filepath.Join(t.TempDir(), "secret.key")
+		require.NoError(t, WriteFileAtomic(path, []byte("secret"), 0o600))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("missing directory returns error", func(t *testing.T) {
+		err := WriteFileAtomic(filepath.Join(t.TempDir(), "missing", "config.json"), []byte("data"), 0o644)
+		assert.Error(t, err)
+	})
+}
+
+func TestAtomicFile(t *testing.T) {
+	t.Run("destination is untouched until Close", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(path, []byte("old"), 0o644))
+
+		file, err := CreateAtomic(path, 0o644)
+		require.NoError(t, err)
+
+		_, err = file.WriteString("new")
+		require.NoError(t, err)
+		assertFileExists(t, path, "old")
+
+		require.NoError(t, file.Close())
+		assertFileExists(t, path, "new")
+
+		// Closing again is a no-op
+		assert.NoError(t, file.Close())
+	})
+
+	t.Run("Abort discards the content", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.json")
+		require.NoError(t, os.WriteFile(path, []byte("old"), 0o644))
+
+		file, err := CreateAtomic(path, 0o644)
+		require.NoError(t, err)
+
+		_, err = file.WriteString("partial")
+		require.NoError(t, err)
+		require.NoError(t, file.Abort())
+		assert.NoError(t, file.Close())
+
+		assertFileExists(t, path, "old")
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+}
@@ -0,0 +1,176 @@
+package fs
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type ZipFlags uint8
+
+const (
+	ZpStore ZipFlags = 1 << iota
+)
+
+// Zip creates a ZIP archive with all files and directories inside a source directory. Entries are stored with their
+// paths relative to the source directory, so the archive can be extracted with Unzip to reproduce the same tree.
+//
+// The flags parameter controls the archive behavior:
+//   - ZpStore: Store files without compression
+//   - 0 (no flags): Compress files with deflate (default behavior)
+//
+// The function mirrors the security measures of Unzip by:
+//   - Never writing absolute paths or ".." segments in entry names
+//   - Rejecting symbolic links whose targets are absolute or point outside the source directory
+//
+// Empty directories are written as directory entries, and symbolic links are stored as links rather than followed. If
+// the ZIP file is created inside the source directory, it is not added to itself.
+//
+// # Parameters:
+//   - sourceDir: Directory whose contents will be archived
+//   - zipPath: Path of the ZIP file to create
+//   - flags: Options that control the archive behavior
+//
+// # Returns an error if:
+//   - The source directory cannot be read
+//   - The ZIP file cannot be created or written
+//   - Any symbolic link has an illegal target (absolute or outside the source directory)
+//
+// A partially written ZIP file is removed when archiving fails.
+func Zip(sourceDir, zipPath string, flags ZipFlags) (err error) {
+	method := zip.Deflate
+	if flags&ZpStore != 0 {
+		method = zip.Store
+	}
+
+	info, err := os.Stat(sourceDir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", sourceDir)
+	}
+
+	// Resolve the archive path so it can be skipped if it's inside the source directory
+	absZipPath, err := filepath.Abs(zipPath)
+	if err != nil {
+		return err
+	}
+
+	zipFile, err := os.Create(zipPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		zipFile.Close()
+		if err != nil {
+			os.Remove(zipPath)
+		}
+	}()
+
+	zipWriter := zip.NewWriter(zipFile)
+
+	err = filepath.WalkDir(sourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if absPath, absErr := filepath.Abs(path); absErr == nil && absPath == absZipPath {
+			return nil
+		}
+
+		rel, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+
+		// Skip the source directory itself
+		if rel == "." {
+			return nil
+		}
+
+		return addZipEntry(zipWriter, sourceDir, path, filepath.ToSlash(rel), method)
+	})
+
+	if err != nil {
+		zipWriter.Close()
+		return err
+	}
+
+	if err = zipWriter.Close(); err != nil {
+		return err
+	}
+
+	return zipFile.Close()
+}
+
+// region - Private functions
+
+func addZipEntry(zipWriter *zip.Writer, sourceDir, path, name string, method uint16) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+
+	header.Name = name
+
+	switch {
+	case info.IsDir():
+		header.Name += "/"
+		header.Method = zip.Store
+
+		_, err = zipWriter.CreateHeader(header)
+		return err
+
+	case info.Mode()&os.ModeSymlink != 0:
+		linkTarget, lErr := os.Readlink(path)
+		if lErr != nil {
+			return lErr
+		}
+
+		if err = sanitizeArchiveSymlink(path, linkTarget, sourceDir); err != nil {
+			return fmt.Errorf("illegal symlink target: %s -> %s", name, linkTarget)
+		}
+
+		header.Method = zip.Store
+
+		writer, wErr := zipWriter.CreateHeader(header)
+		if wErr != nil {
+			return wErr
+		}
+
+		_, err = writer.Write([]byte(linkTarget))
+		return err
+
+	case info.Mode().IsRegular():
+		header.Method = method
+
+		writer, wErr := zipWriter.CreateHeader(header)
+		if wErr != nil {
+			return wErr
+		}
+
+		file, fErr := os.Open(path)
+		if fErr != nil {
+			return fErr
+		}
+		defer file.Close()
+
+		_, err = io.Copy(writer, file)
+		return err
+
+	default:
+		// Skip other types (devices, FIFOs, sockets, etc.)
+		return nil
+	}
+}
+
+// endregion
@@ -0,0 +1,202 @@
+package fs
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestTree(t *testing.T) string {
+	sourceDir := filepath.Join(t.TempDir(), "source")
+
+	files := map[string]string{
+		"root.txt":               "root content",
+		"dir1/file1.txt":         "file 1 content",
+		"dir1/dir2/file2.txt":    strings.Repeat("compressible ", 1000),
+		"dir3/nested/binary.bin": "\x00\x01\x02\x03",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(sourceDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(sourceDir, "empty"), 0755))
+
+	return sourceDir
+}
+
+func readTree(t *testing.T, root string) map[string]string {
+	tree := make(map[string]string)
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		require.NoError(t, err)
+
+		rel, _ := filepath.Rel(root, path)
+		rel = filepath.ToSlash(rel)
+
+		switch {
+		case rel == ".":
+		case info.Mode()&os.ModeSymlink != 0:
+			target, lErr := os.Readlink(path)
+			require.NoError(t, lErr)
+			tree[rel] = "-> " + target
+		case info.IsDir():
+			tree[rel+"/"] = ""
+		default:
+			content, rErr := os.ReadFile(path)
+			require.NoError(t, rErr)
+			tree[rel] = string(content)
+		}
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	return tree
+}
+
+func TestZip(t *testing.T) {
+	t.Run("round trip with Unzip", func(t *testing.T) {
+		sourceDir := createTestTree(t)
+		zipPath := filepath.Join(t.TempDir(), "archive.zip")
+
+		require.NoError(t, Zip(sourceDir, zipPath, 0))
+
+		targetDir := filepath.Join(t.TempDir(), "target")
+		require.NoError(t, Unzip(zipPath, targetDir))
+
+		assert.Equal(t, readTree(t, sourceDir), readTree(t, targetDir))
+		assert.DirExists(t, filepath.Join(targetDir, "empty"))
+	})
+
+	t.Run("deflate and store methods", func(t *testing.T) {
+		sourceDir := createTestTree(t)
+		tempDir := t.TempDir()
+
+		deflatePath := filepath.Join(tempDir, "deflate.zip")
+		storePath := filepath.Join(tempDir, "store.zip")
+		require.NoError(t, Zip(sourceDir, deflatePath, 0))
+		require.NoError(t, Zip(sourceDir, storePath, ZpStore))
+
+		for path, method := range map[string]uint16{deflatePath: zip.Deflate, storePath: zip.Store} {
+			r, err := zip.OpenReader(path)
+			require.NoError(t, err)
+
+			for _, f := range r.File {
+				if !f.FileInfo().IsDir() {
+					assert.Equal(t, method, f.Method, f.Name)
+				}
+			}
+
+			r.Close()
+		}
+
+		deflateInfo, err := os.Stat(deflatePath)
+		require.NoError(t, err)
+		storeInfo, err := os.Stat(storePath)
+		require.NoError(t, err)
+		assert.Less(t, deflateInfo.Size(), storeInfo.Size())
+	})
+
+	t.Run("entry names are relative", func(t *testing.T) {
+		sourceDir := createTestTree(t)
+		zipPath := filepath.Join(t.TempDir(), "archive.zip")
+
+		require.NoError(t, Zip(sourceDir, zipPath, 0))
+
+		r, err := zip.OpenReader(zipPath)
+		require.NoError(t, err)
+		defer r.Close()
+
+		names := make([]string, 0, len(r.File))
+		for _, f := range r.File {
+			assert.False(t, isAbsolutePath(f.Name), f.Name)
+			assert.NotContains(t, f.Name, "..")
+			assert.NotContains(t, f.Name, "\\")
+			names = append(names, f.Name)
+		}
+
+		assert.Contains(t, names, "empty/")
+		assert.Contains(t, names, "dir1/dir2/file2.txt")
+	})
+
+	t.Run("symlinks are preserved", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping symlink test on Windows")
+		}
+
+		sourceDir := createTestTree(t)
+		require.NoError(t, os.Symlink("dir1/file1.txt", filepath.Join(sourceDir, "link.txt")))
+		zipPath := filepath.Join(t.TempDir(), "archive.zip")
+
+		require.NoError(t, Zip(sourceDir, zipPath, 0))
+
+		targetDir := filepath.Join(t.TempDir(), "target")
+		require.NoError(t, Unzip(zipPath, targetDir))
+
+		assert.Equal(t, readTree(t, sourceDir), readTree(t, targetDir))
+	})
+
+	t.Run("symlink outside the source directory", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Skipping symlink test on Windows")
+		}
+
+		sourceDir := createTestTree(t)
+		require.NoError(t, os.Symlink("../outside.txt", filepath.Join(sourceDir, "link.txt")))
+
+		archivePath := filepath.Join(t.TempDir(), "archive.zip")
+		err := Zip(sourceDir, archivePath, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "illegal symlink target")
+		assert.NoFileExists(t, archivePath)
+	})
+
+	t.Run("archive inside the source directory", func(t *testing.T) {
+		sourceDir := createTestTree(t)
+		zipPath := filepath.Join(sourceDir, "archive.zip")
+
+		require.NoError(t, Zip(sourceDir, zipPath, 0))
+
+		r, err := zip.OpenReader(zipPath)
+		require.NoError(t, err)
+		defer r.Close()
+
+		for _, f := range r.File {
+			assert.NotEqual(t, "archive.zip", f.Name)
+		}
+	})
+
+	t.Run("empty source directory", func(t *testing.T) {
+		sourceDir := t.TempDir()
+		zipPath := filepath.Join(t.TempDir(), "empty.zip")
+
+		require.NoError(t, Zip(sourceDir, zipPath, 0))
+
+		r, err := zip.OpenReader(zipPath)
+		require.NoError(t, err)
+		defer r.Close()
+		assert.Empty(t, r.File)
+	})
+
+	t.Run("source is not a directory", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file.txt")
+		require.NoError(t, os.WriteFile(file, []byte("content"), 0644))
+
+		err := Zip(file, filepath.Join(t.TempDir(), "archive.zip"), 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("source does not exist", func(t *testing.T) {
+		err := Zip(filepath.Join(t.TempDir(), "missing"), filepath.Join(t.TempDir(), "archive.zip"), 0)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,55 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetToken(t *testing.T) {
+	var mu sync.Mutex
+	var authorization string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		authorization = r.Header.Get("Authorization")
+		mu.Unlock()
+		writeJSON(t, w, map[string]any{"tag_name": "v1.0.0"})
+	})
+
+	t.Run("authenticates with the token", func(t *testing.T) {
+		useMockServer(t, mux)
+		SetToken("ghp_secret")
+		t.Cleanup(func() { SetToken("") })
+
+		_, err := GetLatestRelease(context.Background(), "owner", "repo")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer ghp_secret", authorization)
+	})
+
+	t.Run("unauthenticated without a token", func(t *testing.T) {
+		useMockServer(t, mux)
+
+		_, err := GetLatestRelease(context.Background(), "owner", "repo")
+
+		require.NoError(t, err)
+		assert.Empty(t, authorization)
+	})
+
+	t.Run("empty token stops authenticating", func(t *testing.T) {
+		useMockServer(t, mux)
+		SetToken("ghp_secret")
+		SetToken("")
+
+		_, err := GetLatestRelease(context.Background(), "owner", "repo")
+
+		require.NoError(t, err)
+		assert.Empty(t, authorization)
+	})
+}
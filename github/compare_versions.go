@@ -0,0 +1,44 @@
+package github
+
+import (
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// CompareVersions compares two semantic versions, with or without the 'v' prefix, using golang.org/x/mod/semver.
+//
+// # Parameters:
+//   - a: The first version (e.g., "1.2.0" or "v1.2.0")
+//   - b: The second version
+//
+// # Returns:
+//   - -1 if a is older than b, 0 if they are equal, or +1 if a is newer than b
+//   - 0 if either version isn't valid semver, since they can't be ordered
+//
+// # Example:
+//
+//	if CompareVersions("1.2.0", "v1.10.0") < 0 {
+//	    fmt.Println("1.2.0 is older")
+//	}
+func CompareVersions(a, b string) int {
+	a, b = normalizeVersion(a), normalizeVersion(b)
+	if !semver.IsValid(a) || !semver.IsValid(b) {
+		return 0
+	}
+
+	return semver.Compare(a, b)
+}
+
+// region - Private functions
+
+// normalizeVersion adds the 'v' prefix that semver requires, when it's missing.
+func normalizeVersion(version string) string {
+	if strings.HasPrefix(version, "v") {
+		return version
+	}
+
+	return "v" + version
+}
+
+// endregion
@@ -0,0 +1,32 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected int
+	}{
+		{"older", "1.2.0", "1.3.0", -1},
+		{"newer", "2.0.0", "1.9.9", 1},
+		{"equal", "1.2.3", "1.2.3", 0},
+		{"numeric not lexical", "v1.2.0", "v1.10.0", -1},
+		{"mixed v prefix", "v1.2.3", "1.2.3", 0},
+		{"prerelease is older than release", "1.0.0-rc.1", "1.0.0", -1},
+		{"short version", "1.2", "1.2.0", 0},
+		{"invalid first", "invalid-version", "1.0.0", 0},
+		{"invalid second", "1.0.0", "", 0},
+		{"both invalid", "abc", "xyz", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CompareVersions(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/vegidio/go-sak/fetch"
+)
+
+// DownloadAsset downloads the first asset of a release whose name matches a glob pattern. The download is done by the
+// fetch package, so it's retried on transient failures and resumed when interrupted.
+//
+// # Parameters:
+//   - ctx: Context for cancellation and timeouts, for both the release lookup and the download
+//   - owner: The GitHub username or organization name that owns the repository
+//   - repo: The name of the repository
+//   - tag: The tag name of the release (e.g., "v1.0.0")
+//   - pattern: The glob pattern the asset name must match, with the syntax of path.Match (e.g., "*-linux-amd64.tar.xz")
+//   - destDir: The directory where the asset is saved, with its original name
+//
+// # Returns:
+//   - string: The path to the downloaded file
+//   - error: An error if the release is not found, the pattern is invalid, no asset matches it, or the download fails
+//
+// When a token is set with SetToken, the asset is downloaded through the API, so assets of private repositories work
+// too.
+//
+// # Example:
+//
+//	filePath, err := DownloadAsset(ctx, "owner", "repo", "v1.2.3", "*-linux-amd64.tar.xz", os.TempDir())
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func DownloadAsset(ctx context.Context, owner, repo, tag, pattern, destDir string) (string, error) {
+	release, err := GetReleaseByName(ctx, owner, repo, tag)
+	if err != nil {
+		return "", err
+	}
+
+	asset, err := matchAsset(release.Assets, pattern)
+	if err != nil {
+		return "", err
+	}
+	if asset == nil {
+		return "", fmt.Errorf("no asset matches %s in release %s", pattern, tag)
+	}
+
+	url, headers := assetURL(asset)
+	filePath := filepath.Join(destDir, asset.GetName())
+
+	f := fetch.New(nil, 3, false)
+	f.SetRetryStatuses([]int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout})
+
+	request, err := f.NewRequest(url, filePath, headers)
+	if err != nil {
+		return "", err
+	}
+
+	response := f.DownloadFile(request)
+
+	select {
+	case <-response.Done:
+	case <-ctx.Done():
+		response.Cancel()
+		<-response.Done
+		return "", fmt.Errorf("failed to download asset %s: %w", asset.GetName(), ctx.Err())
+	}
+
+	if err = response.Error(); err != nil {
+		return "", fmt.Errorf("failed to download asset %s: %w", asset.GetName(), err)
+	}
+
+	// fetch doesn't treat 404 and 410 as errors, so the error page must not be mistaken for the asset
+	if response.StatusCode >= http.StatusBadRequest {
+		os.Remove(filePath)
+		return "", fmt.Errorf("failed to download asset %s: unexpected status: %d", asset.GetName(), response.StatusCode)
+	}
+
+	return filePath, nil
+}
+
+// region - Private functions
+
+func matchAsset(assets []*github.ReleaseAsset, pattern string) (*github.ReleaseAsset, error) {
+	for _, asset := range assets {
+		matched, err := path.Match(pattern, asset.GetName())
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset pattern %s: %w", pattern, err)
+		}
+		if matched {
+			return asset, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func assetURL(asset *github.ReleaseAsset) (string, map[string]string) {
+	tokenMu.RLock()
+	defer tokenMu.RUnlock()
+
+	if token == "" {
+		return asset.GetBrowserDownloadURL(), nil
+	}
+
+	// The browser URL doesn't accept tokens, so authenticated downloads go through the API
+	return asset.GetURL(), map[string]string{
+		"Accept":        "application/octet-stream",
+		"Authorization": "Bearer " + token,
+	}
+}
+
+// endregion
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadAsset(t *testing.T) {
+	handler := func(t *testing.T) http.Handler {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/owner/repo/releases/tags/v1.2.3", func(w http.ResponseWriter, r *http.Request) {
+			base := "http://" + r.Host
+			writeJSON(t, w, map[string]any{
+				"tag_name": "v1.2.3",
+				"assets": []map[string]any{
+					{"name": "app-darwin-arm64.tar.xz", "browser_download_url": base + "/download/darwin",
+						"url": base + "/repos/owner/repo/releases/assets/1"},
+					{"name": "app-linux-amd64.tar.xz", "browser_download_url": base + "/download/linux",
+						"url": base + "/repos/owner/repo/releases/assets/2"},
+					{"name": "app-linux-amd64.tar.xz.sha256", "browser_download_url": base + "/download/sha256",
+						"url": base + "/repos/owner/repo/releases/assets/3"},
+				},
+			})
+		})
+		mux.HandleFunc("/download/linux", func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("Authorization"))
+			w.Write([]byte("linux binary"))
+		})
+		mux.HandleFunc("/repos/owner/repo/releases/assets/2", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/octet-stream", r.Header.Get("Accept"))
+			assert.Equal(t, "Bearer ghp_secret", r.Header.Get("Authorization"))
+			w.Write([]byte("private linux binary"))
+		})
+		return mux
+	}
+
+	t.Run("downloads the first matching asset", func(t *testing.T) {
+		useMockServer(t, handler(t))
+		destDir := t.TempDir()
+
+		filePath, err := DownloadAsset(context.Background(), "owner", "repo", "v1.2.3", "*-linux-amd64.tar.xz", destDir)
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(destDir, "app-linux-amd64.tar.xz"), filePath)
+		content, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, "linux binary", string(content))
+	})
+
+	t.Run("downloads through the API when a token is set", func(t *testing.T) {
+		useMockServer(t, handler(t))
+		SetToken("ghp_secret")
+		t.Cleanup(func() { SetToken("") })
+
+		filePath, err := DownloadAsset(context.Background(), "owner", "repo", "v1.2.3", "*-linux-amd64.tar.xz",
+			t.TempDir())
+
+		require.NoError(t, err)
+		content, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, "private linux binary", string(content))
+	})
+
+	t.Run("no asset matches", func(t *testing.T) {
+		useMockServer(t, handler(t))
+
+		filePath, err := DownloadAsset(context.Background(), "owner", "repo", "v1.2.3", "*-windows-amd64.zip",
+			t.TempDir())
+
+		assert.Empty(t, filePath)
+		assert.EqualError(t, err, "no asset matches *-windows-amd64.zip in release v1.2.3")
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		useMockServer(t, handler(t))
+
+		_, err := DownloadAsset(context.Background(), "owner", "repo", "v1.2.3", "[", t.TempDir())
+
+		assert.ErrorContains(t, err, "invalid asset pattern")
+	})
+
+	t.Run("release not found", func(t *testing.T) {
+		useMockServer(t, handler(t))
+
+		_, err := DownloadAsset(context.Background(), "owner", "repo", "v9.9.9", "*", t.TempDir())
+
+		assert.Error(t, err)
+	})
+
+	t.Run("asset download fails", func(t *testing.T) {
+		useMockServer(t, handler(t))
+
+		destDir := t.TempDir()
+
+		_, err := DownloadAsset(context.Background(), "owner", "repo", "v1.2.3", "*.sha256", destDir)
+
+		assert.ErrorContains(t, err, "failed to download asset app-linux-amd64.tar.xz.sha256")
+		assert.NoFileExists(t, filepath.Join(destDir, "app-linux-amd64.tar.xz.sha256"))
+	})
+}
@@ -10,13 +10,14 @@ import (
 // owner and repository name as parameters and returns the latest release information or an error if the request fails.
 //
 // # Parameters:
-//   - ctx: Context for cancellation and timeouts
+//   - ctx: Context for cancellation and timeouts; when it has no deadline, the request times out after 10 seconds
 //   - owner: The GitHub username or organization name that owns the repository
 //   - repo: The name of the repository
 //
 // # Returns:
 //   - *github.RepositoryRelease: The latest release information including tag name, body, assets, etc.
-//   - error: An error if the API request fails or if no releases are found
+//   - error: An error if the API request fails or if no releases are found; on timeout, it wraps
+//     context.DeadlineExceeded
 //
 // # Example:
 //
@@ -26,11 +27,14 @@ import (
 //	}
 //	fmt.Printf("Latest release: %s\n", release.GetTagName())
 func GetLatestRelease(ctx context.Context, owner, repo string) (*github.RepositoryRelease, error) {
-	client := github.NewClient(nil)
+	ctx, cancel := withDefaultTimeout(ctx)
+	defer cancel()
+
+	client := apiClient()
 
 	release, _, err := client.Repositories.GetLatestRelease(ctx, owner, repo)
 	if err != nil {
-		return nil, err
+		return nil, wrapTimeout(err)
 	}
 
 	return release, nil
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v74/github"
 	"github.com/stretchr/testify/assert"
@@ -238,6 +239,47 @@ func TestGetLatestRelease(t *testing.T) {
 		assert.False(t, release.GetPrerelease()) // Should return false for nil
 		assert.Empty(t, release.GetHTMLURL())    // Should return empty string for nil
 	})
+
+	t.Run("default timeout when the context has no deadline", func(t *testing.T) {
+		useMockServer(t, slowHandler(time.Second))
+		withDefaultTimeoutOf(t, 50*time.Millisecond)
+
+		release, err := GetLatestRelease(context.Background(), "owner", "repo")
+
+		assert.Nil(t, release)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "GitHub request timed out")
+	})
+
+	t.Run("the caller's deadline takes precedence", func(t *testing.T) {
+		useMockServer(t, slowHandler(100*time.Millisecond))
+		withDefaultTimeoutOf(t, 50*time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		release, err := GetLatestRelease(ctx, "owner", "repo")
+
+		require.NoError(t, err)
+		assert.Equal(t, "v1.0.0", release.GetTagName())
+	})
+}
+
+// slowHandler answers the latest release endpoint after a delay.
+func slowHandler(delay time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"tag_name": "v1.0.0", "name": "v1.0.0"}`))
+		case <-r.Context().Done():
+		}
+	})
+}
+
+func withDefaultTimeoutOf(t *testing.T, timeout time.Duration) {
+	previous := defaultTimeout
+	defaultTimeout = timeout
+	t.Cleanup(func() { defaultTimeout = previous })
 }
 
 func BenchmarkGetLatestRelease(b *testing.B) {
@@ -27,7 +27,7 @@ import (
 //	}
 //	fmt.Printf("Release: %s\n", release.GetTagName())
 func GetReleaseByName(ctx context.Context, owner, repo, tagName string) (*github.RepositoryRelease, error) {
-	client := github.NewClient(nil)
+	client := apiClient()
 
 	release, _, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, tagName)
 	if err != nil {
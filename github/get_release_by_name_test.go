@@ -2,9 +2,12 @@ package github
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
+	"github.com/google/go-github/v74/github"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetReleaseByName_Success(t *testing.T) {
@@ -12,8 +15,8 @@ func TestGetReleaseByName_Success(t *testing.T) {
 		t.Skip("Skipping integration test in short mode")
 	}
 
-	// This makes a real API call to GitHub. GetReleaseByName does not accept a client, so we cannot
-	// point it at a mock server (see the TODO in history). Skip gracefully on transient API issues.
+	// This makes a real API call to GitHub; the mock tests below cover the same paths offline. Skip gracefully on
+	// transient API issues.
 	release, err := GetReleaseByName(context.Background(), "microsoft", "vscode", "1.85.0")
 	if err != nil {
 		t.Skipf("API call failed (likely network/rate limit): %v", err)
@@ -58,3 +61,32 @@ func TestGetReleaseByName_RepositoryNotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, release)
 }
+
+func TestGetReleaseByName_MockTagEndpoint(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/releases/tags/v1.2.3", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{
+			"tag_name": "v1.2.3",
+			"assets":   []map[string]any{{"name": "app-linux-amd64.tar.xz"}},
+		})
+	})
+	useMockServer(t, mux)
+
+	release, err := GetReleaseByName(context.Background(), "owner", "repo", "v1.2.3")
+
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.3", release.GetTagName())
+	require.Len(t, release.Assets, 1)
+	assert.Equal(t, "app-linux-amd64.tar.xz", release.Assets[0].GetName())
+}
+
+func TestGetReleaseByName_MockTagNotFound(t *testing.T) {
+	useMockServer(t, http.NotFoundHandler())
+
+	release, err := GetReleaseByName(context.Background(), "owner", "repo", "v9.9.9")
+
+	assert.Nil(t, release)
+	var githubErr *github.ErrorResponse
+	require.ErrorAs(t, err, &githubErr)
+	assert.Equal(t, http.StatusNotFound, githubErr.Response.StatusCode)
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// GetReleases retrieves all the published releases of the specified GitHub repository, paging through the GitHub API.
+// Drafts are never included, and prereleases only when requested, so the first release is the latest stable one even
+// when the newest tag is a prerelease.
+//
+// # Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - owner: The GitHub username or organization name that owns the repository
+//   - repo: The name of the repository
+//   - includePrereleases: Whether the releases marked as prerelease should be included
+//
+// # Returns:
+//   - []*github.RepositoryRelease: The releases sorted newest-first by their publish date
+//   - error: An error if the owner or repo are empty, or if any API request fails
+//
+// # Example:
+//
+//	releases, err := GetReleases(ctx, "microsoft", "vscode", false)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, release := range releases {
+//	    fmt.Println(release.GetTagName())
+//	}
+func GetReleases(
+	ctx context.Context,
+	owner, repo string,
+	includePrereleases bool,
+) ([]*github.RepositoryRelease, error) {
+	if owner == "" || repo == "" {
+		return nil, errors.New("owner and repo must not be empty")
+	}
+
+	client := apiClient()
+	releases := make([]*github.RepositoryRelease, 0)
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		page, resp, err := client.Repositories.ListReleases(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, release := range page {
+			if release.GetDraft() || (release.GetPrerelease() && !includePrereleases) {
+				continue
+			}
+			releases = append(releases, release)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releaseDate(releases[i]).After(releaseDate(releases[j]))
+	})
+
+	return releases, nil
+}
+
+// region - Private functions
+
+func releaseDate(release *github.RepositoryRelease) time.Time {
+	if release.PublishedAt != nil {
+		return release.PublishedAt.Time
+	}
+
+	return release.GetCreatedAt().Time
+}
+
+// endregion
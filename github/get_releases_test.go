@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useMockServer points the package functions to a mock GitHub API for the duration of the test.
+func useMockServer(t *testing.T, handler http.Handler) *httptest.Server {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	previous := newClient
+	newClient = func() *github.Client {
+		client := github.NewClient(nil)
+		client.BaseURL, _ = url.Parse(server.URL + "/")
+		return client
+	}
+	t.Cleanup(func() { newClient = previous })
+
+	return server
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
+
+func TestGetReleases(t *testing.T) {
+	releases := []map[string]any{
+		{"tag_name": "v1.0.0", "published_at": "2024-01-01T00:00:00Z"},
+		{"tag_name": "v2.0.0-rc.1", "prerelease": true, "published_at": "2024-03-01T00:00:00Z"},
+		{"tag_name": "v1.1.0", "published_at": "2024-02-01T00:00:00Z"},
+		{"tag_name": "v3.0.0", "draft": true},
+		{"tag_name": "v0.9.0", "published_at": "2023-06-01T00:00:00Z"},
+	}
+
+	handler := func(t *testing.T) http.Handler {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/owner/repo/releases", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+
+			// Two releases per page, to exercise the pagination
+			page := 1
+			fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+			start, end := (page-1)*2, min(page*2, len(releases))
+
+			if end < len(releases) {
+				next := fmt.Sprintf("http://%s%s?page=%d&per_page=100", r.Host, r.URL.Path, page+1)
+				w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next))
+			}
+			writeJSON(t, w, releases[start:end])
+		})
+
+		return mux
+	}
+
+	tags := func(releases []*github.RepositoryRelease) []string {
+		var out []string
+		for _, release := range releases {
+			out = append(out, release.GetTagName())
+		}
+		return out
+	}
+
+	t.Run("stable releases sorted newest-first", func(t *testing.T) {
+		useMockServer(t, handler(t))
+
+		result, err := GetReleases(context.Background(), "owner", "repo", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v1.1.0", "v1.0.0", "v0.9.0"}, tags(result))
+	})
+
+	t.Run("including prereleases", func(t *testing.T) {
+		useMockServer(t, handler(t))
+
+		result, err := GetReleases(context.Background(), "owner", "repo", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v2.0.0-rc.1", "v1.1.0", "v1.0.0", "v0.9.0"}, tags(result))
+	})
+
+	t.Run("repository without releases", func(t *testing.T) {
+		useMockServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(t, w, []any{})
+		}))
+
+		result, err := GetReleases(context.Background(), "owner", "repo", true)
+
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("error with non-existent repository", func(t *testing.T) {
+		useMockServer(t, http.NotFoundHandler())
+
+		result, err := GetReleases(context.Background(), "owner", "missing", false)
+
+		assert.Nil(t, result)
+		var githubErr *github.ErrorResponse
+		require.ErrorAs(t, err, &githubErr)
+		assert.Equal(t, http.StatusNotFound, githubErr.Response.StatusCode)
+	})
+
+	t.Run("error with empty owner or repo", func(t *testing.T) {
+		for _, params := range [][2]string{{"", "repo"}, {"owner", ""}, {"", ""}} {
+			result, err := GetReleases(context.Background(), params[0], params[1], false)
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		}
+	})
+}
@@ -1,18 +1,12 @@
 package github
 
-import (
-	"context"
-	"strings"
-
-	"github.com/google/go-github/v74/github"
-	"golang.org/x/mod/semver"
-)
+import "context"
 
 // IsOutdatedRelease checks if a given version is outdated compared to the latest release of a GitHub repository. It
 // fetches the latest release from the specified repository and performs semantic version comparison.
 //
 // # Parameters:
-//   - ctx: Context for cancellation and timeouts
+//   - ctx: Context for cancellation and timeouts; when it has no deadline, the check gives up after 10 seconds
 //   - owner: The owner (username or organization) of the GitHub repository
 //   - repo: The name of the GitHub repository
 //   - version: The version to check (can be with or without 'v' prefix)
@@ -20,9 +14,10 @@ import (
 // # Returns:
 //   - true if the given version is older than the latest release
 //   - false if the given version is up-to-date, newer, or if an error occurs
-//     (e.g., repository not found, no releases, network error)
+//     (e.g., repository not found, no releases, network error, invalid version); use IsOutdatedReleaseResult to tell
+//     these cases apart
 //
-// The function automatically handles version prefixes by ensuring both versions have the 'v' prefix before performing
+// The versions are compared with CompareVersions, which handles the optional 'v' prefix before performing the
 // semantic version comparison using golang.org/x/mod/semver.
 //
 // # Example:
@@ -32,26 +27,6 @@ import (
 //	    fmt.Println("Your Go version is outdated")
 //	}
 func IsOutdatedRelease(ctx context.Context, owner, repo, version string) bool {
-	client := github.NewClient(nil)
-
-	release, _, err := client.Repositories.GetLatestRelease(ctx, owner, repo)
-	if err != nil {
-		return false
-	}
-
-	latestVersion := release.GetName()
-	if latestVersion == "" {
-		return false
-	}
-
-	// Ensure both versions have the 'v' prefix for semver comparison
-	if !strings.HasPrefix(version, "v") {
-		version = "v" + version
-	}
-	if !strings.HasPrefix(latestVersion, "v") {
-		latestVersion = "v" + latestVersion
-	}
-
-	// Use semantic version comparison
-	return semver.Compare(latestVersion, version) > 0
+	outdated, _, err := IsOutdatedReleaseResult(ctx, owner, repo, version)
+	return err == nil && outdated
 }
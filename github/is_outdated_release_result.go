@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/mod/semver"
+)
+
+// IsOutdatedReleaseResult checks if a given version is outdated compared to the latest release of a GitHub repository,
+// like IsOutdatedRelease, but also returns the latest version and why the check failed, so callers can tell an
+// up-to-date version from a check that couldn't be done.
+//
+// # Parameters:
+//   - ctx: Context for cancellation and timeouts; when it has no deadline, the check gives up after 10 seconds
+//   - owner: The owner (username or organization) of the GitHub repository
+//   - repo: The name of the GitHub repository
+//   - version: The version to check (can be with or without 'v' prefix)
+//
+// # Returns:
+//   - outdated: true if the given version is older than the latest release
+//   - latest: The name of the latest release, as published on GitHub
+//   - err: An error if the latest release can't be fetched, it has no name, or either version isn't valid semver
+//
+// # Example:
+//
+//	outdated, latest, err := IsOutdatedReleaseResult(ctx, "owner", "repo", "1.2.0")
+//	if err != nil {
+//	    log.Printf("Couldn't check for updates: %v", err)
+//	} else if outdated {
+//	    fmt.Printf("Version %s is available\n", latest)
+//	}
+func IsOutdatedReleaseResult(ctx context.Context, owner, repo, version string) (outdated bool, latest string, err error) {
+	release, err := GetLatestRelease(ctx, owner, repo)
+	if err != nil {
+		return false, "", err
+	}
+
+	latest = release.GetName()
+	if latest == "" {
+		return false, "", fmt.Errorf("latest release of %s/%s has no name", owner, repo)
+	}
+
+	if !semver.IsValid(normalizeVersion(version)) {
+		return false, latest, fmt.Errorf("invalid version: %s", version)
+	}
+	if !semver.IsValid(normalizeVersion(latest)) {
+		return false, latest, fmt.Errorf("invalid latest version: %s", latest)
+	}
+
+	return CompareVersions(latest, version) > 0, latest, nil
+}
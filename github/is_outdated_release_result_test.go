@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsOutdatedReleaseResult(t *testing.T) {
+	latestNamed := func(name string) http.Handler {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/owner/repo/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(t, w, map[string]any{"tag_name": "v1.2.0", "name": name})
+		})
+		return mux
+	}
+
+	t.Run("outdated version", func(t *testing.T) {
+		useMockServer(t, latestNamed("v1.2.0"))
+
+		outdated, latest, err := IsOutdatedReleaseResult(context.Background(), "owner", "repo", "1.1.9")
+
+		require.NoError(t, err)
+		assert.True(t, outdated)
+		assert.Equal(t, "v1.2.0", latest)
+	})
+
+	t.Run("current version", func(t *testing.T) {
+		useMockServer(t, latestNamed("1.2.0"))
+
+		outdated, latest, err := IsOutdatedReleaseResult(context.Background(), "owner", "repo", "v1.2.0")
+
+		require.NoError(t, err)
+		assert.False(t, outdated)
+		assert.Equal(t, "1.2.0", latest)
+	})
+
+	t.Run("newer version", func(t *testing.T) {
+		useMockServer(t, latestNamed("v1.2.0"))
+
+		outdated, _, err := IsOutdatedReleaseResult(context.Background(), "owner", "repo", "2.0.0")
+
+		require.NoError(t, err)
+		assert.False(t, outdated)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		useMockServer(t, latestNamed("v1.2.0"))
+
+		outdated, latest, err := IsOutdatedReleaseResult(context.Background(), "owner", "repo", "invalid-version")
+
+		assert.EqualError(t, err, "invalid version: invalid-version")
+		assert.False(t, outdated)
+		assert.Equal(t, "v1.2.0", latest)
+		assert.False(t, IsOutdatedRelease(context.Background(), "owner", "repo", "invalid-version"))
+	})
+
+	t.Run("invalid latest version", func(t *testing.T) {
+		useMockServer(t, latestNamed("Summer release"))
+
+		_, _, err := IsOutdatedReleaseResult(context.Background(), "owner", "repo", "1.0.0")
+
+		assert.EqualError(t, err, "invalid latest version: Summer release")
+	})
+
+	t.Run("release without a name", func(t *testing.T) {
+		useMockServer(t, latestNamed(""))
+
+		_, latest, err := IsOutdatedReleaseResult(context.Background(), "owner", "repo", "1.0.0")
+
+		assert.EqualError(t, err, "latest release of owner/repo has no name")
+		assert.Empty(t, latest)
+	})
+
+	t.Run("repository not found", func(t *testing.T) {
+		useMockServer(t, http.NotFoundHandler())
+
+		outdated, latest, err := IsOutdatedReleaseResult(context.Background(), "owner", "repo", "1.0.0")
+
+		assert.Error(t, err)
+		assert.False(t, outdated)
+		assert.Empty(t, latest)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		useMockServer(t, slowHandler(time.Second))
+		withDefaultTimeoutOf(t, 50*time.Millisecond)
+
+		_, _, err := IsOutdatedReleaseResult(context.Background(), "owner", "repo", "1.0.0")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
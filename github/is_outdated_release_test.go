@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v74/github"
 	"github.com/stretchr/testify/assert"
@@ -108,6 +109,14 @@ func TestIsOutdatedRelease_ErrorScenarios(t *testing.T) {
 		result := IsOutdatedRelease(context.Background(), "nonexistent-owner", "nonexistent-repo", "1.0.0")
 		assert.False(t, result, "Should return false when repository doesn't exist")
 	})
+
+	t.Run("timeout", func(t *testing.T) {
+		useMockServer(t, slowHandler(time.Second))
+		withDefaultTimeoutOf(t, 50*time.Millisecond)
+
+		result := IsOutdatedRelease(context.Background(), "owner", "repo", "0.1.0")
+		assert.False(t, result, "Should return false when the check times out")
+	})
 }
 
 func TestIsOutdatedRelease_EdgeCases(t *testing.T) {
@@ -0,0 +1,53 @@
+package memo
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Codec serializes the cached values to bytes and back. A Memoizer uses GobCodec unless another one is passed to its
+// constructor; values cached with one codec can't be read with another, so a persistent store should keep the same one.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// GobCodec serializes values with encoding/gob. It's the default codec, and supports any type gob can encode, including
+// structs with unexported fields omitted.
+type GobCodec struct{}
+
+func (GobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (GobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// JSONCodec serializes values with encoding/json, making the cached entries human-readable and readable by other
+// languages.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (JSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// region - Private functions
+
+func encodeValue[T any](c Codec, v T) ([]byte, error) {
+	return c.Marshal(v)
+}
+
+func decodeValue[T any](c Codec, data []byte) (T, error) {
+	var v T
+	err := c.Unmarshal(data, &v)
+	return v, err
+}
+
+// endregion
@@ -0,0 +1,115 @@
+package memo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vegidio/go-sak/memo/internal"
+)
+
+func TestCodec(t *testing.T) {
+	type release struct {
+		Tag       string `json:"tag"`
+		Downloads int    `json:"downloads"`
+	}
+
+	t.Run("defaults to gob", func(t *testing.T) {
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		assert.Equal(t, GobCodec{}, m.Codec)
+	})
+
+	t.Run("struct literal falls back to gob", func(t *testing.T) {
+		store, err := internal.NewMemoryStore(internal.CacheOpts{})
+		require.NoError(t, err)
+
+		m := &Memoizer{Store: store}
+		defer m.Close()
+
+		ctx := context.Background()
+		require.NoError(t, Set(m, ctx, "key", "value", time.Minute))
+
+		result, ok, err := Get[string](m, ctx, "key")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "value", result)
+	})
+
+	t.Run("json entries are human-readable on disk", func(t *testing.T) {
+		m, closeFunc, err := NewMemoryDisk(t.TempDir(), internal.CacheOpts{}, time.Minute, JSONCodec{})
+		require.NoError(t, err)
+		defer closeFunc()
+
+		ctx := context.Background()
+		expected := release{Tag: "v1.0.0", Downloads: 42}
+
+		result, err := Do(m, ctx, "key", time.Minute, func(ctx context.Context) (release, error) {
+			return expected, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+
+		raw, ok, err := m.Store.Get(ctx, "key")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.JSONEq(t, `{"tag":"v1.0.0","downloads":42}`, string(raw))
+
+		cached, ok, err := Get[release](m, ctx, "key")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, expected, cached)
+	})
+
+	t.Run("custom codec", func(t *testing.T) {
+		codec := &countingCodec{}
+		m, err := NewDiskOnly(t.TempDir(), internal.CacheOpts{}, codec)
+		require.NoError(t, err)
+		defer m.Close()
+
+		ctx := context.Background()
+		require.NoError(t, Set(m, ctx, "key", []int{1, 2, 3}, time.Minute))
+
+		result, ok, err := Get[[]int](m, ctx, "key")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []int{1, 2, 3}, result)
+		assert.Equal(t, 1, codec.marshals)
+		assert.Equal(t, 1, codec.unmarshals)
+	})
+
+	t.Run("values from another codec are not decoded", func(t *testing.T) {
+		store, err := internal.NewMemoryStore(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer store.Close()
+
+		ctx := context.Background()
+		require.NoError(t, Set(NewMemoizer(store, JSONCodec{}), ctx, "key", release{Tag: "v1"}, time.Minute))
+
+		_, ok, err := Get[release](NewMemoizer(store, GobCodec{}), ctx, "key")
+		assert.Error(t, err)
+		assert.False(t, ok)
+	})
+}
+
+// Helper types
+
+type countingCodec struct {
+	marshals   int
+	unmarshals int
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	c.marshals++
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.unmarshals++
+	return json.Unmarshal(data, v)
+}
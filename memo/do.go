@@ -1,9 +1,7 @@
 package memo
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
 	"time"
 )
 
@@ -14,7 +12,8 @@ import (
 //  2. If not cached, uses singleflight to deduplicate concurrent calls with the same key
 //  3. Executes the compute function and caches the result with the specified TTL
 //
-// The function is generic and works with any type T that can be encoded/decoded with gob.
+// The function is generic and works with any type T that can be encoded/decoded with the Memoizer's codec (gob by
+// default).
 //
 // # Parameters:
 //   - m: The Memoizer instance containing the cache store and singleflight group
@@ -41,16 +40,19 @@ func Do[T any](
 	if b, ok, err := m.Store.Get(ctx, key); err != nil {
 		return zero, err
 	} else if ok {
-		if v, dErr := decodeGob[T](b); dErr == nil {
+		if v, dErr := decodeValue[T](m.codec(), b); dErr == nil {
 			return v, nil
 		}
 	}
 
 	// Deduplicate concurrent misses
 	val, err, _ := m.Sf.Do(key, func() (any, error) {
+		// Read before computing, so an invalidation during the computation keeps its result out of the cache
+		gen := m.generation(key)
+
 		// Recheck inside singleflight
 		if b, ok, err := m.Store.Get(ctx, key); err == nil && ok {
-			if v, e := decodeGob[T](b); e == nil {
+			if v, e := decodeValue[T](m.codec(), b); e == nil {
 				return v, nil
 			}
 		}
@@ -61,8 +63,8 @@ func Do[T any](
 			return zero, err
 		}
 
-		if payload, e := encodeGob(res); e == nil {
-			_ = m.Store.Set(ctx, key, payload, ttl) // best-effort cache write
+		if payload, e := encodeValue(m.codec(), res); e == nil {
+			m.setIfCurrent(ctx, key, gen, payload, ttl)
 		}
 
 		return res, nil
@@ -73,22 +75,3 @@ func Do[T any](
 
 	return val.(T), nil
 }
-
-// region - Private methods
-
-func encodeGob[T any](v T) ([]byte, error) {
-	var buf bytes.Buffer
-	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
-		return nil, err
-	}
-
-	return buf.Bytes(), nil
-}
-
-func decodeGob[T any](data []byte) (T, error) {
-	var v T
-	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v)
-	return v, err
-}
-
-// endregion
@@ -276,9 +276,10 @@ type result struct {
 }
 
 type mockStore struct {
-	data     map[string][]byte
-	getError error
-	setError error
+	data        map[string][]byte
+	getError    error
+	setError    error
+	deleteError error
 }
 
 func (m *mockStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
@@ -303,6 +304,22 @@ func (m *mockStore) Set(ctx context.Context, key string, value []byte, ttl time.
 	return nil
 }
 
+func (m *mockStore) Delete(ctx context.Context, key string) error {
+	if m.deleteError != nil {
+		return m.deleteError
+	}
+	delete(m.data, key)
+	return nil
+}
+
+func (m *mockStore) Clear(ctx context.Context) error {
+	if m.deleteError != nil {
+		return m.deleteError
+	}
+	m.data = nil
+	return nil
+}
+
 func (m *mockStore) Close() error {
 	return nil
 }
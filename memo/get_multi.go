@@ -0,0 +1,56 @@
+package memo
+
+import (
+	"context"
+
+	"github.com/vegidio/go-sak/memo/internal"
+)
+
+// GetMulti reads many cached values at once, without computing the missing ones. The stores that support batched reads
+// fetch all the keys together (e.g. a single transaction on disk or a single MGET on Redis); the others are read one key
+// at a time.
+//
+// # Parameters:
+//   - m: The Memoizer instance containing the cache store
+//   - ctx: Context for cancellation and timeouts
+//   - keys: The keys of the cached values to read
+//
+// # Returns:
+//   - map[string]T: The values found in the cache, by key
+//   - []string: The keys that were not found, in the order they were requested, so they can be computed in a batch
+//   - error: Any error that occurred reading from the store
+//
+// Values that can't be decoded as T are reported as missing, like Do treats them as cache misses.
+//
+// # Example:
+//
+//	found, missing, err := GetMulti[User](m, ctx, ids)
+//	for _, id := range missing {
+//	    // fetch the missing users and cache them with Set
+//	}
+func GetMulti[T any](m *Memoizer, ctx context.Context, keys []string) (map[string]T, []string, error) {
+	raw, err := internal.GetMany(ctx, m.Store, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found := make(map[string]T, len(raw))
+	missing := make([]string, 0, len(keys)-len(raw))
+
+	for _, key := range keys {
+		if _, ok := found[key]; ok {
+			continue
+		}
+
+		if b, ok := raw[key]; ok {
+			if v, dErr := decodeValue[T](m.codec(), b); dErr == nil {
+				found[key] = v
+				continue
+			}
+		}
+
+		missing = append(missing, key)
+	}
+
+	return found, missing, nil
+}
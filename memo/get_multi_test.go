@@ -0,0 +1,130 @@
+package memo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vegidio/go-sak/memo/internal"
+)
+
+func TestGetMulti(t *testing.T) {
+	stores := map[string]func(t *testing.T) *Memoizer{
+		"memory only": func(t *testing.T) *Memoizer {
+			m, err := NewMemoryOnly(internal.CacheOpts{})
+			require.NoError(t, err)
+			return m
+		},
+		"disk only": func(t *testing.T) *Memoizer {
+			m, err := NewDiskOnly(t.TempDir(), internal.CacheOpts{})
+			require.NoError(t, err)
+			return m
+		},
+		"memory disk": func(t *testing.T) *Memoizer {
+			m, _, err := NewMemoryDisk(t.TempDir(), internal.CacheOpts{}, time.Minute)
+			require.NoError(t, err)
+			return m
+		},
+		"memory redis": func(t *testing.T) *Memoizer {
+			m, _, err := NewMemoryRedis(miniredis.RunT(t).Addr(), internal.RedisOpts{}, internal.CacheOpts{}, time.Minute)
+			require.NoError(t, err)
+			return m
+		},
+		"store without batching": func(t *testing.T) *Memoizer {
+			return NewMemoizer(&mockStore{})
+		},
+	}
+
+	for name, newMemoizer := range stores {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			m := newMemoizer(t)
+			defer m.Close()
+
+			ctx := context.Background()
+			require.NoError(t, Set(m, ctx, "a", 1, time.Minute))
+			require.NoError(t, Set(m, ctx, "c", 3, time.Minute))
+
+			// Act
+			found, missing, err := GetMulti[int](m, ctx, []string{"a", "b", "c", "d"})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]int{"a": 1, "c": 3}, found)
+			assert.Equal(t, []string{"b", "d"}, missing)
+		})
+	}
+
+	t.Run("promotes disk hits to memory", func(t *testing.T) {
+		// Arrange
+		mem, err := internal.NewMemoryStore(internal.CacheOpts{})
+		require.NoError(t, err)
+		disk, err := internal.NewDiskStore(t.TempDir(), internal.CacheOpts{})
+		require.NoError(t, err)
+
+		m := NewMemoizer(internal.NewCompositeStore(mem, disk, time.Minute))
+		defer m.Close()
+
+		ctx := context.Background()
+		require.NoError(t, Set(NewMemoizer(disk), ctx, "cold", "value", time.Minute))
+
+		// Act
+		found, missing, err := GetMulti[string](m, ctx, []string{"cold"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"cold": "value"}, found)
+		assert.Empty(t, missing)
+		_, ok, _ := mem.Get(ctx, "cold")
+		assert.True(t, ok, "disk hit should be promoted to memory")
+	})
+
+	t.Run("values of another type are missing", func(t *testing.T) {
+		// Arrange
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		ctx := context.Background()
+		require.NoError(t, Set(m, ctx, "text", "not a number", time.Minute))
+
+		// Act
+		found, missing, err := GetMulti[int](m, ctx, []string{"text"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+		assert.Equal(t, []string{"text"}, missing)
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		found, missing, err := GetMulti[int](m, context.Background(), nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		// Arrange
+		expectedError := errors.New("store get failed")
+		m := NewMemoizer(&mockStore{getError: expectedError})
+		defer m.Close()
+
+		// Act
+		found, missing, err := GetMulti[int](m, context.Background(), []string{"a"})
+
+		// Assert
+		assert.Equal(t, expectedError, err)
+		assert.Nil(t, found)
+		assert.Nil(t, missing)
+	})
+}
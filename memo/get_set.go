@@ -0,0 +1,63 @@
+package memo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Set stores a precomputed value in the cache with the given key and TTL, without a compute function. The value is
+// encoded the same way as in Do, so both are interchangeable for the same key.
+//
+// # Parameters:
+//   - m: The Memoizer instance containing the cache store
+//   - ctx: Context for cancellation and timeouts
+//   - key: Unique identifier for the cached value
+//   - value: The value to be cached; it must be encodable with the Memoizer's codec
+//   - ttl: Time-to-live duration for the cached value
+//
+// Returns an error if the value can't be encoded or the store fails to write it.
+//
+// # Example:
+//
+//	err := Set(m, ctx, "latest-release", release, time.Hour)
+func Set[T any](m *Memoizer, ctx context.Context, key string, value T, ttl time.Duration) error {
+	payload, err := encodeValue(m.codec(), value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value for key %s: %w", key, err)
+	}
+
+	return m.Store.Set(ctx, key, payload, ttl)
+}
+
+// Get reads a cached value with the given key, without computing it on a miss. It reads values cached by both Set and
+// Do.
+//
+// # Parameters:
+//   - m: The Memoizer instance containing the cache store
+//   - ctx: Context for cancellation and timeouts
+//   - key: Unique identifier for the cached value
+//
+// # Returns:
+//   - T: The cached value, or the zero value of T if the key isn't cached
+//   - bool: Whether the key was found in the cache
+//   - error: Any error that occurred reading from the store, or decoding the value as T
+//
+// # Example:
+//
+//	release, ok, err := Get[Release](m, ctx, "latest-release")
+func Get[T any](m *Memoizer, ctx context.Context, key string) (T, bool, error) {
+	var zero T
+
+	b, ok, err := m.Store.Get(ctx, key)
+	if err != nil || !ok {
+		return zero, false, err
+	}
+
+	v, err := decodeValue[T](m.codec(), b)
+	if err != nil {
+		return zero, false, fmt.Errorf("failed to decode value for key %s: %w", key, err)
+	}
+
+	return v, true, nil
+}
@@ -0,0 +1,133 @@
+package memo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vegidio/go-sak/memo/internal"
+)
+
+func TestSetGet(t *testing.T) {
+	type release struct {
+		Tag    string
+		Assets []string
+	}
+
+	t.Run("round-trips a value", func(t *testing.T) {
+		// Arrange
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		ctx := context.Background()
+		expected := release{Tag: "v1.2.3", Assets: []string{"linux.zip", "windows.zip"}}
+
+		// Act
+		err = Set(m, ctx, "release", expected, time.Minute)
+		require.NoError(t, err)
+		result, ok, err := Get[release](m, ctx, "release")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		// Arrange
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		// Act
+		result, ok, err := Get[string](m, context.Background(), "missing")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Empty(t, result)
+	})
+
+	t.Run("Do reads values from Set", func(t *testing.T) {
+		// Arrange
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		ctx := context.Background()
+		require.NoError(t, Set(m, ctx, "key", 42, time.Minute))
+
+		// Act
+		callCount := 0
+		result, err := Do(m, ctx, "key", time.Minute, func(ctx context.Context) (int, error) {
+			callCount++
+			return 0, nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 42, result)
+		assert.Equal(t, 0, callCount, "compute function should not be called for a value from Set")
+	})
+
+	t.Run("Get reads values from Do", func(t *testing.T) {
+		// Arrange
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		ctx := context.Background()
+		_, err = Do(m, ctx, "key", time.Minute, func(ctx context.Context) (string, error) {
+			return "computed", nil
+		})
+		require.NoError(t, err)
+
+		// Act
+		result, ok, err := Get[string](m, ctx, "key")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "computed", result)
+	})
+
+	t.Run("decode error for the wrong type", func(t *testing.T) {
+		// Arrange
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		ctx := context.Background()
+		require.NoError(t, Set(m, ctx, "key", "text", time.Minute))
+
+		// Act
+		_, ok, err := Get[release](m, ctx, "key")
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		// Arrange
+		getError := errors.New("store get failed")
+		setError := errors.New("store set failed")
+		m := NewMemoizer(&mockStore{getError: getError, setError: setError})
+		defer m.Close()
+
+		ctx := context.Background()
+
+		// Act
+		setErr := Set(m, ctx, "key", "value", time.Minute)
+		_, ok, getErr := Get[string](m, ctx, "key")
+
+		// Assert
+		assert.Equal(t, setError, setErr)
+		assert.Equal(t, getError, getErr)
+		assert.False(t, ok)
+	})
+}
@@ -38,6 +38,44 @@ func (s *CompositeStore) Get(ctx context.Context, key string) ([]byte, bool, err
 	return nil, false, nil
 }
 
+// GetMany reads the keys from memory first, and only the ones missing from memory are read from disk and promoted.
+func (s *CompositeStore) GetMany(ctx context.Context, keys []string) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(keys))
+	missing := keys
+
+	if s.mem != nil {
+		found, err := GetMany(ctx, s.mem, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		missing = make([]string, 0, len(keys)-len(found))
+		for _, key := range keys {
+			if b, ok := found[key]; ok {
+				out[key] = b
+			} else {
+				missing = append(missing, key)
+			}
+		}
+	}
+
+	if s.disk != nil && len(missing) > 0 {
+		found, err := GetMany(ctx, s.disk, missing)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, b := range found {
+			if s.mem != nil && s.hotTTL > 0 {
+				_ = s.mem.Set(ctx, key, b, s.hotTTL) // promote best-effort
+			}
+			out[key] = b
+		}
+	}
+
+	return out, nil
+}
+
 func (s *CompositeStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
 	var firstErr error
 	if s.disk != nil {
@@ -55,6 +93,41 @@ func (s *CompositeStore) Set(ctx context.Context, key string, value []byte, ttl
 	return firstErr
 }
 
+// Delete removes the key from both tiers, so a stale value can't be promoted back from disk.
+func (s *CompositeStore) Delete(ctx context.Context, key string) error {
+	var firstErr error
+	if s.disk != nil {
+		if err := s.disk.Delete(ctx, key); err != nil {
+			firstErr = err
+		}
+	}
+
+	if s.mem != nil {
+		if err := s.mem.Delete(ctx, key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (s *CompositeStore) Clear(ctx context.Context) error {
+	var firstErr error
+	if s.disk != nil {
+		if err := s.disk.Clear(ctx); err != nil {
+			firstErr = err
+		}
+	}
+
+	if s.mem != nil {
+		if err := s.mem.Clear(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
 func (s *CompositeStore) Close() error {
 	var firstErr error
 	if s.mem != nil {
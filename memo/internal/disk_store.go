@@ -7,9 +7,18 @@ import (
 
 	"github.com/dgraph-io/badger/v4"
 	"github.com/dgraph-io/badger/v4/options"
+	"github.com/klauspost/compress/zstd"
 )
 
-type DiskStore struct{ db *badger.DB }
+// metaZstd flags, in the entry's user metadata, the values that were compressed before being written
+const metaZstd byte = 1 << 0
+
+type DiskStore struct {
+	db                *badger.DB
+	compressThreshold int64
+	enc               *zstd.Encoder
+	dec               *zstd.Decoder
+}
 
 func NewDiskStore(path string, opts CacheOpts) (*DiskStore, error) {
 	if opts.MaxEntries == 0 {
@@ -33,7 +42,12 @@ func NewDiskStore(path string, opts CacheOpts) (*DiskStore, error) {
 	// Run value log GC
 	db.RunValueLogGC(0.5)
 
-	return &DiskStore{db: db}, nil
+	// The decoder is always needed, as entries compressed earlier must be readable after the threshold changes; neither
+	// can fail without options
+	enc, _ := zstd.NewWriter(nil)
+	dec, _ := zstd.NewReader(nil)
+
+	return &DiskStore{db: db, compressThreshold: opts.CompressThreshold, enc: enc, dec: dec}, nil
 }
 
 func (s *DiskStore) Get(_ context.Context, key string) ([]byte, bool, error) {
@@ -44,6 +58,12 @@ func (s *DiskStore) Get(_ context.Context, key string) ([]byte, bool, error) {
 			return err
 		}
 		return it.Value(func(val []byte) error {
+			if it.UserMeta()&metaZstd != 0 {
+				decoded, dErr := s.dec.DecodeAll(val, nil)
+				out = decoded
+				return dErr
+			}
+
 			out = append(out[:0], val...) // copy out
 			return nil
 		})
@@ -60,11 +80,68 @@ func (s *DiskStore) Get(_ context.Context, key string) ([]byte, bool, error) {
 	return nil, false, err
 }
 
+// GetMany reads all the keys in a single transaction.
+func (s *DiskStore) GetMany(_ context.Context, keys []string) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(keys))
+	err := s.db.View(func(txn *badger.Txn) error {
+		for _, key := range keys {
+			it, err := txn.Get([]byte(key))
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			value, err := it.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+
+			if it.UserMeta()&metaZstd != 0 {
+				if value, err = s.dec.DecodeAll(value, nil); err != nil {
+					return err
+				}
+			}
+
+			out[key] = value
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (s *DiskStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	var meta byte
+	if s.compressThreshold > 0 && int64(len(value)) >= s.compressThreshold {
+		// Keep the original when compressing doesn't pay off
+		if compressed := s.enc.EncodeAll(value, nil); len(compressed) < len(value) {
+			value = compressed
+			meta = metaZstd
+		}
+	}
+
 	return s.db.Update(func(txn *badger.Txn) error {
-		e := badger.NewEntry([]byte(key), value).WithTTL(ttl)
+		e := badger.NewEntry([]byte(key), value).WithTTL(ttl).WithMeta(meta)
 		return txn.SetEntry(e)
 	})
 }
 
-func (s *DiskStore) Close() error { return s.db.Close() }
+func (s *DiskStore) Delete(_ context.Context, key string) error {
+	return s.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete([]byte(key))
+	})
+}
+
+func (s *DiskStore) Clear(_ context.Context) error { return s.db.DropAll() }
+
+func (s *DiskStore) Close() error {
+	s.enc.Close()
+	s.dec.Close()
+	return s.db.Close()
+}
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func storedSize(t *testing.T, s *DiskStore, key string) int {
+	var size int
+	err := s.db.View(func(txn *badger.Txn) error {
+		it, err := txn.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+		size = int(it.ValueSize())
+		return nil
+	})
+
+	require.NoError(t, err)
+	return size
+}
+
+func TestDiskStoreCompression(t *testing.T) {
+	large := []byte(strings.Repeat("go-sak memoized payload ", 4096))
+	small := []byte("tiny")
+
+	t.Run("compresses entries above the threshold", func(t *testing.T) {
+		s, err := NewDiskStore(t.TempDir(), CacheOpts{CompressThreshold: 1024})
+		require.NoError(t, err)
+		defer s.Close()
+
+		ctx := context.Background()
+		require.NoError(t, s.Set(ctx, "large", large, time.Minute))
+		require.NoError(t, s.Set(ctx, "small", small, time.Minute))
+
+		assert.Less(t, storedSize(t, s, "large"), len(large)/10)
+		assert.Equal(t, len(small), storedSize(t, s, "small"))
+
+		value, ok, err := s.Get(ctx, "large")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.True(t, bytes.Equal(large, value))
+
+		value, ok, err = s.Get(ctx, "small")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, small, value)
+	})
+
+	t.Run("batched reads decompress entries", func(t *testing.T) {
+		s, err := NewDiskStore(t.TempDir(), CacheOpts{CompressThreshold: 1024})
+		require.NoError(t, err)
+		defer s.Close()
+
+		ctx := context.Background()
+		require.NoError(t, s.Set(ctx, "large", large, time.Minute))
+		require.NoError(t, s.Set(ctx, "small", small, time.Minute))
+
+		values, err := s.GetMany(ctx, []string{"large", "small", "missing"})
+		assert.NoError(t, err)
+		assert.Len(t, values, 2)
+		assert.True(t, bytes.Equal(large, values["large"]))
+		assert.Equal(t, small, values["small"])
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		s, err := NewDiskStore(t.TempDir(), CacheOpts{})
+		require.NoError(t, err)
+		defer s.Close()
+
+		require.NoError(t, s.Set(context.Background(), "large", large, time.Minute))
+		assert.Equal(t, len(large), storedSize(t, s, "large"))
+	})
+
+	t.Run("keeps incompressible entries as they are", func(t *testing.T) {
+		s, err := NewDiskStore(t.TempDir(), CacheOpts{CompressThreshold: 1})
+		require.NoError(t, err)
+		defer s.Close()
+
+		ctx := context.Background()
+		require.NoError(t, s.Set(ctx, "key", small, time.Minute))
+		assert.Equal(t, len(small), storedSize(t, s, "key"))
+
+		value, ok, err := s.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, small, value)
+	})
+
+	t.Run("reads compressed entries after the threshold is disabled", func(t *testing.T) {
+		dir := t.TempDir()
+		ctx := context.Background()
+
+		s, err := NewDiskStore(dir, CacheOpts{CompressThreshold: 1024})
+		require.NoError(t, err)
+		require.NoError(t, s.Set(ctx, "large", large, time.Minute))
+		require.NoError(t, s.Close())
+
+		s, err = NewDiskStore(dir, CacheOpts{})
+		require.NoError(t, err)
+		defer s.Close()
+
+		value, ok, err := s.Get(ctx, "large")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.True(t, bytes.Equal(large, value))
+	})
+}
@@ -40,6 +40,17 @@ func (m *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
 	return v, true, nil
 }
 
+func (m *MemoryStore) GetMany(_ context.Context, keys []string) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		if v, ok := m.c.Get(key); ok {
+			out[key] = v
+		}
+	}
+
+	return out, nil
+}
+
 func (m *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
 	// Cost = byte length; adjust if you want different weighting
 	_ = m.c.SetWithTTL(key, value, int64(len(value)), ttl)
@@ -48,4 +59,15 @@ func (m *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.
 	return nil
 }
 
+func (m *MemoryStore) Delete(_ context.Context, key string) error {
+	m.c.Del(key)
+	m.c.Wait()
+	return nil
+}
+
+func (m *MemoryStore) Clear(_ context.Context) error {
+	m.c.Clear()
+	return nil
+}
+
 func (m *MemoryStore) Close() error { m.c.Close(); return nil }
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisOpts struct {
+	// Username and Password are the credentials used to authenticate, if any.
+	Username string
+	Password string
+	// DB is the Redis database to select.
+	DB int
+	// Prefix is prepended to every key, so the cache can share a database; defaults to "memo:".
+	Prefix string
+}
+
+type RedisStore struct {
+	c      *redis.Client
+	prefix string
+}
+
+func NewRedisStore(addr string, opts RedisOpts) (*RedisStore, error) {
+	if opts.Prefix == "" {
+		opts.Prefix = "memo:"
+	}
+
+	c := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Username: opts.Username,
+		Password: opts.Password,
+		DB:       opts.DB,
+	})
+
+	// Fail fast when the server can't be reached, like the other stores do on open
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := c.Ping(ctx).Err(); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return &RedisStore{c: c, prefix: opts.Prefix}, nil
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	b, err := s.c.Get(ctx, s.prefix+key).Bytes()
+	if err == nil {
+		return b, true, nil
+	}
+
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+
+	return nil, false, err
+}
+
+// GetMany reads all the keys with a single MGET round trip.
+func (s *RedisStore) GetMany(ctx context.Context, keys []string) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(keys))
+	if len(keys) == 0 {
+		return out, nil
+	}
+
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = s.prefix + key
+	}
+
+	values, err := s.c.MGet(ctx, prefixed...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, v := range values {
+		if str, ok := v.(string); ok {
+			out[keys[i]] = []byte(str)
+		}
+	}
+
+	return out, nil
+}
+
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	// A negative TTL means the entry is already expired, like in the other stores. It can't be passed to Redis, where 0
+	// means the key never expires and -1 keeps the current TTL, so any previous value is removed instead
+	if ttl < 0 {
+		return s.c.Del(ctx, s.prefix+key).Err()
+	}
+
+	return s.c.Set(ctx, s.prefix+key, value, ttl).Err()
+}
+
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	return s.c.Del(ctx, s.prefix+key).Err()
+}
+
+// Clear deletes only the keys with the store's prefix, leaving the rest of the database untouched.
+func (s *RedisStore) Clear(ctx context.Context) error {
+	iter := s.c.Scan(ctx, 0, s.prefix+"*", 1000).Iterator()
+
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return s.c.Del(ctx, keys...).Err()
+}
+
+func (s *RedisStore) Close() error { return s.c.Close() }
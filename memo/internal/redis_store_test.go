@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisStoreSet(t *testing.T) {
+	newStore := func(t *testing.T) (*RedisStore, *miniredis.Miniredis) {
+		server := miniredis.RunT(t)
+
+		s, err := NewRedisStore(server.Addr(), RedisOpts{})
+		require.NoError(t, err)
+		t.Cleanup(func() { s.Close() })
+
+		return s, server
+	}
+
+	t.Run("stores the value with its TTL", func(t *testing.T) {
+		s, server := newStore(t)
+		ctx := context.Background()
+
+		require.NoError(t, s.Set(ctx, "key", []byte("value"), time.Minute))
+
+		assert.True(t, server.Exists("memo:key"))
+		assert.Equal(t, time.Minute, server.TTL("memo:key"))
+	})
+
+	t.Run("negative TTL leaves no key", func(t *testing.T) {
+		s, server := newStore(t)
+		ctx := context.Background()
+
+		require.NoError(t, s.Set(ctx, "fresh", []byte("value"), -time.Second))
+		assert.False(t, server.Exists("memo:fresh"))
+
+		// A previous value is removed too, since the new one is already expired
+		require.NoError(t, s.Set(ctx, "stale", []byte("old"), time.Minute))
+		require.NoError(t, s.Set(ctx, "stale", []byte("new"), -time.Nanosecond))
+		assert.False(t, server.Exists("memo:stale"))
+
+		_, found, err := s.Get(ctx, "stale")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
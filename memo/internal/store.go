@@ -8,12 +8,43 @@ import (
 type Store interface {
 	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
 	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	Clear(ctx context.Context) error
 	Close() error
 }
 
+// MultiGetter is implemented by the stores that can read many keys at once more efficiently than one by one. The
+// returned map only has the keys that were found.
+type MultiGetter interface {
+	GetMany(ctx context.Context, keys []string) (map[string][]byte, error)
+}
+
+// GetMany reads many keys from the store, in a batch when it implements MultiGetter, or one by one otherwise.
+func GetMany(ctx context.Context, store Store, keys []string) (map[string][]byte, error) {
+	if mg, ok := store.(MultiGetter); ok {
+		return mg.GetMany(ctx, keys)
+	}
+
+	out := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		b, ok, err := store.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out[key] = b
+		}
+	}
+
+	return out, nil
+}
+
 type CacheOpts struct {
 	// MaxEntries is the max number of entries to store.
 	MaxEntries int64
 	// MaxCapacity is the max capacity in bytes.
 	MaxCapacity int64
+	// CompressThreshold is the size in bytes from which disk entries are compressed with zstd; 0 disables compression.
+	// The memory store ignores it and keeps the entries uncompressed for speed.
+	CompressThreshold int64
 }
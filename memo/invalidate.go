@@ -0,0 +1,41 @@
+package memo
+
+import "context"
+
+// Invalidate evicts the cached result of a key, so the next call to Do with it computes a fresh value. Use it when the
+// underlying data is known to have changed before the TTL expires.
+//
+// # Parameters:
+//   - m: The Memoizer instance containing the cache store
+//   - ctx: Context for cancellation and timeouts
+//   - key: The key used to cache the result
+//
+// Calls to Do already in flight for the key still return their result to the callers waiting on them, but that result
+// isn't cached and isn't shared with the calls made after Invalidate. This only covers the calls made through the same
+// Memoizer; other processes sharing a store (e.g. Redis) may still write back a value they were computing.
+//
+// Returns an error if the store fails to delete the key; deleting a key that isn't cached is not an error.
+//
+// # Example:
+//
+//	_ = Invalidate(m, ctx, KeyFrom("release", owner, repo))
+func Invalidate(m *Memoizer, ctx context.Context, key string) error {
+	return m.invalidate(key, false, func() error {
+		return m.Store.Delete(ctx, key)
+	})
+}
+
+// InvalidateAll evicts every cached result from the store, like a full flush.
+//
+// # Parameters:
+//   - m: The Memoizer instance containing the cache store
+//   - ctx: Context for cancellation and timeouts
+//
+// Like in Invalidate, the results of the calls to Do already in flight aren't cached.
+//
+// Returns an error if the store fails to be cleared.
+func InvalidateAll(m *Memoizer, ctx context.Context) error {
+	return m.invalidate("", true, func() error {
+		return m.Store.Clear(ctx)
+	})
+}
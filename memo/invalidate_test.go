@@ -0,0 +1,184 @@
+package memo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vegidio/go-sak/memo/internal"
+)
+
+func TestInvalidate(t *testing.T) {
+	t.Run("recomputes invalidated key", func(t *testing.T) {
+		// Arrange
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		ctx := context.Background()
+		callCount := 0
+		compute := func(ctx context.Context) (int, error) {
+			callCount++
+			return callCount, nil
+		}
+
+		first, err := Do(m, ctx, "key", time.Minute, compute)
+		require.NoError(t, err)
+
+		// Act
+		err = Invalidate(m, ctx, "key")
+		require.NoError(t, err)
+		second, err := Do(m, ctx, "key", time.Minute, compute)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, first)
+		assert.Equal(t, 2, second)
+	})
+
+	t.Run("keeps other keys cached", func(t *testing.T) {
+		// Arrange
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		ctx := context.Background()
+		_, err = Do(m, ctx, "stale", time.Minute, func(ctx context.Context) (string, error) { return "a", nil })
+		require.NoError(t, err)
+		_, err = Do(m, ctx, "fresh", time.Minute, func(ctx context.Context) (string, error) { return "b", nil })
+		require.NoError(t, err)
+
+		// Act
+		err = Invalidate(m, ctx, "stale")
+
+		// Assert
+		assert.NoError(t, err)
+		_, ok, _ := m.Store.Get(ctx, "stale")
+		assert.False(t, ok)
+		_, ok, _ = m.Store.Get(ctx, "fresh")
+		assert.True(t, ok)
+	})
+
+	t.Run("missing key is not an error", func(t *testing.T) {
+		// Arrange
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		// Act
+		err = Invalidate(m, context.Background(), "missing")
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("memory-disk value is not promoted back", func(t *testing.T) {
+		// Arrange
+		m, cleanup, err := NewMemoryDisk(t.TempDir(), internal.CacheOpts{}, time.Minute)
+		require.NoError(t, err)
+		defer cleanup()
+
+		ctx := context.Background()
+		_, err = Do(m, ctx, "key", time.Minute, func(ctx context.Context) (string, error) { return "old", nil })
+		require.NoError(t, err)
+
+		// Act
+		err = Invalidate(m, ctx, "key")
+		require.NoError(t, err)
+		result, err := Do(m, ctx, "key", time.Minute, func(ctx context.Context) (string, error) { return "new", nil })
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "new", result)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		// Arrange
+		expectedError := errors.New("store delete failed")
+		m := NewMemoizer(&mockStore{deleteError: expectedError})
+		defer m.Close()
+
+		// Act
+		err := Invalidate(m, context.Background(), "key")
+
+		// Assert
+		assert.Equal(t, expectedError, err)
+	})
+
+	t.Run("in-flight result is not cached", func(t *testing.T) {
+		// Arrange
+		m, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer m.Close()
+
+		ctx := context.Background()
+		started := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan int)
+
+		go func() {
+			v, _ := Do(m, ctx, "key", time.Minute, func(ctx context.Context) (int, error) {
+				close(started)
+				<-release
+				return 1, nil
+			})
+			done <- v
+		}()
+
+		// Act
+		<-started
+		require.NoError(t, Invalidate(m, ctx, "key"))
+		close(release)
+
+		// Assert
+		assert.Equal(t, 1, <-done)
+		_, ok, _ := m.Store.Get(ctx, "key")
+		assert.False(t, ok)
+
+		second, err := Do(m, ctx, "key", time.Minute, func(ctx context.Context) (int, error) { return 2, nil })
+		assert.NoError(t, err)
+		assert.Equal(t, 2, second)
+	})
+}
+
+func TestInvalidateAll(t *testing.T) {
+	t.Run("flushes every key", func(t *testing.T) {
+		// Arrange
+		m, cleanup, err := NewMemoryDisk(t.TempDir(), internal.CacheOpts{}, time.Minute)
+		require.NoError(t, err)
+		defer cleanup()
+
+		ctx := context.Background()
+		for _, key := range []string{"a", "b", "c"} {
+			_, err = Do(m, ctx, key, time.Minute, func(ctx context.Context) (string, error) { return key, nil })
+			require.NoError(t, err)
+		}
+
+		// Act
+		err = InvalidateAll(m, ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		for _, key := range []string{"a", "b", "c"} {
+			_, ok, getErr := m.Store.Get(ctx, key)
+			assert.NoError(t, getErr)
+			assert.False(t, ok, key)
+		}
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		// Arrange
+		expectedError := errors.New("store clear failed")
+		m := NewMemoizer(&mockStore{deleteError: expectedError})
+		defer m.Close()
+
+		// Act
+		err := InvalidateAll(m, context.Background())
+
+		// Assert
+		assert.Equal(t, expectedError, err)
+	})
+}
@@ -1,6 +1,10 @@
 package memo
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/vegidio/go-sak/memo/internal"
 	"golang.org/x/sync/singleflight"
 )
@@ -8,14 +12,27 @@ import (
 type Memoizer struct {
 	Store internal.Store
 	Sf    singleflight.Group
+	Codec Codec
+
+	// gens counts the invalidations of each key, and epoch those of the whole store, so a computation that was already
+	// in flight when its key was invalidated doesn't cache its result
+	mu    sync.RWMutex
+	gens  map[string]uint64
+	epoch uint64
 }
 
 // NewMemoizer creates a new Memoizer instance with the provided store. The store parameter defines the underlying
-// storage mechanism for cached values.
+// storage mechanism for cached values, and the optional codec defines how values are serialized; it defaults to
+// GobCodec.
 //
 // Returns a pointer to the newly created Memoizer.
-func NewMemoizer(store internal.Store) *Memoizer {
-	return &Memoizer{Store: store}
+func NewMemoizer(store internal.Store, codec ...Codec) *Memoizer {
+	m := &Memoizer{Store: store, Codec: GobCodec{}}
+	if len(codec) > 0 && codec[0] != nil {
+		m.Codec = codec[0]
+	}
+
+	return m
 }
 
 // Close closes the Memoizer and releases any resources held by the underlying store. This method should be called when
@@ -25,3 +42,54 @@ func NewMemoizer(store internal.Store) *Memoizer {
 func (m *Memoizer) Close() error {
 	return m.Store.Close()
 }
+
+// region - Private methods
+
+func (m *Memoizer) codec() Codec {
+	// A Memoizer built as a struct literal has no codec
+	if m.Codec == nil {
+		return GobCodec{}
+	}
+
+	return m.Codec
+}
+
+// generation returns a number that changes every time the key is invalidated, either by Invalidate or InvalidateAll.
+func (m *Memoizer) generation(key string) uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.epoch + m.gens[key]
+}
+
+// setIfCurrent writes a computed value to the store, unless its key was invalidated since gen was read. The check and
+// the write happen under the lock taken by invalidate, so an invalidation can't slip in between.
+func (m *Memoizer) setIfCurrent(ctx context.Context, key string, gen uint64, payload []byte, ttl time.Duration) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.epoch+m.gens[key] == gen {
+		_ = m.Store.Set(ctx, key, payload, ttl) // best-effort cache write
+	}
+}
+
+// invalidate bumps the generation of the key, or of every key when all is true, and evicts it from the store while
+// holding the lock, so computations in flight can't write their results back afterward.
+func (m *Memoizer) invalidate(key string, all bool, evict func() error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if all {
+		m.epoch++
+	} else {
+		if m.gens == nil {
+			m.gens = make(map[string]uint64)
+		}
+		m.gens[key]++
+		m.Sf.Forget(key)
+	}
+
+	return evict()
+}
+
+// endregion
@@ -12,6 +12,8 @@ import "github.com/vegidio/go-sak/memo/internal"
 //   - MaxEntries: maximum number of entries to store (defaults to 1,000,000 if not specified)
 //   - MaxCapacity: maximum storage capacity in bytes (defaults to 1 GiB if not specified)
 //
+// The optional codec defines how values are serialized on disk; it defaults to GobCodec.
+//
 // Returns a pointer to the newly created Memoizer configured with disk storage, or an error if the disk store
 // initialization fails (e.g., due to permission issues or invalid directory path).
 //
@@ -26,11 +28,11 @@ import "github.com/vegidio/go-sak/memo/internal"
 //		log.Fatal(err)
 //	}
 //	defer memoizer.Close()
-func NewDiskOnly(directory string, opts CacheOpts) (*Memoizer, error) {
+func NewDiskOnly(directory string, opts CacheOpts, codec ...Codec) (*Memoizer, error) {
 	d, err := internal.NewDiskStore(directory, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewMemoizer(d), nil
+	return NewMemoizer(d, codec...), nil
 }
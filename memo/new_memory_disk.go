@@ -14,6 +14,7 @@ import (
 //   - path: The filesystem path where the disk cache will be stored
 //   - opts: Cache configuration options applied to both memory and disk stores
 //   - promoteTTL: Time-to-live duration for entries promoted from disk to memory
+//   - codec: Optional codec used to serialize the values; defaults to GobCodec. Use JSONCodec for human-readable entries
 //
 // # Returns:
 //   - *Memoizer: The configured memoizer instance
@@ -30,7 +31,12 @@ import (
 //	    return err
 //	}
 //	defer cleanup()
-func NewMemoryDisk(path string, opts CacheOpts, promoteTTL time.Duration) (*Memoizer, func() error, error) {
+func NewMemoryDisk(
+	path string,
+	opts CacheOpts,
+	promoteTTL time.Duration,
+	codec ...Codec,
+) (*Memoizer, func() error, error) {
 	mem, err := internal.NewMemoryStore(opts)
 	if err != nil {
 		return nil, nil, err
@@ -43,7 +49,7 @@ func NewMemoryDisk(path string, opts CacheOpts, promoteTTL time.Duration) (*Memo
 	}
 
 	comp := internal.NewCompositeStore(mem, disk, promoteTTL)
-	m := NewMemoizer(comp)
+	m := NewMemoizer(comp, codec...)
 	closeAll := func() error { return comp.Close() }
 
 	return m, closeAll, nil
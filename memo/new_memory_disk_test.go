@@ -1,6 +1,9 @@
 package memo
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -260,4 +263,58 @@ func TestNewMemoryDisk(t *testing.T) {
 		err = closeFunc()
 		assert.NoError(t, err)
 	})
+
+	t.Run("store deletes from both tiers", func(t *testing.T) {
+		mem, err := internal.NewMemoryStore(internal.CacheOpts{})
+		require.NoError(t, err)
+		disk, err := internal.NewDiskStore(t.TempDir(), internal.CacheOpts{})
+		require.NoError(t, err)
+
+		comp := internal.NewCompositeStore(mem, disk, time.Minute)
+		defer comp.Close()
+
+		ctx := context.Background()
+		require.NoError(t, comp.Set(ctx, "key", []byte("value"), time.Minute))
+
+		require.NoError(t, comp.Delete(ctx, "key"))
+
+		for _, store := range []internal.Store{mem, disk, comp} {
+			_, ok, getErr := store.Get(ctx, "key")
+			assert.NoError(t, getErr)
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("store reports the first tier error", func(t *testing.T) {
+		memErr := errors.New("memory delete failed")
+		diskErr := errors.New("disk delete failed")
+		comp := internal.NewCompositeStore(&mockStore{deleteError: memErr}, &mockStore{deleteError: diskErr}, time.Minute)
+
+		assert.Equal(t, diskErr, comp.Delete(context.Background(), "key"))
+		assert.Equal(t, diskErr, comp.Clear(context.Background()))
+	})
+
+	t.Run("compresses large disk entries but not memory ones", func(t *testing.T) {
+		mem, err := internal.NewMemoryStore(internal.CacheOpts{})
+		require.NoError(t, err)
+		disk, err := internal.NewDiskStore(t.TempDir(), internal.CacheOpts{CompressThreshold: 1024})
+		require.NoError(t, err)
+
+		m := NewMemoizer(internal.NewCompositeStore(mem, disk, time.Minute))
+		defer m.Close()
+
+		ctx := context.Background()
+		expected := strings.Repeat("large payload ", 1024)
+		require.NoError(t, Set(m, ctx, "key", expected, time.Minute))
+
+		raw, ok, err := mem.Get(ctx, "key")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Greater(t, len(raw), len(expected))
+
+		fromDisk, ok, err := Get[string](NewMemoizer(disk), ctx, "key")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, expected, fromDisk)
+	})
 }
@@ -9,6 +9,8 @@ import "github.com/vegidio/go-sak/memo/internal"
 //   - MaxEntries: maximum number of entries to store (defaults to 1,000,000 if not specified)
 //   - MaxCapacity: maximum storage capacity in bytes (defaults to 1 GiB if not specified)
 //
+// The optional codec defines how values are serialized; it defaults to GobCodec.
+//
 // Returns a pointer to the newly created Memoizer instance and nil error on success, or an error if the memory store
 // initialization fails.
 //
@@ -23,11 +25,11 @@ import "github.com/vegidio/go-sak/memo/internal"
 //		log.Fatal(err)
 //	}
 //	defer memoizer.Close()
-func NewMemoryOnly(opts CacheOpts) (*Memoizer, error) {
+func NewMemoryOnly(opts CacheOpts, codec ...Codec) (*Memoizer, error) {
 	mem, err := internal.NewMemoryStore(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewMemoizer(mem), nil
+	return NewMemoizer(mem, codec...), nil
 }
@@ -1,7 +1,9 @@
 package memo
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -110,4 +112,24 @@ func TestNewMemoryOnly(t *testing.T) {
 		err = memoizer.Close()
 		assert.NoError(t, err)
 	})
+
+	t.Run("store deletes and clears keys", func(t *testing.T) {
+		memoizer, err := NewMemoryOnly(internal.CacheOpts{})
+		require.NoError(t, err)
+		defer memoizer.Close()
+
+		ctx := context.Background()
+		require.NoError(t, memoizer.Store.Set(ctx, "a", []byte("1"), time.Minute))
+		require.NoError(t, memoizer.Store.Set(ctx, "b", []byte("2"), time.Minute))
+
+		require.NoError(t, memoizer.Store.Delete(ctx, "a"))
+		_, ok, err := memoizer.Store.Get(ctx, "a")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, memoizer.Store.Clear(ctx))
+		_, ok, err = memoizer.Store.Get(ctx, "b")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
 }
@@ -0,0 +1,58 @@
+package memo
+
+import (
+	"time"
+
+	"github.com/vegidio/go-sak/memo/internal"
+)
+
+// NewMemoryRedis creates a new Memoizer with a two-tier memory-Redis composite store, so the cache can be shared by
+// multiple instances. The composite store uses an in-memory cache as the primary tier (L1) and a Redis server as the
+// secondary tier (L2). Cache misses in memory are checked against Redis, and successful Redis hits are promoted back to
+// memory.
+//
+// # Parameters:
+//   - addr: The address of the Redis server, in the "host:port" format
+//   - redisOpts: Connection options for the Redis server, like credentials, database and key prefix
+//   - opts: Cache configuration options applied to the memory store
+//   - promoteTTL: Time-to-live duration for entries promoted from Redis to memory
+//   - codec: Optional codec used to serialize the values; defaults to GobCodec
+//
+// # Returns:
+//   - *Memoizer: The configured memoizer instance
+//   - func() error: A cleanup function that closes both stores and should be called when done
+//   - error: Any error that occurred during initialization, like the Redis server being unreachable
+//
+// Values are stored in Redis with the same codec as the other stores, and expire using the Redis key expiry.
+//
+// # Example:
+//
+//	memoizer, cleanup, err := NewMemoryRedis("localhost:6379", RedisOpts{}, CacheOpts{}, time.Minute)
+//	if err != nil {
+//	    return err
+//	}
+//	defer cleanup()
+func NewMemoryRedis(
+	addr string,
+	redisOpts RedisOpts,
+	opts CacheOpts,
+	promoteTTL time.Duration,
+	codec ...Codec,
+) (*Memoizer, func() error, error) {
+	mem, err := internal.NewMemoryStore(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	rds, err := internal.NewRedisStore(addr, redisOpts)
+
+	if err != nil {
+		mem.Close()
+		return nil, nil, err
+	}
+
+	comp := internal.NewCompositeStore(mem, rds, promoteTTL)
+	m := NewMemoizer(comp, codec...)
+	closeAll := func() error { return comp.Close() }
+
+	return m, closeAll, nil
+}
@@ -0,0 +1,113 @@
+package memo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vegidio/go-sak/memo/internal"
+)
+
+func TestNewMemoryRedis(t *testing.T) {
+	t.Run("creates memoizer with composite store", func(t *testing.T) {
+		server := miniredis.RunT(t)
+
+		memoizer, closeFunc, err := NewMemoryRedis(server.Addr(), internal.RedisOpts{}, internal.CacheOpts{}, time.Minute)
+
+		require.NoError(t, err)
+		require.NotNil(t, memoizer)
+		require.NotNil(t, closeFunc)
+		assert.IsType(t, &internal.CompositeStore{}, memoizer.Store)
+
+		err = closeFunc()
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns error for unreachable server", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		addr := server.Addr()
+		server.Close()
+
+		memoizer, closeFunc, err := NewMemoryRedis(addr, internal.RedisOpts{}, internal.CacheOpts{}, time.Minute)
+
+		assert.Error(t, err)
+		assert.Nil(t, memoizer)
+		assert.Nil(t, closeFunc)
+	})
+
+	t.Run("shares values across instances", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		ctx := context.Background()
+
+		first, closeFirst, err := NewMemoryRedis(server.Addr(), internal.RedisOpts{}, internal.CacheOpts{}, time.Minute)
+		require.NoError(t, err)
+		defer closeFirst()
+
+		second, closeSecond, err := NewMemoryRedis(server.Addr(), internal.RedisOpts{}, internal.CacheOpts{}, time.Minute)
+		require.NoError(t, err)
+		defer closeSecond()
+
+		_, err = Do(first, ctx, "key", time.Minute, func(ctx context.Context) (string, error) {
+			return "shared", nil
+		})
+		require.NoError(t, err)
+
+		callCount := 0
+		result, err := Do(second, ctx, "key", time.Minute, func(ctx context.Context) (string, error) {
+			callCount++
+			return "recomputed", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "shared", result)
+		assert.Equal(t, 0, callCount, "compute function should not be called for a value in Redis")
+	})
+
+	t.Run("stores values with prefix and expiry", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		ctx := context.Background()
+
+		store, err := internal.NewRedisStore(server.Addr(), internal.RedisOpts{Prefix: "app:"})
+		require.NoError(t, err)
+		defer store.Close()
+
+		require.NoError(t, store.Set(ctx, "key", []byte("value"), time.Minute))
+		require.NoError(t, store.Set(ctx, "forever", []byte("value"), 0))
+
+		assert.True(t, server.Exists("app:key"))
+		assert.Equal(t, time.Minute, server.TTL("app:key"))
+		assert.Zero(t, server.TTL("app:forever"))
+
+		server.FastForward(2 * time.Minute)
+		_, ok, err := store.Get(ctx, "key")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("store deletes and clears only its keys", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		ctx := context.Background()
+		require.NoError(t, server.Set("other", "untouched"))
+
+		store, err := internal.NewRedisStore(server.Addr(), internal.RedisOpts{})
+		require.NoError(t, err)
+		defer store.Close()
+
+		require.NoError(t, store.Set(ctx, "a", []byte("1"), time.Minute))
+		require.NoError(t, store.Set(ctx, "b", []byte("2"), time.Minute))
+
+		require.NoError(t, store.Delete(ctx, "a"))
+		_, ok, err := store.Get(ctx, "a")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, store.Clear(ctx))
+		_, ok, err = store.Get(ctx, "b")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.True(t, server.Exists("other"))
+	})
+}
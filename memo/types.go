@@ -3,3 +3,5 @@ package memo
 import "github.com/vegidio/go-sak/memo/internal"
 
 type CacheOpts = internal.CacheOpts
+
+type RedisOpts = internal.RedisOpts
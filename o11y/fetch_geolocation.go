@@ -24,7 +24,8 @@ type Geolocation struct {
 // FetchGeolocation retrieves geolocation information for the current public IP address.
 //
 // It makes an HTTP GET request to the ipinfo.io service to get location details including IP address, city, region,
-// country, coordinates, organization, postal code, and timezone information.
+// country, coordinates, organization, postal code, and timezone information. The request times out after 1s; use
+// FetchGeolocationCtx to control the timeout or the HTTP client.
 //
 // Returns a pointer to a Geolocation struct containing the location data, or an error if the request fails, returns a
 // non-200 status code, or the response cannot be decoded.
@@ -32,9 +33,28 @@ func FetchGeolocation(baseURL ...string) (*Geolocation, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 
+	url := ""
+	if len(baseURL) > 0 {
+		url = baseURL[0]
+	}
+
+	return FetchGeolocationCtx(ctx, url)
+}
+
+// FetchGeolocationCtx retrieves geolocation information like FetchGeolocation, but ties the request to the context
+// instead of using an internal timeout, so it can be canceled with the request scope. An empty baseURL uses ipinfo.io.
+//
+// The optional client is used to send the request, e.g. to reuse a client configured with a proxy or custom TLS
+// settings; if it's omitted or nil, http.DefaultClient is used.
+func FetchGeolocationCtx(ctx context.Context, baseURL string, client ...*http.Client) (*Geolocation, error) {
 	url := "https://ipinfo.io/json"
-	if len(baseURL) > 0 && baseURL[0] != "" {
-		url = baseURL[0] + "/json"
+	if baseURL != "" {
+		url = baseURL + "/json"
+	}
+
+	httpClient := http.DefaultClient
+	if len(client) > 0 && client[0] != nil {
+		httpClient = client[0]
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -42,7 +62,7 @@ func FetchGeolocation(baseURL ...string) (*Geolocation, error) {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch geolocation: %w", err)
 	}
@@ -0,0 +1,60 @@
+package o11y
+
+import (
+	"sync"
+	"time"
+)
+
+type geolocationEntry struct {
+	geo       Geolocation
+	fetchedAt time.Time
+}
+
+type geolocationCache struct {
+	mu      sync.Mutex
+	entries map[string]geolocationEntry
+}
+
+var geoCache geolocationCache
+
+// FetchGeolocationCached retrieves geolocation information like FetchGeolocation, but memoizes it for the given TTL, so
+// subsequent calls are served without hitting the network. A TTL <= 0 never expires. An empty baseURL uses ipinfo.io;
+// each base URL is cached separately.
+//
+// Errors are not cached, so a failed lookup is retried on the next call. It's safe for concurrent use.
+func FetchGeolocationCached(baseURL string, ttl time.Duration) (*Geolocation, error) {
+	return geoCache.get(baseURL, ttl, func() (*Geolocation, error) {
+		return FetchGeolocation(baseURL)
+	})
+}
+
+// region - Private functions
+
+func (c *geolocationCache) get(
+	key string,
+	ttl time.Duration,
+	fetch func() (*Geolocation, error),
+) (*Geolocation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok && (ttl <= 0 || time.Since(entry.fetchedAt) < ttl) {
+		geo := entry.geo
+		return &geo, nil
+	}
+
+	geo, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.entries == nil {
+		c.entries = make(map[string]geolocationEntry)
+	}
+	c.entries[key] = geolocationEntry{geo: *geo, fetchedAt: time.Now()}
+
+	cached := *geo
+	return &cached, nil
+}
+
+// endregion
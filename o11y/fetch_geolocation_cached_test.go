@@ -0,0 +1,83 @@
+package o11y
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchGeolocationCached(t *testing.T) {
+	newServer := func(t *testing.T, hits *atomic.Int32, status *atomic.Int32) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			w.WriteHeader(int(status.Load()))
+			w.Write([]byte(`{"ip": "8.8.8.8", "country": "US"}`))
+		}))
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	t.Run("serves cached results within the ttl", func(t *testing.T) {
+		var hits, status atomic.Int32
+		status.Store(http.StatusOK)
+		server := newServer(t, &hits, &status)
+
+		for range 3 {
+			geo, err := FetchGeolocationCached(server.URL, time.Hour)
+			require.NoError(t, err)
+			assert.Equal(t, "8.8.8.8", geo.IP)
+		}
+
+		assert.Equal(t, int32(1), hits.Load())
+	})
+
+	t.Run("refreshes after the ttl", func(t *testing.T) {
+		var hits, status atomic.Int32
+		status.Store(http.StatusOK)
+		server := newServer(t, &hits, &status)
+
+		_, err := FetchGeolocationCached(server.URL, 50*time.Millisecond)
+		require.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+		_, err = FetchGeolocationCached(server.URL, 50*time.Millisecond)
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), hits.Load())
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		var hits, status atomic.Int32
+		status.Store(http.StatusServiceUnavailable)
+		server := newServer(t, &hits, &status)
+
+		_, err := FetchGeolocationCached(server.URL, time.Hour)
+		assert.ErrorContains(t, err, "unexpected status code")
+
+		status.Store(http.StatusOK)
+		geo, err := FetchGeolocationCached(server.URL, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, "US", geo.Country)
+		assert.Equal(t, int32(2), hits.Load())
+	})
+
+	t.Run("callers can't modify the cached result", func(t *testing.T) {
+		var hits, status atomic.Int32
+		status.Store(http.StatusOK)
+		server := newServer(t, &hits, &status)
+
+		geo, err := FetchGeolocationCached(server.URL, time.Hour)
+		require.NoError(t, err)
+		geo.IP = "changed"
+
+		geo, err = FetchGeolocationCached(server.URL, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, "8.8.8.8", geo.IP)
+	})
+}
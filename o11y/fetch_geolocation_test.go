@@ -1,6 +1,7 @@
 package o11y
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -233,3 +234,71 @@ func TestGeolocation_Structure(t *testing.T) {
 		assert.Equal(t, "UTC", geo.Timezone)
 	})
 }
+
+func TestFetchGeolocationCtx(t *testing.T) {
+	t.Run("canceled context", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"ip": "8.8.8.8"}`))
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		geo, err := FetchGeolocationCtx(ctx, server.URL)
+		assert.Nil(t, geo)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Contains(t, err.Error(), "failed to fetch geolocation")
+	})
+
+	t.Run("deadline from the context", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := FetchGeolocationCtx(ctx, server.URL)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("custom client", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "go-sak", r.Header.Get("X-Client"))
+			w.Write([]byte(`{"ip": "8.8.8.8"}`))
+		}))
+		defer server.Close()
+
+		client := &http.Client{Transport: headerTransport{"X-Client", "go-sak"}}
+
+		geo, err := FetchGeolocationCtx(context.Background(), server.URL, client)
+		require.NoError(t, err)
+		assert.Equal(t, "8.8.8.8", geo.IP)
+	})
+
+	t.Run("nil client uses the default client", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"ip": "8.8.8.8"}`))
+		}))
+		defer server.Close()
+
+		geo, err := FetchGeolocationCtx(context.Background(), server.URL, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "8.8.8.8", geo.IP)
+	})
+}
+
+type headerTransport struct {
+	key, value string
+}
+
+func (h headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(h.key, h.value)
+	return http.DefaultTransport.RoundTrip(req)
+}
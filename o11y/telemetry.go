@@ -2,31 +2,49 @@ package o11y
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
 	"runtime"
 	"strings"
 
 	"github.com/denisbrodbeck/machineid"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/metric"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Telemetry struct {
 	logger    log.Logger
+	meter     metric.Meter
+	tracer    trace.Tracer
 	prefilled map[string]any
 	cleanup   func() error
 }
 
+// NewTelemetry creates a Telemetry that sends logs, metrics and traces to the OpenTelemetry collector at the endpoint,
+// with the logs and spans prefilled with the version, a session ID, and machine and location info. The optional protocol selects the
+// transport used to export them: OtelHTTP (the default, usually on port 4318) or OtelGRPC (usually on port 4317).
 func NewTelemetry(
 	endpoint, serviceName, version string,
 	headers map[string]string,
 	environment OtelEnvironment,
 	enabled bool,
+	protocol ...OtelProtocol,
 ) *Telemetry {
 	fields := make(map[string]any)
 
@@ -46,13 +64,28 @@ func NewTelemetry(
 		fields["location.city"] = geo.City
 	}
 
-	cleanup, _ := initLogger(endpoint, serviceName, headers, environment, enabled)
+	proto := OtelHTTP
+	if len(protocol) > 0 && protocol[0] != "" {
+		proto = protocol[0]
+	}
+
+	cleanupLogger, _ := initLogger(endpoint, serviceName, headers, environment, proto, enabled)
 	logger := global.GetLoggerProvider().Logger(serviceName)
 
+	cleanupMeter, _ := initMeter(endpoint, serviceName, headers, environment, proto, enabled)
+	meter := otel.GetMeterProvider().Meter(serviceName)
+
+	cleanupTracer, _ := initTracer(endpoint, serviceName, headers, environment, proto, enabled)
+	tracer := otel.GetTracerProvider().Tracer(serviceName)
+
 	return &Telemetry{
 		logger:    logger,
+		meter:     meter,
+		tracer:    tracer,
 		prefilled: fields,
-		cleanup:   cleanup,
+		cleanup: func() error {
+			return errors.Join(cleanupLogger(), cleanupMeter(), cleanupTracer())
+		},
 	}
 }
 
@@ -70,6 +103,7 @@ func initLogger(
 	endpoint, serviceName string,
 	headers map[string]string,
 	environment OtelEnvironment,
+	protocol OtelProtocol,
 	enabled bool,
 ) (func() error, error) {
 	if !enabled {
@@ -83,23 +117,14 @@ func initLogger(
 		return func() error { return nil }, err
 	}
 
-	exp, err := otlploghttp.New(ctx,
-		otlploghttp.WithEndpoint(parsedURL.Host),
-		otlploghttp.WithURLPath(parsedURL.Path+"/v1/logs"),
-		otlploghttp.WithHeaders(headers),
-	)
+	exp, err := newLogExporter(ctx, parsedURL, headers, protocol)
 	if err != nil {
 		return func() error { return nil }, err
 	}
 
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(serviceName),
-			semconv.DeploymentEnvironment(string(environment)),
-		),
-	)
+	res, err := newResource(ctx, serviceName, environment)
 	if err != nil {
-		return nil, err
+		return func() error { return nil }, err
 	}
 
 	lp := sdklog.NewLoggerProvider(
@@ -114,4 +139,164 @@ func initLogger(
 	}, nil
 }
 
+func newLogExporter(
+	ctx context.Context,
+	endpoint *url.URL,
+	headers map[string]string,
+	protocol OtelProtocol,
+) (sdklog.Exporter, error) {
+	switch protocol {
+	case OtelHTTP:
+		return otlploghttp.New(ctx,
+			otlploghttp.WithEndpoint(endpoint.Host),
+			otlploghttp.WithURLPath(endpoint.Path+"/v1/logs"),
+			otlploghttp.WithHeaders(headers),
+		)
+	case OtelGRPC:
+		return otlploggrpc.New(ctx,
+			otlploggrpc.WithEndpoint(endpoint.Host),
+			otlploggrpc.WithHeaders(headers),
+		)
+	default:
+		return nil, fmt.Errorf("unsupported OTel protocol: %s", protocol)
+	}
+}
+
+func initMeter(
+	endpoint, serviceName string,
+	headers map[string]string,
+	environment OtelEnvironment,
+	protocol OtelProtocol,
+	enabled bool,
+) (func() error, error) {
+	if !enabled {
+		return func() error { return nil }, nil
+	}
+
+	ctx := context.Background()
+
+	parsedURL, err := url.Parse(endpoint)
+	if err != nil {
+		return func() error { return nil }, err
+	}
+
+	exp, err := newMetricExporter(ctx, parsedURL, headers, protocol)
+	if err != nil {
+		return func() error { return nil }, err
+	}
+
+	res, err := newResource(ctx, serviceName, environment)
+	if err != nil {
+		return func() error { return nil }, err
+	}
+
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp)),
+	)
+
+	otel.SetMeterProvider(mp)
+
+	// Shutdown flushes the pending measurements before stopping
+	return func() error {
+		return mp.Shutdown(context.Background())
+	}, nil
+}
+
+func newMetricExporter(
+	ctx context.Context,
+	endpoint *url.URL,
+	headers map[string]string,
+	protocol OtelProtocol,
+) (sdkmetric.Exporter, error) {
+	switch protocol {
+	case OtelHTTP:
+		return otlpmetrichttp.New(ctx,
+			otlpmetrichttp.WithEndpoint(endpoint.Host),
+			otlpmetrichttp.WithURLPath(endpoint.Path+"/v1/metrics"),
+			otlpmetrichttp.WithHeaders(headers),
+		)
+	case OtelGRPC:
+		return otlpmetricgrpc.New(ctx,
+			otlpmetricgrpc.WithEndpoint(endpoint.Host),
+			otlpmetricgrpc.WithHeaders(headers),
+		)
+	default:
+		return nil, fmt.Errorf("unsupported OTel protocol: %s", protocol)
+	}
+}
+
+func initTracer(
+	endpoint, serviceName string,
+	headers map[string]string,
+	environment OtelEnvironment,
+	protocol OtelProtocol,
+	enabled bool,
+) (func() error, error) {
+	if !enabled {
+		return func() error { return nil }, nil
+	}
+
+	ctx := context.Background()
+
+	parsedURL, err := url.Parse(endpoint)
+	if err != nil {
+		return func() error { return nil }, err
+	}
+
+	exp, err := newSpanExporter(ctx, parsedURL, headers, protocol)
+	if err != nil {
+		return func() error { return nil }, err
+	}
+
+	res, err := newResource(ctx, serviceName, environment)
+	if err != nil {
+		return func() error { return nil }, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(exp),
+	)
+
+	otel.SetTracerProvider(tp)
+
+	// Shutdown exports the ended spans before stopping
+	return func() error {
+		return tp.Shutdown(context.Background())
+	}, nil
+}
+
+func newSpanExporter(
+	ctx context.Context,
+	endpoint *url.URL,
+	headers map[string]string,
+	protocol OtelProtocol,
+) (sdktrace.SpanExporter, error) {
+	switch protocol {
+	case OtelHTTP:
+		return otlptracehttp.New(ctx,
+			otlptracehttp.WithEndpoint(endpoint.Host),
+			otlptracehttp.WithURLPath(endpoint.Path+"/v1/traces"),
+			otlptracehttp.WithHeaders(headers),
+		)
+	case OtelGRPC:
+		return otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(endpoint.Host),
+			otlptracegrpc.WithHeaders(headers),
+		)
+	default:
+		return nil, fmt.Errorf("unsupported OTel protocol: %s", protocol)
+	}
+}
+
+func newResource(ctx context.Context, serviceName string, environment OtelEnvironment) (*resource.Resource, error) {
+	return resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(serviceName),
+			semconv.DeploymentEnvironment(string(environment)),
+		),
+	)
+}
+
 // endregion
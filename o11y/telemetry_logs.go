@@ -27,6 +27,58 @@ func (t *Telemetry) LogError(event string, fields map[string]any, err error) {
 	t.log(event, fields, log.SeverityError)
 }
 
+// Log emits a log record with the given severity, merging the prefilled fields (version, session and machine info)
+// with the fields of the call. The fields of the call take precedence over the prefilled ones with the same key.
+func (t *Telemetry) Log(severity log.Severity, event string, fields map[string]any) {
+	t.log(event, fields, severity)
+}
+
+// WithField returns an Entry with a field that's added to every record it emits, on top of the prefilled fields.
+//
+// # Example:
+//
+//	entry := tel.WithField("download.id", id).WithField("url", url)
+//	entry.LogInfo("download started", nil)
+//	entry.LogError("download failed", map[string]any{"attempt": 3}, err)
+func (t *Telemetry) WithField(key string, value any) *Entry {
+	return &Entry{telemetry: t, fields: map[string]any{key: value}}
+}
+
+// Entry is a set of fields bound to a Telemetry, created with Telemetry.WithField, that are added to every record it
+// emits.
+type Entry struct {
+	telemetry *Telemetry
+	fields    map[string]any
+}
+
+// WithField returns a new Entry with the fields of this one plus the given field; this Entry is not modified.
+func (e *Entry) WithField(key string, value any) *Entry {
+	fields := lo.Assign(e.fields)
+	fields[key] = value
+
+	return &Entry{telemetry: e.telemetry, fields: fields}
+}
+
+// Log emits a log record like Telemetry.Log, merging the prefilled fields, the fields of the entry and the fields of
+// the call, in increasing order of precedence.
+func (e *Entry) Log(severity log.Severity, event string, fields map[string]any) {
+	e.telemetry.log(event, lo.Assign(e.fields, fields), severity)
+}
+
+func (e *Entry) LogInfo(event string, fields map[string]any) {
+	e.Log(log.SeverityInfo, event, fields)
+}
+
+func (e *Entry) LogWarn(event string, fields map[string]any) {
+	e.Log(log.SeverityWarn, event, fields)
+}
+
+func (e *Entry) LogError(event string, fields map[string]any, err error) {
+	fields = lo.Assign(fields)
+	fields["error"] = err
+	e.Log(log.SeverityError, event, fields)
+}
+
 // region - Private methods
 
 func (t *Telemetry) log(event string, fields map[string]any, severity log.Severity) {
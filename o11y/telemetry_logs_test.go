@@ -1,12 +1,14 @@
 package o11y
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
 )
 
 func newTestTelemetry() *Telemetry {
@@ -105,3 +107,100 @@ func TestMapToAttributes(t *testing.T) {
 		assert.Equal(t, log.KindString, attrs[0].Value.Kind())
 	})
 }
+
+type recordingLogger struct {
+	embedded.Logger
+	records []log.Record
+}
+
+func (l *recordingLogger) Emit(_ context.Context, record log.Record) {
+	l.records = append(l.records, record.Clone())
+}
+
+func (l *recordingLogger) Enabled(context.Context, log.EnabledParameters) bool {
+	return true
+}
+
+func recordAttributes(record log.Record) map[string]string {
+	attrs := make(map[string]string)
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+		return true
+	})
+	return attrs
+}
+
+func newRecordingTelemetry() (*Telemetry, *recordingLogger) {
+	logger := &recordingLogger{}
+	tel := newTestTelemetry()
+	tel.logger = logger
+	tel.prefilled = map[string]any{"version": "1.0.0", "session.id": "session"}
+
+	return tel, logger
+}
+
+func TestLog(t *testing.T) {
+	tel, logger := newRecordingTelemetry()
+	defer tel.Close()
+
+	tel.Log(log.SeverityDebug, "cache miss", map[string]any{"key": "releases", "version": "override"})
+
+	require.Len(t, logger.records, 1)
+	record := logger.records[0]
+	assert.Equal(t, log.SeverityDebug, record.Severity())
+	assert.Equal(t, "cache miss", record.Body().AsString())
+	assert.Equal(t, map[string]string{
+		"key":        "releases",
+		"version":    "override",
+		"session.id": "session",
+	}, recordAttributes(record))
+}
+
+func TestWithField(t *testing.T) {
+	t.Run("adds the fields to every record", func(t *testing.T) {
+		tel, logger := newRecordingTelemetry()
+		defer tel.Close()
+
+		entry := tel.WithField("download.id", "42").WithField("url", "https://example.com")
+		entry.LogInfo("started", nil)
+		entry.LogWarn("slow", map[string]any{"url": "https://mirror.example.com"})
+		entry.LogError("failed", nil, errors.New("boom"))
+
+		require.Len(t, logger.records, 3)
+
+		assert.Equal(t, log.SeverityInfo, logger.records[0].Severity())
+		assert.Equal(t, map[string]string{
+			"download.id": "42",
+			"url":         "https://example.com",
+			"version":     "1.0.0",
+			"session.id":  "session",
+		}, recordAttributes(logger.records[0]))
+
+		assert.Equal(t, log.SeverityWarn, logger.records[1].Severity())
+		assert.Equal(t, "https://mirror.example.com", recordAttributes(logger.records[1])["url"])
+
+		assert.Equal(t, log.SeverityError, logger.records[2].Severity())
+		assert.Equal(t, "boom", recordAttributes(logger.records[2])["error"])
+	})
+
+	t.Run("chaining doesn't modify the parent entry", func(t *testing.T) {
+		tel, logger := newRecordingTelemetry()
+		defer tel.Close()
+
+		parent := tel.WithField("a", "1")
+		parent.WithField("b", "2")
+		parent.LogInfo("event", nil)
+
+		require.Len(t, logger.records, 1)
+		assert.NotContains(t, recordAttributes(logger.records[0]), "b")
+	})
+
+	t.Run("doesn't modify the fields of the call", func(t *testing.T) {
+		tel, _ := newRecordingTelemetry()
+		defer tel.Close()
+
+		fields := map[string]any{"op": "connect"}
+		tel.WithField("a", "1").LogError("db failure", fields, errors.New("boom"))
+		assert.Equal(t, map[string]any{"op": "connect"}, fields)
+	})
+}
@@ -0,0 +1,38 @@
+package o11y
+
+import (
+	"context"
+)
+
+// Counter returns a function that adds a delta to the counter with the given name, to track things like the number of
+// downloads or cache hits. The counter is monotonic, so the deltas should be non-negative.
+//
+// The measurements are exported to the same endpoint of the logs, and flushed when the Telemetry is closed. If the
+// counter can't be created, the returned function does nothing.
+//
+// # Example:
+//
+//	downloads := tel.Counter("downloads")
+//	downloads(1)
+func (t *Telemetry) Counter(name string) func(delta int64) {
+	counter, err := t.meter.Int64Counter(name)
+	if err != nil {
+		return func(int64) {}
+	}
+
+	return func(delta int64) {
+		counter.Add(context.Background(), delta)
+	}
+}
+
+// Gauge records the current value of the gauge with the given name, to track things like the cache hit rate or the
+// size of a queue. Like Counter, the value is exported to the same endpoint of the logs; if the gauge can't be created,
+// the value is discarded.
+func (t *Telemetry) Gauge(name string, value float64) {
+	gauge, err := t.meter.Float64Gauge(name)
+	if err != nil {
+		return
+	}
+
+	gauge.Record(context.Background(), value)
+}
@@ -0,0 +1,103 @@
+package o11y
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func newMeteredTelemetry(t *testing.T) (*Telemetry, *sdkmetric.ManualReader) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	tel := newTestTelemetry()
+	tel.meter = provider.Meter("test-service")
+
+	return tel, reader
+}
+
+func collectMetrics(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	return metrics
+}
+
+func TestCounter(t *testing.T) {
+	tel, reader := newMeteredTelemetry(t)
+	defer tel.Close()
+
+	downloads := tel.Counter("downloads")
+	downloads(1)
+	downloads(2)
+
+	// Counters with the same name are the same instrument
+	tel.Counter("downloads")(3)
+
+	sum, ok := collectMetrics(t, reader)["downloads"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.True(t, sum.IsMonotonic)
+	assert.Equal(t, int64(6), sum.DataPoints[0].Value)
+}
+
+func TestGauge(t *testing.T) {
+	tel, reader := newMeteredTelemetry(t)
+	defer tel.Close()
+
+	tel.Gauge("cache.hit_rate", 0.5)
+	tel.Gauge("cache.hit_rate", 0.75)
+
+	gauge, ok := collectMetrics(t, reader)["cache.hit_rate"].(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 1)
+	assert.Equal(t, 0.75, gauge.DataPoints[0].Value)
+}
+
+func TestMetricsDisabled(t *testing.T) {
+	tel := newTestTelemetry()
+	defer tel.Close()
+
+	assert.NotPanics(t, func() {
+		tel.Counter("downloads")(1)
+		tel.Gauge("cache.hit_rate", 0.5)
+	})
+}
+
+func TestInitMeter(t *testing.T) {
+	t.Run("returns no-op cleanup when disabled", func(t *testing.T) {
+		cleanup, err := initMeter("localhost:4318", "test-service", nil, EnvDevelopment, OtelHTTP, false)
+
+		assert.NoError(t, err)
+		assert.NoError(t, cleanup())
+	})
+
+	t.Run("creates an exporter for each protocol", func(t *testing.T) {
+		for _, protocol := range []OtelProtocol{OtelHTTP, OtelGRPC} {
+			cleanup, err := initMeter("http://localhost:4317", "test-service", nil, EnvDevelopment, protocol, true)
+
+			// Not called: without a collector, flushing the metrics over gRPC waits for the export timeout
+			require.NoError(t, err, protocol)
+			assert.NotNil(t, cleanup)
+		}
+	})
+
+	t.Run("rejects unknown protocols", func(t *testing.T) {
+		cleanup, err := initMeter("http://localhost:4317", "test-service", nil, EnvDevelopment, "carrier-pigeon", true)
+
+		assert.ErrorContains(t, err, "unsupported OTel protocol")
+		assert.NotNil(t, cleanup)
+	})
+}
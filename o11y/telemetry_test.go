@@ -316,6 +316,7 @@ func TestInitLogger(t *testing.T) {
 			"test-service",
 			nil,
 			EnvDevelopment,
+			OtelHTTP,
 			false,
 		)
 
@@ -332,6 +333,7 @@ func TestInitLogger(t *testing.T) {
 			"test-service",
 			nil,
 			EnvDevelopment,
+			OtelHTTP,
 			false,
 		)
 
@@ -345,6 +347,7 @@ func TestInitLogger(t *testing.T) {
 			"",
 			nil,
 			EnvDevelopment,
+			OtelHTTP,
 			false,
 		)
 
@@ -364,6 +367,7 @@ func TestInitLogger(t *testing.T) {
 				"test-service",
 				nil,
 				env,
+				OtelHTTP,
 				false,
 			)
 
@@ -400,3 +404,56 @@ func TestTelemetryIntegration(t *testing.T) {
 		})
 	})
 }
+
+func TestInitLoggerProtocols(t *testing.T) {
+	t.Run("creates an exporter for each protocol", func(t *testing.T) {
+		for _, protocol := range []OtelProtocol{OtelHTTP, OtelGRPC} {
+			cleanup, err := initLogger(
+				"http://localhost:4317",
+				"test-service",
+				map[string]string{"authorization": "token"},
+				EnvDevelopment,
+				protocol,
+				true,
+			)
+
+			require.NoError(t, err, protocol)
+			assert.NotPanics(t, func() {
+				cleanup()
+			})
+		}
+	})
+
+	t.Run("rejects unknown protocols", func(t *testing.T) {
+		cleanup, err := initLogger(
+			"http://localhost:4317",
+			"test-service",
+			nil,
+			EnvDevelopment,
+			OtelProtocol("carrier-pigeon"),
+			true,
+		)
+
+		assert.ErrorContains(t, err, "unsupported OTel protocol")
+		assert.NotNil(t, cleanup)
+	})
+
+	t.Run("telemetry with gRPC", func(t *testing.T) {
+		telemetry := NewTelemetry(
+			"http://localhost:4317",
+			"test-service",
+			"1.0.0",
+			nil,
+			EnvDevelopment,
+			true,
+			OtelGRPC,
+		)
+		require.NotNil(t, telemetry)
+
+		// Not closed: without a collector, flushing the metrics waits for the export timeout
+		assert.NotNil(t, telemetry.logger)
+		assert.NotNil(t, telemetry.meter)
+		assert.NotNil(t, telemetry.tracer)
+		assert.NotNil(t, telemetry.cleanup)
+	})
+}
@@ -0,0 +1,52 @@
+package o11y
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartSpan starts a span with the given name as a child of the span in the context, if any, to trace an operation
+// across subsystems. The span is seeded with the prefilled fields (version, session and machine info) as attributes.
+//
+// Returns the context carrying the new span, which should be passed to the operations it contains, and the function
+// that ends it. The spans are exported to the same endpoint of the logs when the Telemetry is closed, or periodically.
+//
+// # Example:
+//
+//	ctx, end := tel.StartSpan(ctx, "download")
+//	defer end()
+func (t *Telemetry) StartSpan(ctx context.Context, name string) (context.Context, func()) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(t.spanAttributes()...))
+	return ctx, func() { span.End() }
+}
+
+// region - Private methods
+
+func (t *Telemetry) spanAttributes() []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(t.prefilled))
+
+	for k, v := range t.prefilled {
+		switch val := v.(type) {
+		case string:
+			attrs = append(attrs, attribute.String(k, val))
+		case int:
+			attrs = append(attrs, attribute.Int(k, val))
+		case int64:
+			attrs = append(attrs, attribute.Int64(k, val))
+		case float64:
+			attrs = append(attrs, attribute.Float64(k, val))
+		case bool:
+			attrs = append(attrs, attribute.Bool(k, val))
+		default:
+			// Fallback to string representation
+			attrs = append(attrs, attribute.String(k, fmt.Sprintf("%v", val)))
+		}
+	}
+
+	return attrs
+}
+
+// endregion
@@ -0,0 +1,96 @@
+package o11y
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTracedTelemetry(t *testing.T) (*Telemetry, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	tel := newTestTelemetry()
+	tel.tracer = provider.Tracer("test-service")
+	tel.prefilled = map[string]any{"version": "1.0.0", "session.id": "session", "retries": 3}
+
+	return tel, recorder
+}
+
+func TestStartSpan(t *testing.T) {
+	t.Run("seeds the span with the prefilled fields", func(t *testing.T) {
+		tel, recorder := newTracedTelemetry(t)
+		defer tel.Close()
+
+		_, end := tel.StartSpan(context.Background(), "download")
+		end()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "download", spans[0].Name())
+		assert.ElementsMatch(t, []attribute.KeyValue{
+			attribute.String("version", "1.0.0"),
+			attribute.String("session.id", "session"),
+			attribute.Int("retries", 3),
+		}, spans[0].Attributes())
+	})
+
+	t.Run("nests spans through the context", func(t *testing.T) {
+		tel, recorder := newTracedTelemetry(t)
+		defer tel.Close()
+
+		ctx, endParent := tel.StartSpan(context.Background(), "sync")
+		_, endChild := tel.StartSpan(ctx, "memo.get")
+		endChild()
+		endParent()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "memo.get", spans[0].Name())
+		assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, spans[1].SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+	})
+
+	t.Run("doesn't panic when disabled", func(t *testing.T) {
+		tel := newTestTelemetry()
+		defer tel.Close()
+
+		assert.NotPanics(t, func() {
+			_, end := tel.StartSpan(context.Background(), "download")
+			end()
+		})
+	})
+}
+
+func TestInitTracer(t *testing.T) {
+	t.Run("returns no-op cleanup when disabled", func(t *testing.T) {
+		cleanup, err := initTracer("localhost:4318", "test-service", nil, EnvDevelopment, OtelHTTP, false)
+
+		assert.NoError(t, err)
+		assert.NoError(t, cleanup())
+	})
+
+	t.Run("creates an exporter for each protocol", func(t *testing.T) {
+		for _, protocol := range []OtelProtocol{OtelHTTP, OtelGRPC} {
+			cleanup, err := initTracer("http://localhost:4317", "test-service", nil, EnvDevelopment, protocol, true)
+
+			require.NoError(t, err, protocol)
+			assert.NotPanics(t, func() {
+				cleanup()
+			})
+		}
+	})
+
+	t.Run("rejects unknown protocols", func(t *testing.T) {
+		cleanup, err := initTracer("http://localhost:4317", "test-service", nil, EnvDevelopment, "carrier-pigeon", true)
+
+		assert.ErrorContains(t, err, "unsupported OTel protocol")
+		assert.NotNil(t, cleanup)
+	})
+}
@@ -7,3 +7,11 @@ const (
 	EnvDevelopment OtelEnvironment = "development"
 	EnvProduction  OtelEnvironment = "production"
 )
+
+// OtelProtocol specifies the transport used to export telemetry to the OpenTelemetry collector.
+type OtelProtocol string
+
+const (
+	OtelHTTP OtelProtocol = "http"
+	OtelGRPC OtelProtocol = "grpc"
+)
@@ -0,0 +1,30 @@
+package os
+
+import (
+	goos "os"
+	"slices"
+	"strings"
+)
+
+// AppendEnvPathUnique appends a path to an environment variable that contains a list of paths, unless it's already
+// there.
+//
+// It works like AppendEnvPath, but first splits the existing value on the OS-specific path list separator and does
+// nothing when one of the entries is exactly equal to the path. This makes it safe to call every time the program
+// starts, to ensure a directory is in the PATH without accumulating duplicates.
+//
+// # Parameters
+//   - envvar: the name of the environment variable to modify (e.g., "PATH")
+//   - path: the directory path to append
+//
+// # Example
+//
+//	AppendEnvPathUnique("PATH", "/opt/mytool/bin")
+func AppendEnvPathUnique(envvar string, path string) {
+	entries := strings.Split(goos.Getenv(envvar), string(goos.PathListSeparator))
+	if slices.Contains(entries, path) {
+		return
+	}
+
+	AppendEnvPath(envvar, path)
+}
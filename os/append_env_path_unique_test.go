@@ -0,0 +1,55 @@
+package os
+
+import (
+	goos "os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendEnvPathUnique(t *testing.T) {
+	sep := string(goos.PathListSeparator)
+
+	t.Run("appends a missing path", func(t *testing.T) {
+		t.Setenv("PATH", "/usr/bin")
+
+		AppendEnvPathUnique("PATH", "/custom/path")
+
+		assert.Equal(t, "/usr/bin"+sep+"/custom/path", goos.Getenv("PATH"))
+	})
+
+	t.Run("appending repeatedly adds the path once", func(t *testing.T) {
+		t.Setenv("PATH", "/usr/bin")
+
+		AppendEnvPathUnique("PATH", "/path1")
+		AppendEnvPathUnique("PATH", "/path1")
+		AppendEnvPathUnique("PATH", "/path1")
+
+		assert.Equal(t, "/usr/bin"+sep+"/path1", goos.Getenv("PATH"))
+	})
+
+	t.Run("skips a path already in the middle", func(t *testing.T) {
+		value := "/usr/bin" + sep + "/custom/path" + sep + "/bin"
+		t.Setenv("PATH", value)
+
+		AppendEnvPathUnique("PATH", "/custom/path")
+
+		assert.Equal(t, value, goos.Getenv("PATH"))
+	})
+
+	t.Run("matches entries exactly", func(t *testing.T) {
+		t.Setenv("PATH", "/usr/bin/extra")
+
+		AppendEnvPathUnique("PATH", "/usr/bin")
+
+		assert.Equal(t, "/usr/bin/extra"+sep+"/usr/bin", goos.Getenv("PATH"))
+	})
+
+	t.Run("appends to empty PATH", func(t *testing.T) {
+		t.Setenv("PATH", "")
+
+		AppendEnvPathUnique("PATH", "/new/path")
+
+		assert.Equal(t, "/new/path", goos.Getenv("PATH"))
+	})
+}
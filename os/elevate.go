@@ -0,0 +1,60 @@
+package os
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// ElevatePrivileges re-executes the current program with elevated privileges, preserving the original command-line
+// arguments while adding the specified environment variables.
+//
+// How the privileges are requested depends on the operating system:
+//   - Linux and other Unix systems: sudo, which asks for the user's password in the terminal
+//   - macOS: osascript, which shows the system's administrator prompt
+//   - Windows: ShellExecute with the "runas" verb, which shows the UAC prompt
+//
+// The function sets APP_ELEVATED=1 in the elevated process, so calling it there returns an error instead of prompting
+// again. On Windows, UAC starts the elevated process with a fresh environment, so neither envVars nor the guard reach
+// it; the process is recognized as elevated by its token instead.
+//
+// On success the current process exits once the elevated one has been started (or, on macOS, when it has finished), so
+// the function only returns on failure.
+//
+// $ Parameters:
+//   - envVars: Zero or more environment variable strings in the format "KEY=VALUE" to be added to the elevated process
+//     environment.
+//
+// # Returns an error if:
+//   - The program is already running with elevated privileges
+//   - The user declines the elevation prompt
+//   - The elevation tool can't be found or started
+//
+// # Example:
+//
+//	if err := ElevatePrivileges("INSTALL_DIR=/opt/myapp"); err != nil {
+//	    log.Fatalf("Administrator rights are required: %v", err)
+//	}
+func ElevatePrivileges(envVars ...string) error {
+	if os.Getenv(elevateGuardVar) == "1" || isElevated() {
+		return errors.New("already running with elevated privileges")
+	}
+
+	exe, err := executable()
+	if err != nil {
+		return fmt.Errorf("failed to resolve the executable: %w", err)
+	}
+
+	env := append(slices.Clone(envVars), elevateGuardVar+"=1")
+	return elevate(exe, os.Args[1:], env)
+}
+
+// region - Private functions
+
+const elevateGuardVar = "APP_ELEVATED"
+
+// errElevationDeclined is returned by elevate when the user dismisses the prompt or fails to authenticate.
+var errElevationDeclined = errors.New("elevation was declined")
+
+// endregion
@@ -0,0 +1,61 @@
+//go:build darwin
+
+package os
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+func isElevated() bool {
+	return os.Geteuid() == 0
+}
+
+// elevate runs the program through osascript's `do shell script ... with administrator privileges` and waits for it;
+// the output of the elevated process is only printed when it finishes.
+func elevate(exe string, args []string, env []string) error {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command("osascript", "-e", elevateScript(exe, args, env))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err == nil {
+		os.Exit(0)
+	}
+
+	// -128 is the AppleScript error for "User canceled"
+	if strings.Contains(stderr.String(), "(-128)") {
+		return errElevationDeclined
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		os.Stderr.Write(stderr.Bytes())
+		os.Exit(exitErr.ExitCode())
+	}
+
+	return fmt.Errorf("failed to run osascript: %w", err)
+}
+
+// elevateScript builds the AppleScript that runs exe with the arguments and environment additions as an administrator.
+func elevateScript(exe string, args []string, env []string) string {
+	words := append([]string{"env"}, env...)
+	words = append(words, exe)
+	words = append(words, args...)
+
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+	}
+
+	command := strings.Join(quoted, " ")
+	command = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(command)
+
+	return fmt.Sprintf(`do shell script "%s" with administrator privileges`, command)
+}
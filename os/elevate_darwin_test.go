@@ -0,0 +1,18 @@
+//go:build darwin
+
+package os
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElevateScript(t *testing.T) {
+	t.Run("quotes the command for the shell and AppleScript", func(t *testing.T) {
+		script := elevateScript("/Applications/My App/app", []string{"--name", `it's "quoted"`}, []string{"APP_ELEVATED=1"})
+
+		assert.Equal(t, `do shell script "'env' 'APP_ELEVATED=1' '/Applications/My App/app' '--name' `+
+			`'it'\\''s \"quoted\"'" with administrator privileges`, script)
+	})
+}
@@ -0,0 +1,29 @@
+package os
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElevatePrivileges(t *testing.T) {
+	t.Run("returns an error when APP_ELEVATED is already set", func(t *testing.T) {
+		t.Setenv("APP_ELEVATED", "1")
+
+		err := ElevatePrivileges("TEST=value")
+
+		assert.EqualError(t, err, "already running with elevated privileges")
+	})
+
+	t.Run("returns an error when already elevated", func(t *testing.T) {
+		if !isElevated() {
+			t.Skip("Skipping test that requires elevated privileges")
+		}
+
+		t.Setenv("APP_ELEVATED", "")
+
+		err := ElevatePrivileges()
+
+		assert.EqualError(t, err, "already running with elevated privileges")
+	})
+}
@@ -0,0 +1,42 @@
+//go:build !windows && !darwin
+
+package os
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+func isElevated() bool {
+	return os.Geteuid() == 0
+}
+
+// elevate asks for the password with `sudo -v` first, so a declined prompt can be told apart from a failure of the
+// program itself, and then replaces the current process with `sudo -n env`, which reuses the cached credentials.
+func elevate(exe string, args []string, env []string) error {
+	sudo, err := exec.LookPath("sudo")
+	if err != nil {
+		return fmt.Errorf("failed to find sudo: %w", err)
+	}
+
+	validate := exec.Command(sudo, "-v")
+	validate.Stdin = os.Stdin
+	validate.Stdout = os.Stdout
+	validate.Stderr = os.Stderr
+
+	if err = validate.Run(); err != nil {
+		return fmt.Errorf("%w: %w", errElevationDeclined, err)
+	}
+
+	// sudo resets the environment, so the additions are passed through env
+	argv := append([]string{"sudo", "-n", "env"}, env...)
+	argv = append(argv, exe)
+	argv = append(argv, args...)
+
+	if err = execProcess(sudo, argv, os.Environ(), false); err != nil {
+		return fmt.Errorf("failed to re-execute with sudo: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+//go:build windows
+
+package os
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+func isElevated() bool {
+	return windows.GetCurrentProcessToken().IsElevated()
+}
+
+// elevate starts the program with the "runas" verb, which shows the UAC prompt. The elevated process gets a fresh
+// environment, so env can't be passed to it.
+func elevate(exe string, args []string, _ []string) error {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = syscall.EscapeArg(arg)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get the working directory: %w", err)
+	}
+
+	verb, _ := windows.UTF16PtrFromString("runas")
+	file, _ := windows.UTF16PtrFromString(exe)
+	params, _ := windows.UTF16PtrFromString(strings.Join(quoted, " "))
+	dir, _ := windows.UTF16PtrFromString(cwd)
+
+	err = windows.ShellExecute(0, verb, file, params, dir, windows.SW_NORMAL)
+	if errors.Is(err, windows.ERROR_CANCELLED) {
+		return errElevationDeclined
+	}
+	if err != nil {
+		return fmt.Errorf("failed to start the elevated process: %w", err)
+	}
+
+	os.Exit(0)
+	return nil
+}
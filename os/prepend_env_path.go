@@ -0,0 +1,33 @@
+package os
+
+import goos "os"
+
+// PrependEnvPath prepends a path to an environment variable that contains a list of paths.
+//
+// It works like AppendEnvPath, but inserts the path at the front of the existing value using the OS-specific path list
+// separator, so the directory takes precedence over the ones already in the list.
+//
+// # Parameters
+//   - envvar: the name of the environment variable to modify (e.g., "PATH")
+//   - path: the directory path to prepend
+//
+// # Example
+//
+//	PrependEnvPath("PATH", "/opt/mytool/bin")
+func PrependEnvPath(envvar string, path string) {
+	existingValue := goos.Getenv(envvar)
+
+	// If the variable is empty, just set it to the path without a separator
+	if existingValue == "" {
+		goos.Setenv(envvar, path)
+		return
+	}
+
+	// Check if the existing value already starts with a separator
+	separator := string(goos.PathListSeparator)
+	if existingValue[:1] == separator {
+		goos.Setenv(envvar, path+existingValue)
+	} else {
+		goos.Setenv(envvar, path+separator+existingValue)
+	}
+}
@@ -0,0 +1,45 @@
+package os
+
+import (
+	goos "os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrependEnvPath(t *testing.T) {
+	sep := string(goos.PathListSeparator)
+
+	t.Run("prepends path to existing PATH", func(t *testing.T) {
+		t.Setenv("PATH", "/usr/bin"+sep+"/bin")
+
+		PrependEnvPath("PATH", "/custom/path")
+
+		assert.Equal(t, "/custom/path"+sep+"/usr/bin"+sep+"/bin", goos.Getenv("PATH"))
+	})
+
+	t.Run("prepends path to empty PATH", func(t *testing.T) {
+		t.Setenv("PATH", "")
+
+		PrependEnvPath("PATH", "/new/path")
+
+		assert.Equal(t, "/new/path", goos.Getenv("PATH"))
+	})
+
+	t.Run("does not duplicate a leading separator", func(t *testing.T) {
+		t.Setenv("PATH", sep+"/usr/bin")
+
+		PrependEnvPath("PATH", "/new/path")
+
+		assert.Equal(t, "/new/path"+sep+"/usr/bin", goos.Getenv("PATH"))
+	})
+
+	t.Run("prepends multiple paths sequentially", func(t *testing.T) {
+		t.Setenv("PATH", "/usr/bin")
+
+		PrependEnvPath("PATH", "/path1")
+		PrependEnvPath("PATH", "/path2")
+
+		assert.Equal(t, "/path2"+sep+"/path1"+sep+"/usr/bin", goos.Getenv("PATH"))
+	})
+}
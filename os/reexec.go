@@ -1,9 +1,9 @@
 package os
 
 import (
+	"fmt"
 	"os"
 	"strings"
-	"syscall"
 )
 
 // ReExec re-executes the current program with additional environment variables.
@@ -25,26 +25,112 @@ import (
 //
 //	ReExec("DEBUG=1", "LOG_LEVEL=trace")
 func ReExec(envVars ...string) {
-	if os.Getenv("APP_REEXEC") == "1" {
-		return
+	reExec(defaultGuardVar, nil, envVars, false)
+}
+
+// ReExecArgs re-executes the current program like ReExec, but with a different list of command-line arguments.
+//
+// The guard against infinite recursion and the handling of the environment are the same as in ReExec.
+//
+// $ Parameters:
+//   - args: The command-line arguments of the new process, without the program name; when nil, the original arguments
+//     (os.Args[1:]) are used.
+//   - envVars: Zero or more environment variable strings in the format "KEY=VALUE" to be added to the new process
+//     environment.
+//
+// # Example:
+//
+//	ReExecArgs(append(os.Args[1:], "--elevated"), "DEBUG=1")
+func ReExecArgs(args []string, envVars ...string) {
+	reExec(defaultGuardVar, args, envVars, false)
+}
+
+// ReExecWith re-executes the current program like ReExec, but uses guardVar instead of APP_REEXEC to prevent infinite
+// recursion.
+//
+// Giving each program its own guard matters when programs that use ReExec launch each other: with the shared
+// APP_REEXEC, a child inherits the guard from its parent and never re-executes.
+//
+// $ Parameters:
+//   - guardVar: The name of the environment variable set to "1" in the re-executed process (e.g., "MYAPP_REEXEC").
+//   - envVars: Zero or more environment variable strings in the format "KEY=VALUE" to be added to the new process
+//     environment.
+//
+// # Example:
+//
+//	ReExecWith("MYAPP_REEXEC", "LD_LIBRARY_PATH=/opt/myapp/lib")
+func ReExecWith(guardVar string, envVars ...string) {
+	reExec(guardVar, nil, envVars, false)
+}
+
+// ReExecE re-executes the current program like ReExec, but returns an error when the re-execution fails instead of
+// carrying on silently.
+//
+// On Windows, which can't replace a running process, the program is started again as a child process; the current
+// process waits for it and then exits with the child's exit code. ReExec, ReExecArgs and ReExecWith don't do this, so
+// on Windows they return without re-executing.
+//
+// $ Parameters:
+//   - envVars: Zero or more environment variable strings in the format "KEY=VALUE" to be added to the new process
+//     environment.
+//
+// # Returns:
+//   - nil if APP_REEXEC is already set to "1", so there's nothing to do; otherwise it only returns on failure.
+//   - An error if the path of the executable can't be resolved or the new process can't be started.
+//
+// # Example:
+//
+//	if err := ReExecE("LD_LIBRARY_PATH=/opt/myapp/lib"); err != nil {
+//	    log.Printf("Running without the bundled libraries: %v", err)
+//	}
+func ReExecE(envVars ...string) error {
+	return reExec(defaultGuardVar, nil, envVars, true)
+}
+
+// region - Private functions
+
+const defaultGuardVar = "APP_REEXEC"
+
+// executable resolves the path of the current program; it's a variable so tests can make it fail.
+var executable = os.Executable
+
+// reExec re-executes the current program; with emulate, platforms that can't replace a running process (Windows) run it
+// as a child process instead.
+func reExec(guardVar string, args []string, envVars []string, emulate bool) error {
+	if os.Getenv(guardVar) == "1" {
+		return nil
+	}
+
+	exe, err := executable()
+	if err != nil {
+		return fmt.Errorf("failed to resolve the executable: %w", err)
 	}
 
-	exe, _ := os.Executable()
+	argv := os.Args
+	if args != nil {
+		argv = append([]string{os.Args[0]}, args...)
+	}
 
-	// Start from the current environment minus any prior APP_REEXEC entry.
-	// Without this, a caller that explicitly set APP_REEXEC to a non-"1" value would leak a duplicate entry into the
+	// Start from the current environment minus any prior guard entry.
+	// Without this, a caller that explicitly set the guard to a non-"1" value would leak a duplicate entry into the
 	// re-executed process; POSIX getenv returns the first match, so the guard above would fail to trigger and recursion
 	// would not stop.
 	current := os.Environ()
 	env := make([]string, 0, len(current)+len(envVars)+1)
 	for _, kv := range current {
-		if !strings.HasPrefix(kv, "APP_REEXEC=") {
+		if !strings.HasPrefix(kv, guardVar+"=") {
 			env = append(env, kv)
 		}
 	}
 
-	env = append(env, "APP_REEXEC=1")
+	env = append(env, guardVar+"=1")
 	env = append(env, envVars...)
 
-	syscall.Exec(exe, os.Args, env)
+	if err = execProcess(exe, argv, env, emulate); err != nil {
+		return fmt.Errorf("failed to re-execute %s: %w", exe, err)
+	}
+
+	return nil
 }
+
+// endregion
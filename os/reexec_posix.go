@@ -0,0 +1,10 @@
+//go:build !windows
+
+package os
+
+import "syscall"
+
+// execProcess replaces the current process with exe; it only returns if that fails. There's nothing to emulate here.
+func execProcess(exe string, argv []string, env []string, _ bool) error {
+	return syscall.Exec(exe, argv, env)
+}
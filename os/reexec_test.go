@@ -1,8 +1,11 @@
 package os
 
 import (
+	"errors"
+	"flag"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -215,3 +218,158 @@ func TestReExec_EdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestReExecArgs(t *testing.T) {
+	t.Run("subprocess re-executes with the given arguments", func(t *testing.T) {
+		// Stage 2: re-executed process, which must have received the new arguments
+		if os.Getenv("TEST_REEXEC_ARGS_STAGE2") == "1" {
+			assert.Equal(t, "1", os.Getenv("APP_REEXEC"))
+			assert.Equal(t, []string{"--elevated"}, flag.Args())
+			assert.NotContains(t, os.Args, "original")
+			os.Exit(50)
+		}
+
+		// Stage 1: initial subprocess, which replaces its arguments; "--" stops the test flags from parsing "--elevated"
+		if os.Getenv("TEST_REEXEC_ARGS_STAGE1") == "1" {
+			ReExecArgs(
+				[]string{"-test.run=TestReExecArgs/subprocess_re-executes_with_the_given_arguments", "--", "--elevated"},
+				"TEST_REEXEC_ARGS_STAGE2=1",
+			)
+			t.Fatal("syscall.Exec did not replace the process")
+		}
+
+		cmd := exec.Command(os.Args[0], "-test.run=TestReExecArgs/subprocess_re-executes_with_the_given_arguments",
+			"--", "original")
+		cmd.Env = append(os.Environ(), "TEST_REEXEC_ARGS_STAGE1=1")
+
+		err := cmd.Run()
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			assert.Equal(t, 50, exitErr.ExitCode())
+		} else {
+			t.Fatalf("expected exit error with code 50, got: %v", err)
+		}
+	})
+
+	t.Run("subprocess keeps the original arguments when nil", func(t *testing.T) {
+		if os.Getenv("TEST_REEXEC_ARGS_NIL_STAGE2") == "1" {
+			assert.Equal(t, []string{"original"}, flag.Args())
+			os.Exit(51)
+		}
+
+		if os.Getenv("TEST_REEXEC_ARGS_NIL_STAGE1") == "1" {
+			ReExecArgs(nil, "TEST_REEXEC_ARGS_NIL_STAGE2=1")
+			t.Fatal("syscall.Exec did not replace the process")
+		}
+
+		cmd := exec.Command(os.Args[0], "-test.run=TestReExecArgs/subprocess_keeps_the_original_arguments_when_nil",
+			"--", "original")
+		cmd.Env = append(os.Environ(), "TEST_REEXEC_ARGS_NIL_STAGE1=1")
+
+		err := cmd.Run()
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			assert.Equal(t, 51, exitErr.ExitCode())
+		} else {
+			t.Fatalf("expected exit error with code 51, got: %v", err)
+		}
+	})
+
+	t.Run("does not re-execute when APP_REEXEC is already set", func(t *testing.T) {
+		t.Setenv("APP_REEXEC", "1")
+
+		ReExecArgs([]string{"--elevated"}, "TEST=value")
+
+		assert.Equal(t, "1", os.Getenv("APP_REEXEC"))
+	})
+}
+
+func TestReExecWith(t *testing.T) {
+	t.Run("subprocess re-executes with a custom guard", func(t *testing.T) {
+		// Stage 2: re-executed process, guarded by its own variable only
+		if os.Getenv("TEST_REEXEC_WITH_STAGE2") == "1" {
+			assert.Equal(t, "1", os.Getenv("MYAPP_REEXEC"))
+			assert.Equal(t, "1", os.Getenv("APP_REEXEC"), "the default guard is left as inherited")
+			os.Exit(52)
+		}
+
+		// Stage 1: the default guard is set, as if launched by another re-executed program, but it doesn't apply here
+		if os.Getenv("TEST_REEXEC_WITH_STAGE1") == "1" {
+			ReExecWith("MYAPP_REEXEC", "TEST_REEXEC_WITH_STAGE2=1")
+			t.Fatal("syscall.Exec did not replace the process")
+		}
+
+		cmd := exec.Command(os.Args[0], "-test.run=TestReExecWith/subprocess_re-executes_with_a_custom_guard")
+		cmd.Env = append(os.Environ(), "TEST_REEXEC_WITH_STAGE1=1", "APP_REEXEC=1")
+
+		err := cmd.Run()
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			assert.Equal(t, 52, exitErr.ExitCode())
+		} else {
+			t.Fatalf("expected exit error with code 52, got: %v", err)
+		}
+	})
+
+	t.Run("does not re-execute when the custom guard is already set", func(t *testing.T) {
+		t.Setenv("MYAPP_REEXEC", "1")
+
+		ReExecWith("MYAPP_REEXEC", "TEST=value")
+
+		assert.Equal(t, "1", os.Getenv("MYAPP_REEXEC"))
+	})
+}
+
+func TestReExecE(t *testing.T) {
+	withExecutable := func(t *testing.T, fn func() (string, error)) {
+		previous := executable
+		executable = fn
+		t.Cleanup(func() { executable = previous })
+	}
+
+	t.Run("subprocess re-executes with additional environment variables", func(t *testing.T) {
+		if os.Getenv("TEST_REEXEC_E_STAGE2") == "1" {
+			assert.Equal(t, "1", os.Getenv("APP_REEXEC"))
+			assert.Equal(t, "custom_value", os.Getenv("CUSTOM_VAR"))
+			os.Exit(53)
+		}
+
+		if os.Getenv("TEST_REEXEC_E_STAGE1") == "1" {
+			err := ReExecE("TEST_REEXEC_E_STAGE2=1", "CUSTOM_VAR=custom_value")
+			t.Fatalf("ReExecE did not replace the process: %v", err)
+		}
+
+		cmd := exec.Command(os.Args[0],
+			"-test.run=TestReExecE/subprocess_re-executes_with_additional_environment_variables")
+		cmd.Env = append(os.Environ(), "TEST_REEXEC_E_STAGE1=1")
+
+		err := cmd.Run()
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			assert.Equal(t, 53, exitErr.ExitCode())
+		} else {
+			t.Fatalf("expected exit error with code 53, got: %v", err)
+		}
+	})
+
+	t.Run("returns nil when APP_REEXEC is already set", func(t *testing.T) {
+		t.Setenv("APP_REEXEC", "1")
+
+		assert.NoError(t, ReExecE("TEST=value"))
+	})
+
+	t.Run("returns an error when the executable can't be resolved", func(t *testing.T) {
+		t.Setenv("APP_REEXEC", "")
+		withExecutable(t, func() (string, error) { return "", errors.New("not supported") })
+
+		err := ReExecE()
+
+		assert.EqualError(t, err, "failed to resolve the executable: not supported")
+	})
+
+	t.Run("returns an error when the executable can't be started", func(t *testing.T) {
+		t.Setenv("APP_REEXEC", "")
+		missing := filepath.Join(t.TempDir(), "missing")
+		withExecutable(t, func() (string, error) { return missing, nil })
+
+		err := ReExecE()
+
+		assert.ErrorContains(t, err, "failed to re-execute "+missing)
+	})
+}
@@ -0,0 +1,38 @@
+//go:build windows
+
+package os
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// execProcess emulates exec, which Windows doesn't have, by running exe as a child process with the same standard
+// streams and exiting with its exit code; it only returns if the child can't be started. Without emulate, it keeps the
+// plain syscall.Exec, which always fails on Windows, so the current program carries on.
+func execProcess(exe string, argv []string, env []string, emulate bool) error {
+	if !emulate {
+		return syscall.Exec(exe, argv, env)
+	}
+
+	cmd := exec.Command(exe, argv[1:]...)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.ExitCode())
+	}
+	if err != nil {
+		return err
+	}
+
+	os.Exit(0)
+	return nil
+}
@@ -0,0 +1,42 @@
+package os
+
+import (
+	goos "os"
+	"strings"
+)
+
+// RemoveEnvPath removes a path from an environment variable that contains a list of paths.
+//
+// It splits the existing value on the OS-specific path list separator, drops every entry that is exactly equal to the
+// path, and joins the remaining entries back. The variable is left untouched when it's empty or doesn't contain the
+// path.
+//
+// # Parameters
+//   - envvar: the name of the environment variable to modify (e.g., "PATH")
+//   - path: the directory path to remove
+//
+// # Example
+//
+//	RemoveEnvPath("PATH", "/opt/mytool/bin")
+func RemoveEnvPath(envvar string, path string) {
+	existingValue := goos.Getenv(envvar)
+	if existingValue == "" {
+		return
+	}
+
+	separator := string(goos.PathListSeparator)
+	entries := strings.Split(existingValue, separator)
+	kept := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry != path {
+			kept = append(kept, entry)
+		}
+	}
+
+	if len(kept) == len(entries) {
+		return
+	}
+
+	goos.Setenv(envvar, strings.Join(kept, separator))
+}
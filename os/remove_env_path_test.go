@@ -0,0 +1,70 @@
+package os
+
+import (
+	goos "os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveEnvPath(t *testing.T) {
+	sep := string(goos.PathListSeparator)
+
+	t.Run("removes path from the middle", func(t *testing.T) {
+		t.Setenv("PATH", "/usr/bin"+sep+"/custom/path"+sep+"/bin")
+
+		RemoveEnvPath("PATH", "/custom/path")
+
+		assert.Equal(t, "/usr/bin"+sep+"/bin", goos.Getenv("PATH"))
+	})
+
+	t.Run("removes first and last paths without stray separators", func(t *testing.T) {
+		t.Setenv("PATH", "/first"+sep+"/usr/bin"+sep+"/last")
+
+		RemoveEnvPath("PATH", "/first")
+		RemoveEnvPath("PATH", "/last")
+
+		assert.Equal(t, "/usr/bin", goos.Getenv("PATH"))
+	})
+
+	t.Run("removes every occurrence", func(t *testing.T) {
+		t.Setenv("PATH", "/dup"+sep+"/usr/bin"+sep+"/dup")
+
+		RemoveEnvPath("PATH", "/dup")
+
+		assert.Equal(t, "/usr/bin", goos.Getenv("PATH"))
+	})
+
+	t.Run("removing the only path leaves it empty", func(t *testing.T) {
+		t.Setenv("PATH", "/only")
+
+		RemoveEnvPath("PATH", "/only")
+
+		assert.Empty(t, goos.Getenv("PATH"))
+	})
+
+	t.Run("matches entries exactly", func(t *testing.T) {
+		value := "/usr/bin" + sep + "/usr/bin/extra"
+		t.Setenv("PATH", value)
+
+		RemoveEnvPath("PATH", "/usr")
+
+		assert.Equal(t, value, goos.Getenv("PATH"))
+	})
+
+	t.Run("path not present", func(t *testing.T) {
+		t.Setenv("PATH", "/usr/bin"+sep)
+
+		RemoveEnvPath("PATH", "/missing")
+
+		assert.Equal(t, "/usr/bin"+sep, goos.Getenv("PATH"))
+	})
+
+	t.Run("empty PATH", func(t *testing.T) {
+		t.Setenv("PATH", "")
+
+		RemoveEnvPath("PATH", "/missing")
+
+		assert.Empty(t, goos.Getenv("PATH"))
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"math"
 	"os"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -12,11 +14,24 @@ import (
 )
 
 type CPUInfo struct {
-	Name  string
-	Cores uint
+	Name          string
+	Cores         uint // logical cores
+	PhysicalCores uint // 0 when unknown
+	ClockMHz      uint // base clock; 0 when unknown
 }
 
-// GetCPUInfo returns the CPU model/name and the number of logical cores on Linux, macOS and Windows.
+var cpuClockInName = regexp.MustCompile(`@\s*([0-9.]+)\s*GHz`)
+
+// GetCPUInfo returns the CPU model/name, the number of logical and physical cores, and the base clock on Linux, macOS
+// and Windows.
+//
+// Backends (best-effort):
+//   - Linux: /proc/cpuinfo (lscpu fallback for the name); base clock from cpufreq sysfs or the model name
+//   - macOS: sysctl machdep.cpu.brand_string, hw.logicalcpu, hw.physicalcpu and hw.cpufrequency
+//   - Windows: PowerShell CIM Win32_Processor
+//
+// Only the name and the logical core count are required; the physical core count and the base clock are 0 when they
+// can't be determined, e.g. the clock on Apple Silicon.
 func GetCPUInfo() (CPUInfo, error) {
 	var cpu CPUInfo
 	var err error
@@ -42,44 +57,92 @@ func GetCPUInfo() (CPUInfo, error) {
 // region - Linux
 
 func linuxCPUInfo() (CPUInfo, error) {
-	// Model name from /proc/cpuinfo; logical cores via runtime.NumCPU().
-	name := parseCPUNameFromProcInfo()
+	// Model name and physical cores from /proc/cpuinfo; logical cores via runtime.NumCPU().
+	var cpu CPUInfo
+	if b, err := os.ReadFile("/proc/cpuinfo"); err == nil {
+		cpu = parseProcCPUInfo(string(b))
+	}
 
-	if name == "" {
-		name = parseCPUNameFromLscpu()
+	if cpu.Name == "" {
+		cpu.Name = parseCPUNameFromLscpu()
 	}
 
-	cores := uint(runtime.NumCPU())
-	if cores == 0 {
+	cpu.Cores = uint(runtime.NumCPU())
+	if cpu.Cores == 0 {
 		return CPUInfo{}, errors.New("could not determine core count")
 	}
 
-	if name == "" {
-		name = "Unknown CPU"
+	// base_frequency is only exposed by some drivers (e.g. intel_pstate), in kHz
+	if khz := readUint64File("/sys/devices/system/cpu/cpu0/cpufreq/base_frequency"); khz > 0 {
+		cpu.ClockMHz = uint(khz / 1000)
+	} else {
+		cpu.ClockMHz = parseClockFromCPUName(cpu.Name)
 	}
 
-	return CPUInfo{Name: name, Cores: cores}, nil
+	if cpu.Name == "" {
+		cpu.Name = "Unknown CPU"
+	}
+
+	return cpu, nil
 }
 
-func parseCPUNameFromProcInfo() string {
-	b, err := os.ReadFile("/proc/cpuinfo")
-	if err != nil {
-		return ""
+// parseProcCPUInfo returns the name and the number of physical cores found in the content of /proc/cpuinfo.
+func parseProcCPUInfo(content string) CPUInfo {
+	var cpu CPUInfo
+	var physicalID, coreID string
+	cores := make(map[string]struct{})
+
+	addCore := func() {
+		if coreID != "" {
+			cores[physicalID+":"+coreID] = struct{}{}
+		}
+		physicalID, coreID = "", ""
 	}
 
-	// x86: "model name\t: Intel(R)..."
-	// ARM: sometimes "Hardware\t: ..." or "Processor\t: ..."
-	for _, line := range strings.Split(string(b), "\n") {
-		if strings.HasPrefix(line, "model name") || strings.HasPrefix(line, "Processor") || strings.HasPrefix(line, "Hardware") {
-			if parts := strings.SplitN(line, ":", 2); len(parts) == 2 {
-				if name := strings.TrimSpace(parts[1]); name != "" {
-					return name
-				}
+	for _, line := range strings.Split(content, "\n") {
+		key, val, ok := strings.Cut(line, ":")
+		if !ok {
+			// Blank lines separate the processors
+			addCore()
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		val = strings.TrimSpace(val)
+
+		switch key {
+		// x86: "model name\t: Intel(R)..."
+		// ARM: sometimes "Hardware\t: ..." or "Processor\t: ..."
+		case "model name", "Processor", "Hardware":
+			if cpu.Name == "" {
+				cpu.Name = val
 			}
+		case "physical id":
+			physicalID = val
+		case "core id":
+			coreID = val
 		}
 	}
 
-	return ""
+	addCore()
+	cpu.PhysicalCores = uint(len(cores))
+
+	return cpu
+}
+
+// parseClockFromCPUName returns the clock in MHz from CPU names like "Intel(R) Core(TM) i7-8700 CPU @ 3.20GHz".
+func parseClockFromCPUName(name string) uint {
+	m := cpuClockInName.FindStringSubmatch(name)
+	if m == nil {
+		return 0
+	}
+
+	ghz, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0
+	}
+
+	return uint(math.Round(ghz * 1000))
 }
 
 func parseCPUNameFromLscpu() string {
@@ -134,7 +197,29 @@ func macCPUInfo() (CPUInfo, error) {
 		return CPUInfo{}, errors.New("could not parse core count")
 	}
 
-	return CPUInfo{Name: name, Cores: uint(c64)}, nil
+	cpu := CPUInfo{Name: name, Cores: uint(c64)}
+
+	// Best-effort; hw.cpufrequency (Hz) doesn't exist on Apple Silicon.
+	if physical, ok := sysctlUint("hw.physicalcpu"); ok {
+		cpu.PhysicalCores = uint(physical)
+	}
+	if hz, ok := sysctlUint("hw.cpufrequency"); ok {
+		cpu.ClockMHz = uint(hz / 1_000_000)
+	} else {
+		cpu.ClockMHz = parseClockFromCPUName(name)
+	}
+
+	return cpu, nil
+}
+
+func sysctlUint(name string) (uint64, bool) {
+	out, err := run("sysctl", "-n", name)
+	if err != nil {
+		return 0, false
+	}
+
+	n, err := strconv.ParseUint(strings.TrimSpace(string(out)), 10, 64)
+	return n, err == nil && n > 0
 }
 
 // endregion
@@ -142,9 +227,9 @@ func macCPUInfo() (CPUInfo, error) {
 // region - Windows
 
 func windowsCPUInfo() (CPUInfo, error) {
-	// Use CIM for name; logical cores via NumberOfLogicalProcessors.
+	// Use CIM for name; logical cores via NumberOfLogicalProcessors; base clock via MaxClockSpeed (MHz).
 	ps := strings.Join([]string{
-		"$c=Get-CimInstance Win32_Processor | Select-Object -First 1 Name,NumberOfLogicalProcessors;",
+		"$c=Get-CimInstance Win32_Processor | Select-Object -First 1 Name,NumberOfCores,NumberOfLogicalProcessors,MaxClockSpeed;",
 		"$c | ConvertTo-Json -Depth 2",
 	}, " ")
 
@@ -160,7 +245,9 @@ func windowsCPUInfo() (CPUInfo, error) {
 
 	type row struct {
 		Name                      string `json:"Name"`
+		NumberOfCores             any    `json:"NumberOfCores"`
 		NumberOfLogicalProcessors any    `json:"NumberOfLogicalProcessors"`
+		MaxClockSpeed             any    `json:"MaxClockSpeed"`
 	}
 
 	var r row
@@ -178,7 +265,11 @@ func windowsCPUInfo() (CPUInfo, error) {
 		return CPUInfo{}, errors.New("could not read NumberOfLogicalProcessors")
 	}
 
-	return CPUInfo{Name: name, Cores: uint(c64)}, nil
+	// Best-effort; left as 0 when missing.
+	physical, _ := anyToUint64(r.NumberOfCores)
+	mhz, _ := anyToUint64(r.MaxClockSpeed)
+
+	return CPUInfo{Name: name, Cores: uint(c64), PhysicalCores: uint(physical), ClockMHz: uint(mhz)}, nil
 }
 
 // endregion
@@ -22,3 +22,53 @@ func TestGetCPUInfo(t *testing.T) {
 	assert.NotEmpty(t, info.Name, "CPU name should be populated")
 	assert.Greater(t, info.Cores, uint(0), "core count must be positive")
 }
+
+func TestParseProcCPUInfo(t *testing.T) {
+	t.Run("x86 with hyper-threading", func(t *testing.T) {
+		content := `processor	: 0
+model name	: Intel(R) Core(TM) i7-8700 CPU @ 3.20GHz
+physical id	: 0
+core id		: 0
+
+processor	: 1
+model name	: Intel(R) Core(TM) i7-8700 CPU @ 3.20GHz
+physical id	: 0
+core id		: 1
+
+processor	: 2
+model name	: Intel(R) Core(TM) i7-8700 CPU @ 3.20GHz
+physical id	: 0
+core id		: 0
+
+processor	: 3
+model name	: Intel(R) Core(TM) i7-8700 CPU @ 3.20GHz
+physical id	: 0
+core id		: 1
+`
+		info := parseProcCPUInfo(content)
+		assert.Equal(t, "Intel(R) Core(TM) i7-8700 CPU @ 3.20GHz", info.Name)
+		assert.Equal(t, uint(2), info.PhysicalCores)
+	})
+
+	t.Run("multiple sockets", func(t *testing.T) {
+		content := "model name : Xeon\nphysical id : 0\ncore id : 0\n\nmodel name : Xeon\nphysical id : 1\ncore id : 0\n"
+
+		info := parseProcCPUInfo(content)
+		assert.Equal(t, uint(2), info.PhysicalCores)
+	})
+
+	t.Run("ARM without core ids", func(t *testing.T) {
+		content := "processor : 0\nBogoMIPS : 108.00\n\nprocessor : 1\nBogoMIPS : 108.00\n\nHardware : BCM2835\n"
+
+		info := parseProcCPUInfo(content)
+		assert.Equal(t, "BCM2835", info.Name)
+		assert.Zero(t, info.PhysicalCores, "physical cores should be unknown")
+	})
+}
+
+func TestParseClockFromCPUName(t *testing.T) {
+	assert.Equal(t, uint(3200), parseClockFromCPUName("Intel(R) Core(TM) i7-8700 CPU @ 3.20GHz"))
+	assert.Equal(t, uint(2500), parseClockFromCPUName("Intel(R) Xeon(R) CPU E5-2670 v2 @2.5 GHz"))
+	assert.Zero(t, parseClockFromCPUName("AMD Ryzen 9 5950X 16-Core Processor"))
+	assert.Zero(t, parseClockFromCPUName("Apple M2"))
+}
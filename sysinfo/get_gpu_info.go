@@ -2,6 +2,7 @@ package sysinfo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 type GPUInfo struct {
@@ -20,16 +22,28 @@ type GPUInfo struct {
 	Memory uint
 }
 
-// GetGPUInfo returns GPU info across macOS, Linux and Windows.
+const gpuInfoTimeout = 5 * time.Second
+
+// GetGPUInfo returns GPU info across macOS, Linux and Windows. It's the same as GetGPUInfoCtx with a timeout of 5s.
 //
 // Backends (best-effort):
 //   - macOS: system_profiler SPDisplaysDataType (text parsing)
 //   - Windows: PowerShell CIM Win32_VideoController
 //   - Linux:
 //     1) NVIDIA: nvidia-smi (if present)
-//     2) /sys/class/drm/* (VRAM for AMD amdgpu when available)
+//     2) /sys/class/drm/* (VRAM for AMD amdgpu and Intel discrete GPUs when available; names from pci.ids)
 //     3) lspci fallback (name/vendor; memory unknown)
 func GetGPUInfo() ([]GPUInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gpuInfoTimeout)
+	defer cancel()
+
+	return GetGPUInfoCtx(ctx)
+}
+
+// GetGPUInfoCtx returns GPU info like GetGPUInfo, killing the backend commands when the context is canceled or its
+// deadline passes, so a wedged driver tool can't hang the caller. In that case, the returned error wraps the context's
+// error.
+func GetGPUInfoCtx(ctx context.Context) ([]GPUInfo, error) {
 	var gpus []GPUInfo
 	var err error
 	var wg sync.WaitGroup
@@ -37,24 +51,29 @@ func GetGPUInfo() ([]GPUInfo, error) {
 	wg.Go(func() {
 		switch runtime.GOOS {
 		case "linux":
-			gpus, err = linuxGPUInfo()
+			gpus, err = linuxGPUInfo(ctx)
 		case "darwin":
-			gpus, err = darwinGPUInfo()
+			gpus, err = darwinGPUInfo(ctx)
 		case "windows":
-			gpus, err = windowsGPUInfo()
+			gpus, err = windowsGPUInfo(ctx)
 		default:
 			gpus, err = nil, errors.New("unsupported OS: "+runtime.GOOS)
 		}
 	})
 
 	wg.Wait()
+
+	if err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("failed to detect GPU: %w", ctx.Err())
+	}
+
 	return gpus, err
 }
 
 // region - macOS
 
-func darwinGPUInfo() ([]GPUInfo, error) {
-	if gpus, err := viaMacSystemProfilerText(); err == nil && len(gpus) > 0 {
+func darwinGPUInfo(ctx context.Context) ([]GPUInfo, error) {
+	if gpus, err := viaMacSystemProfilerText(ctx); err == nil && len(gpus) > 0 {
 		return gpus, nil
 	} else if err != nil {
 		return nil, errors.New("mac system_profiler: " + err.Error())
@@ -63,9 +82,9 @@ func darwinGPUInfo() ([]GPUInfo, error) {
 	return nil, errors.New("failed to detect GPU")
 }
 
-func viaMacSystemProfilerText() ([]GPUInfo, error) {
+func viaMacSystemProfilerText(ctx context.Context) ([]GPUInfo, error) {
 	// Text output is more stable across macOS versions than -json for this use case.
-	out, err := run("system_profiler", "SPDisplaysDataType")
+	out, err := runCtx(ctx, "system_profiler", "SPDisplaysDataType")
 	if err != nil {
 		return nil, err
 	}
@@ -188,11 +207,11 @@ func parseMacVRAMToMiB(s string) uint {
 
 // region - Linux
 
-func linuxGPUInfo() ([]GPUInfo, error) {
+func linuxGPUInfo(ctx context.Context) ([]GPUInfo, error) {
 	var errs []string
 
 	// Prefer NVIDIA if available.
-	if gpus, err := viaNvidiaSMILinux(); err == nil && len(gpus) > 0 {
+	if gpus, err := viaNvidiaSMILinux(ctx); err == nil && len(gpus) > 0 {
 		return gpus, nil
 	} else if err != nil && !isExecNotFound(err) {
 		errs = append(errs, "nvidia-smi: "+err.Error())
@@ -204,7 +223,7 @@ func linuxGPUInfo() ([]GPUInfo, error) {
 		errs = append(errs, "linux drm sysfs: "+err.Error())
 	}
 
-	if gpus, err := viaLinuxLspci(); err == nil && len(gpus) > 0 {
+	if gpus, err := viaLinuxLspci(ctx); err == nil && len(gpus) > 0 {
 		return gpus, nil
 	} else if err != nil {
 		errs = append(errs, "linux lspci: "+err.Error())
@@ -217,17 +236,8 @@ func linuxGPUInfo() ([]GPUInfo, error) {
 	return nil, errors.New("failed to detect GPU: " + strings.Join(errs, " | "))
 }
 
-func viaNvidiaSMILinux() ([]GPUInfo, error) {
-	out, err := run("nvidia-smi", "--query-gpu=name,memory.total", "--format=csv,noheader,nounits")
-	if err != nil {
-		// Common WSL location if not on PATH
-		if runtime.GOOS == "linux" {
-			if _, statErr := os.Stat("/usr/lib/wsl/lib/nvidia-smi"); statErr == nil {
-				out, err = run("/usr/lib/wsl/lib/nvidia-smi", "--query-gpu=name,memory.total", "--format=csv,noheader,nounits")
-			}
-		}
-	}
-
+func viaNvidiaSMILinux(ctx context.Context) ([]GPUInfo, error) {
+	out, err := runNvidiaSMI(ctx, "--query-gpu=name,memory.total", "--format=csv,noheader,nounits")
 	if err != nil {
 		return nil, err
 	}
@@ -258,11 +268,20 @@ func viaLinuxDRMSysfs() ([]GPUInfo, error) {
 		}
 
 		vendor := vendorFromPCI(vendorID)
-		name := fmt.Sprintf("PCI GPU (%s %s)", vendorID, deviceID)
+		name := pciDeviceName(vendorID, deviceID)
+		if name == "" {
+			name = fmt.Sprintf("PCI GPU (%s %s)", vendorID, deviceID)
+		}
 
+		// AMD amdgpu exposes mem_info_vram_total; Intel discrete GPUs using the xe driver expose the VRAM size of the
+		// first tile (in hex). Integrated GPUs share the system memory, so it's left as 0.
 		memMiB := uint(0)
 		if b := readUint64File(devDir + "/mem_info_vram_total"); b > 0 {
 			memMiB = uint(b / MiB)
+		} else if s := readFirstLine(devDir + "/tile0/physical_vram_size_bytes"); s != "" {
+			if b, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(s), "0x"), 16, 64); err == nil {
+				memMiB = uint(b / MiB)
+			}
 		}
 
 		gpus = append(gpus, GPUInfo{Name: name, Vendor: vendor, Memory: memMiB})
@@ -275,8 +294,8 @@ func viaLinuxDRMSysfs() ([]GPUInfo, error) {
 	return gpus, nil
 }
 
-func viaLinuxLspci() ([]GPUInfo, error) {
-	out, err := run("sh", "-c", "command -v lspci >/dev/null 2>&1 && lspci -nn | egrep -i 'vga|3d|display' || true")
+func viaLinuxLspci(ctx context.Context) ([]GPUInfo, error) {
+	out, err := runCtx(ctx, "sh", "-c", "command -v lspci >/dev/null 2>&1 && lspci -nn | egrep -i 'vga|3d|display' || true")
 	if err != nil {
 		return nil, err
 	}
@@ -309,18 +328,18 @@ func viaLinuxLspci() ([]GPUInfo, error) {
 
 // region - Windows
 
-func windowsGPUInfo() ([]GPUInfo, error) {
+func windowsGPUInfo(ctx context.Context) ([]GPUInfo, error) {
 	var errs []string
 
 	// Prefer NVIDIA if nvidia-smi exists (correct VRAM, like Linux)
-	if gpus, err := viaNvidiaSMIWindows(); err == nil && len(gpus) > 0 {
+	if gpus, err := viaNvidiaSMIWindows(ctx); err == nil && len(gpus) > 0 {
 		return gpus, nil
 	} else if err != nil && !isExecNotFound(err) {
 		errs = append(errs, "nvidia-smi: "+err.Error())
 	}
 
 	// Fallback: CIM for name/vendor (AdapterRAM is unreliable; don't trust it for >4GB)
-	if gpus, err := viaWindowsCIMNameOnly(); err == nil && len(gpus) > 0 {
+	if gpus, err := viaWindowsCIMNameOnly(ctx); err == nil && len(gpus) > 0 {
 		return gpus, nil
 	} else if err != nil {
 		errs = append(errs, "windows CIM: "+err.Error())
@@ -333,14 +352,9 @@ func windowsGPUInfo() ([]GPUInfo, error) {
 	return nil, errors.New("failed to detect GPU: " + strings.Join(errs, " | "))
 }
 
-func viaNvidiaSMIWindows() ([]GPUInfo, error) {
-	// Only attempt if present.
-	if _, err := exec.LookPath("nvidia-smi"); err != nil {
-		return nil, err
-	}
-
+func viaNvidiaSMIWindows(ctx context.Context) ([]GPUInfo, error) {
 	// Same query as Linux.
-	out, err := run("nvidia-smi", "--query-gpu=name,memory.total", "--format=csv,noheader,nounits")
+	out, err := runNvidiaSMI(ctx, "--query-gpu=name,memory.total", "--format=csv,noheader,nounits")
 	if err != nil {
 		return nil, err
 	}
@@ -348,6 +362,28 @@ func viaNvidiaSMIWindows() ([]GPUInfo, error) {
 	return parseNvidiaSMIOutput(out)
 }
 
+// runNvidiaSMI runs nvidia-smi with the given arguments on Linux (including WSL) and Windows.
+func runNvidiaSMI(ctx context.Context, args ...string) ([]byte, error) {
+	// Only attempt if present.
+	if runtime.GOOS == "windows" {
+		if _, err := exec.LookPath("nvidia-smi"); err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := runCtx(ctx, "nvidia-smi", args...)
+	if err != nil {
+		// Common WSL location if not on PATH
+		if runtime.GOOS == "linux" {
+			if _, statErr := os.Stat("/usr/lib/wsl/lib/nvidia-smi"); statErr == nil {
+				out, err = runCtx(ctx, "/usr/lib/wsl/lib/nvidia-smi", args...)
+			}
+		}
+	}
+
+	return out, err
+}
+
 // parseNvidiaSMIOutput parses the CSV output from nvidia-smi and returns GPU information.
 func parseNvidiaSMIOutput(out []byte) ([]GPUInfo, error) {
 	lines := nonEmptyLines(string(out))
@@ -381,13 +417,13 @@ func parseNvidiaSMIOutput(out []byte) ([]GPUInfo, error) {
 	return gpus, nil
 }
 
-func viaWindowsCIMNameOnly() ([]GPUInfo, error) {
+func viaWindowsCIMNameOnly(ctx context.Context) ([]GPUInfo, error) {
 	ps := strings.Join([]string{
 		"$g=Get-CimInstance Win32_VideoController | Select-Object Name,AdapterCompatibility;",
 		"$g | ConvertTo-Json -Depth 3",
 	}, " ")
 
-	out, err := run("powershell", "-NoProfile", "-NonInteractive", "-Command", ps)
+	out, err := runCtx(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", ps)
 	if err != nil {
 		return nil, err
 	}
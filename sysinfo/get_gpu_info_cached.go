@@ -0,0 +1,50 @@
+package sysinfo
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+type gpuInfoCache struct {
+	mu        sync.Mutex
+	gpus      []GPUInfo
+	fetchedAt time.Time
+	valid     bool
+}
+
+var gpuCache gpuInfoCache
+
+// GetGPUInfoCached returns the same information as GetGPUInfo, but memoizes it for the given TTL, so subsequent calls
+// are served instantly instead of shelling out to the backends again. A TTL <= 0 never expires, which is fine for most
+// cases since the hardware doesn't change at runtime.
+//
+// Errors are not cached, so a transient failure is retried on the next call. It's safe for concurrent use; concurrent
+// calls while the cache is empty or expired wait for a single probe.
+func GetGPUInfoCached(ttl time.Duration) ([]GPUInfo, error) {
+	return gpuCache.get(ttl, GetGPUInfo)
+}
+
+// region - Private functions
+
+func (c *gpuInfoCache) get(ttl time.Duration, fetch func() ([]GPUInfo, error)) ([]GPUInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.valid && (ttl <= 0 || time.Since(c.fetchedAt) < ttl) {
+		return slices.Clone(c.gpus), nil
+	}
+
+	gpus, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.gpus = gpus
+	c.fetchedAt = time.Now()
+	c.valid = true
+
+	return slices.Clone(gpus), nil
+}
+
+// endregion
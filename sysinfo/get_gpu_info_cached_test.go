@@ -0,0 +1,88 @@
+package sysinfo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGPUInfoCache(t *testing.T) {
+	gpus := []GPUInfo{{Name: "GeForce RTX 4090", Vendor: "NVIDIA", Memory: 24564}}
+
+	newFetch := func(calls *int, err error) func() ([]GPUInfo, error) {
+		return func() ([]GPUInfo, error) {
+			*calls++
+			if err != nil {
+				return nil, err
+			}
+			return gpus, nil
+		}
+	}
+
+	t.Run("serves cached results within the ttl", func(t *testing.T) {
+		var cache gpuInfoCache
+		calls := 0
+		fetch := newFetch(&calls, nil)
+
+		for range 3 {
+			result, err := cache.get(time.Hour, fetch)
+			require.NoError(t, err)
+			assert.Equal(t, gpus, result)
+		}
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("no ttl never expires", func(t *testing.T) {
+		var cache gpuInfoCache
+		calls := 0
+		fetch := newFetch(&calls, nil)
+
+		_, _ = cache.get(0, fetch)
+		cache.fetchedAt = time.Now().Add(-24 * time.Hour)
+		_, _ = cache.get(0, fetch)
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("refreshes after the ttl", func(t *testing.T) {
+		var cache gpuInfoCache
+		calls := 0
+		fetch := newFetch(&calls, nil)
+
+		_, _ = cache.get(time.Minute, fetch)
+		cache.fetchedAt = time.Now().Add(-2 * time.Minute)
+		_, _ = cache.get(time.Minute, fetch)
+
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		var cache gpuInfoCache
+		failures := 0
+
+		_, err := cache.get(time.Hour, newFetch(&failures, errors.New("nvidia-smi: timeout")))
+		assert.Error(t, err)
+
+		calls := 0
+		result, err := cache.get(time.Hour, newFetch(&calls, nil))
+		require.NoError(t, err)
+		assert.Equal(t, gpus, result)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("callers can't modify the cached results", func(t *testing.T) {
+		var cache gpuInfoCache
+		calls := 0
+		fetch := newFetch(&calls, nil)
+
+		result, _ := cache.get(time.Hour, fetch)
+		result[0].Name = "changed"
+
+		result, _ = cache.get(time.Hour, fetch)
+		assert.Equal(t, "GeForce RTX 4090", result[0].Name)
+	})
+}
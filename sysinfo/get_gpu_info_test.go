@@ -1,6 +1,7 @@
 package sysinfo
 
 import (
+	"context"
 	"runtime"
 	"testing"
 
@@ -23,3 +24,13 @@ func TestGetGPUInfo(t *testing.T) {
 		assert.NotEmptyf(t, g.Name, "gpu[%d] name should be populated", i)
 	}
 }
+
+func TestGetGPUInfoCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Backends that don't run commands (e.g. sysfs) may still succeed; otherwise the context's error is returned
+	if _, err := GetGPUInfoCtx(ctx); err != nil {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
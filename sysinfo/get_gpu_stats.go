@@ -0,0 +1,90 @@
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// GPUStat extends GPUInfo with live usage metrics, for monitoring. Metrics that can't be read are left at 0.
+type GPUStat struct {
+	GPUInfo
+	MemoryUsed         uint // MiB
+	UtilizationPercent int
+	TempCelsius        int
+}
+
+// GetGPUStats returns the info and the current usage metrics (used memory, utilization and temperature) of each
+// NVIDIA GPU on Linux and Windows, using nvidia-smi. Like GetGPUInfo, the command is killed after 5s.
+//
+// A GPU is still reported when some of its metrics aren't available (e.g. "[N/A]" on some models); those metrics are
+// left at 0. Returns an error on other OSes, or when nvidia-smi isn't available.
+func GetGPUStats() ([]GPUStat, error) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "windows" {
+		return nil, errors.New("unsupported OS: " + runtime.GOOS)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gpuInfoTimeout)
+	defer cancel()
+
+	out, err := runNvidiaSMI(ctx,
+		"--query-gpu=name,memory.total,memory.used,utilization.gpu,temperature.gpu",
+		"--format=csv,noheader,nounits",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseNvidiaSMIStats(out)
+}
+
+// region - Private functions
+
+// parseNvidiaSMIStats parses the CSV output from the nvidia-smi stats query and returns the GPU stats.
+func parseNvidiaSMIStats(out []byte) ([]GPUStat, error) {
+	lines := nonEmptyLines(string(out))
+	if len(lines) == 0 {
+		return nil, errors.New("no output")
+	}
+
+	var stats []GPUStat
+
+	for _, line := range lines {
+		parts := strings.Split(line, ",")
+
+		name := strings.TrimSpace(parts[0])
+		if name == "" {
+			continue
+		}
+
+		field := func(i int) uint64 {
+			if i >= len(parts) {
+				return 0
+			}
+
+			// Unsupported metrics are reported as "[N/A]" or "[Not Supported]"
+			n, err := strconv.ParseUint(strings.TrimSpace(parts[i]), 10, 64)
+			if err != nil {
+				return 0
+			}
+			return n
+		}
+
+		stats = append(stats, GPUStat{
+			GPUInfo:            GPUInfo{Name: name, Vendor: "NVIDIA", Memory: uint(field(1))},
+			MemoryUsed:         uint(field(2)),
+			UtilizationPercent: int(field(3)),
+			TempCelsius:        int(field(4)),
+		})
+	}
+
+	if len(stats) == 0 {
+		return nil, errors.New("could not parse nvidia-smi output")
+	}
+
+	return stats, nil
+}
+
+// endregion
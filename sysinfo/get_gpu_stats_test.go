@@ -0,0 +1,63 @@
+package sysinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGPUStats(t *testing.T) {
+	stats, err := GetGPUStats()
+	if err != nil {
+		t.Skipf("nvidia-smi isn't available on this host (acceptable in headless/CI): %v", err)
+	}
+
+	for i, s := range stats {
+		assert.NotEmptyf(t, s.Name, "gpu[%d] name should be populated", i)
+	}
+}
+
+func TestParseNvidiaSMIStats(t *testing.T) {
+	t.Run("all fields", func(t *testing.T) {
+		out := []byte("NVIDIA GeForce RTX 4090, 24564, 1024, 37, 45\nNVIDIA GeForce RTX 3080, 10240, 512, 5, 38\n")
+
+		stats, err := parseNvidiaSMIStats(out)
+		require.NoError(t, err)
+		assert.Equal(t, []GPUStat{
+			{
+				GPUInfo:            GPUInfo{Name: "NVIDIA GeForce RTX 4090", Vendor: "NVIDIA", Memory: 24564},
+				MemoryUsed:         1024,
+				UtilizationPercent: 37,
+				TempCelsius:        45,
+			},
+			{
+				GPUInfo:            GPUInfo{Name: "NVIDIA GeForce RTX 3080", Vendor: "NVIDIA", Memory: 10240},
+				MemoryUsed:         512,
+				UtilizationPercent: 5,
+				TempCelsius:        38,
+			},
+		}, stats)
+	})
+
+	t.Run("unparseable fields are left at zero", func(t *testing.T) {
+		out := []byte("Tesla K80, 11441, [N/A], [Not Supported], 30\nQuadro P400, 2048\n")
+
+		stats, err := parseNvidiaSMIStats(out)
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+
+		assert.Equal(t, uint(11441), stats[0].Memory)
+		assert.Zero(t, stats[0].MemoryUsed)
+		assert.Zero(t, stats[0].UtilizationPercent)
+		assert.Equal(t, 30, stats[0].TempCelsius)
+
+		assert.Equal(t, "Quadro P400", stats[1].Name)
+		assert.Zero(t, stats[1].TempCelsius)
+	})
+
+	t.Run("no output", func(t *testing.T) {
+		_, err := parseNvidiaSMIStats([]byte("\n"))
+		assert.Error(t, err)
+	})
+}
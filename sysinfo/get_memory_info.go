@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"os"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -12,14 +13,18 @@ import (
 )
 
 type MemoryInfo struct {
-	Total uint64 // bytes
+	Total     uint64 // MiB
+	Available uint64 // MiB; 0 when unknown
 }
 
-// GetMemoryInfo returns the machine's total physical RAM (bytes) on Windows, Linux and macOS.
+var vmStatPageSize = regexp.MustCompile(`page size of (\d+) bytes`)
+
+// GetMemoryInfo returns the machine's total and available physical RAM (MiB) on Windows, Linux and macOS. The available
+// memory is an estimate of how much memory can be used by new applications without swapping.
 //
-// - Linux: parses /proc/meminfo (MemTotal)
-// - macOS: uses sysctl hw.memsize
-// - Windows: uses PowerShell (CIM) Win32_ComputerSystem TotalPhysicalMemory
+// - Linux: parses /proc/meminfo (MemTotal and MemAvailable)
+// - macOS: uses sysctl hw.memsize and vm_stat (free, inactive and speculative pages)
+// - Windows: uses PowerShell (CIM) Win32_ComputerSystem TotalPhysicalMemory and Win32_OperatingSystem FreePhysicalMemory
 func GetMemoryInfo() (MemoryInfo, error) {
 	var mem MemoryInfo
 	var err error
@@ -28,11 +33,11 @@ func GetMemoryInfo() (MemoryInfo, error) {
 	wg.Go(func() {
 		switch runtime.GOOS {
 		case "linux":
-			mem, err = linuxTotalMemory()
+			mem, err = linuxMemoryInfo()
 		case "darwin":
-			mem, err = macTotalMemory()
+			mem, err = macMemoryInfo()
 		case "windows":
-			mem, err = windowsTotalMemory()
+			mem, err = windowsMemoryInfo()
 		default:
 			mem, err = MemoryInfo{}, errors.New("unsupported OS: "+runtime.GOOS)
 		}
@@ -42,35 +47,56 @@ func GetMemoryInfo() (MemoryInfo, error) {
 	return mem, err
 }
 
-func linuxTotalMemory() (MemoryInfo, error) {
+// region - Linux
+
+func linuxMemoryInfo() (MemoryInfo, error) {
 	b, err := os.ReadFile("/proc/meminfo")
 	if err != nil {
 		return MemoryInfo{}, err
 	}
 
-	for _, line := range strings.Split(string(b), "\n") {
-		if strings.HasPrefix(line, "MemTotal:") {
-			fields := strings.Fields(line)
+	return parseProcMeminfo(string(b))
+}
 
-			// MemTotal: <kB> kB
-			if len(fields) < 2 {
-				return MemoryInfo{}, errors.New("unexpected MemTotal format")
-			}
+func parseProcMeminfo(content string) (MemoryInfo, error) {
+	// <Key>: <kB> kB
+	values := make(map[string]uint64)
+	for _, line := range nonEmptyLines(content) {
+		key, val, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
 
-			kb, err := strconv.ParseUint(fields[1], 10, 64)
-			if err != nil {
-				return MemoryInfo{}, err
-			}
+		fields := strings.Fields(val)
+		if len(fields) == 0 {
+			continue
+		}
 
-			// /proc/meminfo reports in KiB, convert to MB
-			return MemoryInfo{Total: kb * KiB / 1_000_000}, nil
+		if kb, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			values[key] = kb
 		}
 	}
 
-	return MemoryInfo{}, errors.New("MemTotal not found in /proc/meminfo")
+	total, ok := values["MemTotal"]
+	if !ok {
+		return MemoryInfo{}, errors.New("MemTotal not found in /proc/meminfo")
+	}
+
+	// MemAvailable only exists since Linux 3.14; estimate it on older kernels
+	available, ok := values["MemAvailable"]
+	if !ok {
+		available = values["MemFree"] + values["Buffers"] + values["Cached"]
+	}
+
+	// /proc/meminfo reports in KiB, convert to MiB
+	return MemoryInfo{Total: total * KiB / MiB, Available: available * KiB / MiB}, nil
 }
 
-func macTotalMemory() (MemoryInfo, error) {
+// endregion
+
+// region - macOS
+
+func macMemoryInfo() (MemoryInfo, error) {
 	out, err := run("sysctl", "-n", "hw.memsize")
 	if err != nil {
 		return MemoryInfo{}, err
@@ -85,15 +111,65 @@ func macTotalMemory() (MemoryInfo, error) {
 		return MemoryInfo{}, errors.New("sysctl returned 0")
 	}
 
-	return MemoryInfo{Total: n / 1_000_000}, nil
+	mem := MemoryInfo{Total: n / MiB}
+
+	// Best-effort; left as 0 when vm_stat can't be read.
+	if vmOut, vmErr := run("vm_stat"); vmErr == nil {
+		mem.Available = parseVMStatAvailable(string(vmOut)) / MiB
+	}
+
+	return mem, nil
 }
 
-func windowsTotalMemory() (MemoryInfo, error) {
-	// TotalPhysicalMemory is bytes.
+// parseVMStatAvailable returns the available memory (bytes) from the output of vm_stat, counting the free, inactive
+// and speculative pages.
+func parseVMStatAvailable(output string) uint64 {
+	lines := nonEmptyLines(output)
+	if len(lines) == 0 {
+		return 0
+	}
+
+	// Mach Virtual Memory Statistics: (page size of 16384 bytes)
+	m := vmStatPageSize.FindStringSubmatch(lines[0])
+	if m == nil {
+		return 0
+	}
+
+	pageSize, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	var pages uint64
+	for _, line := range lines[1:] {
+		key, val, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "Pages free", "Pages inactive", "Pages speculative":
+			// Pages free:                               12345.
+			if n, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(val), "."), 10, 64); err == nil {
+				pages += n
+			}
+		}
+	}
+
+	return pages * pageSize
+}
+
+// endregion
+
+// region - Windows
+
+func windowsMemoryInfo() (MemoryInfo, error) {
+	// TotalPhysicalMemory is bytes; FreePhysicalMemory is KiB.
 	// Using CIM is more modern than legacy WMI/wmic.
 	ps := strings.Join([]string{
 		"$cs=Get-CimInstance Win32_ComputerSystem | Select-Object -First 1 TotalPhysicalMemory;",
-		"$cs | ConvertTo-Json -Depth 2",
+		"$os=Get-CimInstance Win32_OperatingSystem | Select-Object -First 1 FreePhysicalMemory;",
+		"@{TotalPhysicalMemory=$cs.TotalPhysicalMemory; FreePhysicalMemory=$os.FreePhysicalMemory} | ConvertTo-Json -Depth 2",
 	}, " ")
 
 	out, err := run("powershell", "-NoProfile", "-NonInteractive", "-Command", ps)
@@ -108,6 +184,7 @@ func windowsTotalMemory() (MemoryInfo, error) {
 
 	type row struct {
 		TotalPhysicalMemory any `json:"TotalPhysicalMemory"`
+		FreePhysicalMemory  any `json:"FreePhysicalMemory"`
 	}
 
 	var r row
@@ -120,5 +197,10 @@ func windowsTotalMemory() (MemoryInfo, error) {
 		return MemoryInfo{}, errors.New("could not read TotalPhysicalMemory")
 	}
 
-	return MemoryInfo{Total: total / 1_000_000}, nil
+	// Best-effort; left as 0 when missing.
+	free, _ := anyToUint64(r.FreePhysicalMemory)
+
+	return MemoryInfo{Total: total / MiB, Available: free * KiB / MiB}, nil
 }
+
+// endregion
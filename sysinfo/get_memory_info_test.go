@@ -19,4 +19,43 @@ func TestGetMemoryInfo(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Greater(t, info.Total, uint64(0), "total memory must be positive")
+	assert.LessOrEqual(t, info.Available, info.Total, "available memory can't exceed the total")
+}
+
+func TestParseProcMeminfo(t *testing.T) {
+	t.Run("with MemAvailable", func(t *testing.T) {
+		content := "MemTotal:       16384000 kB\nMemFree:         1024000 kB\nMemAvailable:    8192000 kB\nBuffers:          102400 kB\nCached:          2048000 kB\n"
+
+		info, err := parseProcMeminfo(content)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(16000), info.Total)
+		assert.Equal(t, uint64(8000), info.Available)
+	})
+
+	t.Run("estimates available memory on older kernels", func(t *testing.T) {
+		content := "MemTotal:       16384000 kB\nMemFree:         1024000 kB\nBuffers:          102400 kB\nCached:          2048000 kB\n"
+
+		info, err := parseProcMeminfo(content)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3100), info.Available)
+	})
+
+	t.Run("missing MemTotal", func(t *testing.T) {
+		_, err := parseProcMeminfo("MemFree:         1024000 kB\n")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseVMStatAvailable(t *testing.T) {
+	output := `Mach Virtual Memory Statistics: (page size of 16384 bytes)
+Pages free:                               10000.
+Pages active:                            200000.
+Pages inactive:                           20000.
+Pages speculative:                         2000.
+Pages throttled:                              0.
+Pages wired down:                         90000.
+`
+
+	assert.Equal(t, uint64(32000*16384), parseVMStatAvailable(output))
+	assert.Zero(t, parseVMStatAvailable("unexpected output"))
 }
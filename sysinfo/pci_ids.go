@@ -0,0 +1,139 @@
+package sysinfo
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// pciIDsPaths are the usual locations of the PCI ID database on Linux distributions.
+var pciIDsPaths = []string{
+	"/usr/share/hwdata/pci.ids",
+	"/usr/share/misc/pci.ids",
+	"/usr/share/pci.ids",
+}
+
+// knownGPUs maps the vendor and device IDs of common GPUs to friendly names, for systems without the PCI ID database.
+var knownGPUs = map[string]string{
+	// Intel
+	"8086:0412": "Intel HD Graphics 4600",
+	"8086:1912": "Intel HD Graphics 530",
+	"8086:5912": "Intel HD Graphics 630",
+	"8086:591b": "Intel HD Graphics 630",
+	"8086:3e92": "Intel UHD Graphics 630",
+	"8086:3e9b": "Intel UHD Graphics 630",
+	"8086:9a49": "Intel Iris Xe Graphics",
+	"8086:46a6": "Intel Iris Xe Graphics",
+	"8086:4680": "Intel UHD Graphics 770",
+	"8086:a780": "Intel UHD Graphics 770",
+	"8086:56a0": "Intel Arc A770",
+	"8086:56a5": "Intel Arc A380",
+
+	// AMD
+	"1002:73bf": "AMD Radeon RX 6800/6800 XT/6900 XT",
+	"1002:73df": "AMD Radeon RX 6700/6700 XT/6750 XT",
+	"1002:744c": "AMD Radeon RX 7900 XT/7900 XTX",
+	"1002:1638": "AMD Radeon Vega (Cezanne)",
+	"1002:164e": "AMD Radeon Graphics (Raphael)",
+	"1002:15bf": "AMD Radeon 780M (Phoenix)",
+
+	// NVIDIA
+	"10de:2204": "NVIDIA GeForce RTX 3090",
+	"10de:2206": "NVIDIA GeForce RTX 3080",
+	"10de:2484": "NVIDIA GeForce RTX 3070",
+	"10de:2684": "NVIDIA GeForce RTX 4090",
+}
+
+// pciDeviceName returns a human-readable name for a PCI device, looking it up in the PCI ID database when present, or
+// in a small table of common GPUs. Returns an empty string when the device is unknown.
+func pciDeviceName(vendorID, deviceID string) string {
+	vendorID = normalizePCIID(vendorID)
+	deviceID = normalizePCIID(deviceID)
+
+	for _, path := range pciIDsPaths {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+
+		name := lookupPCIIDs(f, vendorID, deviceID)
+		f.Close()
+
+		if name != "" {
+			return name
+		}
+	}
+
+	return knownGPUs[vendorID+":"+deviceID]
+}
+
+// lookupPCIIDs finds the device in a PCI ID database, returning its name prefixed with the vendor, e.g.
+// "Intel UHD Graphics 630" for "CoffeeLake-S GT2 [UHD Graphics 630]".
+func lookupPCIIDs(r io.Reader, vendorID, deviceID string) string {
+	// Format:
+	// 8086  Intel Corporation
+	// \t3e92  CoffeeLake-S GT2 [UHD Graphics 630]
+	// \t\t<subvendor> <subdevice>  <subsystem name>
+	var vendorName string
+	inVendor := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, "\t") {
+			if inVendor {
+				// Reached the next vendor without finding the device
+				return ""
+			}
+
+			id, name, ok := strings.Cut(line, "  ")
+			if ok && strings.ToLower(id) == vendorID {
+				inVendor = true
+				vendorName = strings.TrimSpace(name)
+			}
+			continue
+		}
+
+		if !inVendor || strings.HasPrefix(line, "\t\t") {
+			continue
+		}
+
+		id, name, ok := strings.Cut(strings.TrimPrefix(line, "\t"), "  ")
+		if ok && strings.ToLower(id) == deviceID {
+			return friendlyPCIName(vendorID, vendorName, strings.TrimSpace(name))
+		}
+	}
+
+	return ""
+}
+
+// friendlyPCIName prefers the marketing name in brackets, e.g. "GeForce RTX 4090" in "AD102 [GeForce RTX 4090]", and
+// prefixes it with the short vendor name.
+func friendlyPCIName(vendorID, vendorName, deviceName string) string {
+	if start := strings.LastIndex(deviceName, "["); start >= 0 {
+		if end := strings.LastIndex(deviceName, "]"); end > start+1 {
+			deviceName = deviceName[start+1 : end]
+		}
+	}
+
+	vendor := vendorFromPCI("0x" + vendorID)
+	if vendor == "0x"+vendorID {
+		vendor = vendorName
+	}
+
+	if vendor == "" || strings.HasPrefix(deviceName, vendor) {
+		return deviceName
+	}
+
+	return vendor + " " + deviceName
+}
+
+func normalizePCIID(id string) string {
+	id = strings.ToLower(strings.TrimSpace(id))
+	return strings.TrimPrefix(id, "0x")
+}
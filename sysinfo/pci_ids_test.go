@@ -0,0 +1,66 @@
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPCIIDs = `# PCI ID database
+10de  NVIDIA Corporation
+	2684  AD102 [GeForce RTX 4090]
+		10de 167c  GeForce RTX 4090 Founders Edition
+1234  Example Vendor
+	0001  Plain Device
+8086  Intel Corporation
+	3e92  CoffeeLake-S GT2 [UHD Graphics 630]
+	9999  Intel Test Device
+C 03  Display controller
+`
+
+func TestLookupPCIIDs(t *testing.T) {
+	tests := []struct {
+		vendorID, deviceID, expected string
+	}{
+		{"8086", "3e92", "Intel UHD Graphics 630"},
+		{"10de", "2684", "NVIDIA GeForce RTX 4090"},
+		{"1234", "0001", "Example Vendor Plain Device"},
+		{"8086", "9999", "Intel Test Device"},
+		{"8086", "0000", ""},
+		{"10de", "167c", ""}, // subsystems are not devices
+		{"abcd", "0001", ""},
+	}
+
+	for _, tt := range tests {
+		name := lookupPCIIDs(strings.NewReader(testPCIIDs), tt.vendorID, tt.deviceID)
+		assert.Equal(t, tt.expected, name, tt.vendorID+":"+tt.deviceID)
+	}
+}
+
+func TestPCIDeviceName(t *testing.T) {
+	setPaths := func(t *testing.T, paths ...string) {
+		original := pciIDsPaths
+		pciIDsPaths = paths
+		t.Cleanup(func() { pciIDsPaths = original })
+	}
+
+	t.Run("uses the PCI ID database when present", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "pci.ids")
+		require.NoError(t, os.WriteFile(path, []byte(testPCIIDs), 0644))
+		setPaths(t, filepath.Join(t.TempDir(), "missing.ids"), path)
+
+		assert.Equal(t, "Intel Test Device", pciDeviceName("0x8086", "0x9999"))
+	})
+
+	t.Run("falls back to the embedded table", func(t *testing.T) {
+		setPaths(t, filepath.Join(t.TempDir(), "missing.ids"))
+
+		assert.Equal(t, "Intel UHD Graphics 630", pciDeviceName("0x8086", "0x3E92"))
+		assert.Equal(t, "AMD Radeon RX 7900 XT/7900 XTX", pciDeviceName("0x1002", "0x744c"))
+		assert.Empty(t, pciDeviceName("0x8086", "0x9999"))
+	})
+}
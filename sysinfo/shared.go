@@ -1,6 +1,7 @@
 package sysinfo
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 	"strings"
@@ -33,3 +34,8 @@ func anyToUint64(v any) (uint64, bool) {
 		return 0, false
 	}
 }
+
+// run executes a command and returns its output, without a timeout; see runCtx.
+func run(name string, args ...string) ([]byte, error) {
+	return runCtx(context.Background(), name, args...)
+}
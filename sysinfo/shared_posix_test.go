@@ -0,0 +1,36 @@
+//go:build !windows
+
+package sysinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCtx(t *testing.T) {
+	t.Run("returns the output", func(t *testing.T) {
+		out, err := runCtx(context.Background(), "sh", "-c", "echo hello")
+		require.NoError(t, err)
+		assert.Equal(t, "hello\n", string(out))
+	})
+
+	t.Run("kills the command when the deadline passes", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := runCtx(ctx, "sh", "-c", "sleep 10")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("includes stderr in the error", func(t *testing.T) {
+		_, err := runCtx(context.Background(), "sh", "-c", "echo broken >&2; exit 1")
+		assert.EqualError(t, err, "sh: broken")
+	})
+}
@@ -4,17 +4,23 @@ package sysinfo
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
 	"syscall"
+	"time"
 )
 
-func run(name string, args ...string) ([]byte, error) {
-	cmd := exec.Command(name, args...)
+// runCtx executes a command and returns its output. The command is killed when the context is done.
+func runCtx(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
+	// Don't wait forever for children (e.g. of "sh -c") that keep the output open after the command is killed
+	cmd.WaitDelay = time.Second
+
 	// Hide the console window
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		HideWindow:    true,
@@ -23,6 +29,10 @@ func run(name string, args ...string) ([]byte, error) {
 
 	out, err := cmd.Output()
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("%s: %w", name, ctxErr)
+		}
+
 		msg := strings.TrimSpace(stderr.String())
 		if msg == "" {
 			msg = err.Error()
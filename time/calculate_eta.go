@@ -2,6 +2,9 @@ package time
 
 import gotime "time"
 
+// etaFallback is returned as the ETA when it can't be estimated from the inputs.
+const etaFallback = 7 * 24 * gotime.Hour
+
 // CalculateEta estimates the time remaining to complete a task based on progress made so far.
 //
 // It calculates the estimated time of arrival (ETA) by analyzing the average time per completed unit and extrapolating
@@ -25,7 +28,7 @@ import gotime "time"
 func CalculateEta(total, completed int, elapsed gotime.Duration) gotime.Duration {
 	// Validate inputs
 	if total <= 0 || completed <= 0 || elapsed <= 0 {
-		return gotime.Duration(7 * 24 * gotime.Hour)
+		return etaFallback
 	}
 
 	// Nothing to do
@@ -6,12 +6,26 @@ import (
 	"time"
 )
 
-// EpochTime is a wrapper around time.Time to handle JSON unmarshalling of epoch time.
+// EpochTime is a wrapper around time.Time to handle JSON marshalling and unmarshalling of epoch time.
+//
+// The zero value is marshalled as null, and null is unmarshalled as the zero value, so it round-trips.
 type EpochTime struct {
 	time.Time
 }
 
+func (t EpochTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.Unix())
+}
+
 func (t *EpochTime) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
 	var epoch float64
 	if err := json.Unmarshal(b, &epoch); err != nil {
 		return err
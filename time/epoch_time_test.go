@@ -231,3 +231,48 @@ func TestEpochTime_EdgeCases(t *testing.T) {
 		assert.Equal(t, expected, et.Time)
 	})
 }
+
+func TestEpochTime_MarshalJSON(t *testing.T) {
+	t.Run("integer unix seconds", func(t *testing.T) {
+		et := EpochTime{time.Date(2023, 1, 1, 0, 0, 0, 500, time.UTC)}
+
+		data, err := json.Marshal(et)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `1672531200`, string(data))
+	})
+
+	t.Run("unix epoch is not the zero value", func(t *testing.T) {
+		data, err := json.Marshal(EpochTime{time.Unix(0, 0)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, `0`, string(data))
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		data, err := json.Marshal(EpochTime{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, `null`, string(data))
+	})
+
+	t.Run("round trip within struct", func(t *testing.T) {
+		type Event struct {
+			CreatedAt EpochTime `json:"created_at"`
+			DeletedAt EpochTime `json:"deleted_at"`
+		}
+
+		original := Event{CreatedAt: EpochTime{time.Unix(1672531200, 0)}}
+
+		data, err := json.Marshal(original)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"created_at":1672531200,"deleted_at":null}`, string(data))
+
+		var decoded Event
+		err = json.Unmarshal(data, &decoded)
+
+		assert.NoError(t, err)
+		assert.True(t, original.CreatedAt.Equal(decoded.CreatedAt.Time))
+		assert.True(t, decoded.DeletedAt.IsZero())
+	})
+}
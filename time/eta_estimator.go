@@ -0,0 +1,88 @@
+package time
+
+import gotime "time"
+
+const defaultEtaSmoothing = 0.3
+
+// EtaEstimator estimates the time remaining to complete a task using an exponentially weighted moving average (EMA) of
+// the recent throughput, instead of the average over the whole elapsed time used by CalculateEta. This gives much
+// steadier ETAs for bursty workloads, like downloads, where the speed changes over time.
+//
+// An EtaEstimator is not safe for concurrent use.
+type EtaEstimator struct {
+	total     int
+	smoothing float64
+
+	rate          float64 // Smoothed throughput, in units per nanosecond
+	lastCompleted int
+	lastElapsed   gotime.Duration
+}
+
+// NewEtaEstimator creates an EtaEstimator for a task with the given total number of units.
+//
+// # Parameters:
+//   - total: The total number of units to complete (must be > 0)
+//   - smoothing: The weight of the most recent throughput sample, between 0 (exclusive) and 1 (inclusive); higher values
+//     react faster to speed changes, lower values give steadier ETAs. If it's out of range, 0.3 is used
+//
+// # Example:
+//
+//	estimator := NewEtaEstimator(1000, 0.3)
+//	for completed := range progress {
+//	    eta := estimator.Update(completed, time.Since(start))
+//	}
+func NewEtaEstimator(total int, smoothing float64) *EtaEstimator {
+	if smoothing <= 0 || smoothing > 1 {
+		smoothing = defaultEtaSmoothing
+	}
+
+	return &EtaEstimator{total: total, smoothing: smoothing}
+}
+
+// Update records the progress made so far and returns the estimated duration to complete the remaining work, based on
+// the smoothed throughput between the updates.
+//
+// # Parameters:
+//   - completed: The number of units already completed (must be > 0)
+//   - elapsed: The time duration spent since the task started (must be > 0)
+//
+// # Returns:
+//   - The estimated duration to complete the remaining work
+//   - Returns 0 if the task is already complete (completed >= total)
+//   - Returns 7 days (168 hours) as a fallback for invalid inputs, like CalculateEta
+//
+// Updates where the elapsed time or the completed units didn't increase don't produce a new throughput sample; they
+// only project the remaining time from the current one.
+func (e *EtaEstimator) Update(completed int, elapsed gotime.Duration) gotime.Duration {
+	// Validate inputs
+	if e.total <= 0 || completed <= 0 || elapsed <= 0 {
+		return etaFallback
+	}
+
+	// Nothing to do
+	if completed >= e.total {
+		return 0
+	}
+
+	deltaUnits := completed - e.lastCompleted
+	deltaTime := elapsed - e.lastElapsed
+
+	if deltaUnits > 0 && deltaTime > 0 {
+		sample := float64(deltaUnits) / float64(deltaTime)
+		if e.rate == 0 {
+			e.rate = sample
+		} else {
+			e.rate = e.smoothing*sample + (1-e.smoothing)*e.rate
+		}
+
+		e.lastCompleted = completed
+		e.lastElapsed = elapsed
+	}
+
+	if e.rate == 0 {
+		return etaFallback
+	}
+
+	remaining := float64(e.total - completed)
+	return gotime.Duration(remaining / e.rate)
+}
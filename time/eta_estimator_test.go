@@ -0,0 +1,76 @@
+package time
+
+import (
+	"testing"
+	gotime "time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEtaEstimator(t *testing.T) {
+	t.Run("first update matches CalculateEta", func(t *testing.T) {
+		estimator := NewEtaEstimator(10, 0.5)
+
+		eta := estimator.Update(3, 30*gotime.Minute)
+		assert.Equal(t, CalculateEta(10, 3, 30*gotime.Minute), eta)
+	})
+
+	t.Run("smooths throughput changes", func(t *testing.T) {
+		estimator := NewEtaEstimator(100, 0.5)
+
+		// Steady at 10 units/s
+		assert.Equal(t, 9*gotime.Second, estimator.Update(10, gotime.Second))
+		assert.Equal(t, 8*gotime.Second, estimator.Update(20, 2*gotime.Second))
+		assert.Equal(t, 7*gotime.Second, estimator.Update(30, 3*gotime.Second))
+
+		// A burst at 50 units/s moves the smoothed rate to 30 units/s, while the linear average is still at 20 units/s
+		eta := estimator.Update(80, 4*gotime.Second)
+		assert.InDelta(t, float64(20*gotime.Second/30), float64(eta), float64(gotime.Microsecond))
+		assert.Equal(t, gotime.Second, CalculateEta(100, 80, 4*gotime.Second))
+	})
+
+	t.Run("updates without progress keep the current rate", func(t *testing.T) {
+		estimator := NewEtaEstimator(100, 0.5)
+
+		assert.Equal(t, 9*gotime.Second, estimator.Update(10, gotime.Second))
+		assert.Equal(t, 9*gotime.Second, estimator.Update(10, 3*gotime.Second))
+		assert.Equal(t, 9*gotime.Second, estimator.Update(10, gotime.Second))
+	})
+
+	t.Run("out of range smoothing uses the default", func(t *testing.T) {
+		for _, smoothing := range []float64{0, -1, 1.5} {
+			estimator := NewEtaEstimator(10, smoothing)
+			assert.Equal(t, defaultEtaSmoothing, estimator.smoothing)
+		}
+	})
+
+	t.Run("already complete", func(t *testing.T) {
+		estimator := NewEtaEstimator(10, 0.3)
+		assert.Equal(t, gotime.Duration(0), estimator.Update(10, gotime.Hour))
+		assert.Equal(t, gotime.Duration(0), estimator.Update(15, gotime.Hour))
+	})
+}
+
+func TestEtaEstimator_InvalidInputs(t *testing.T) {
+	fallbackDuration := gotime.Duration(7 * 24 * gotime.Hour) // 7 days
+
+	t.Run("Zero total", func(t *testing.T) {
+		assert.Equal(t, fallbackDuration, NewEtaEstimator(0, 0.3).Update(5, gotime.Hour))
+	})
+
+	t.Run("Negative total", func(t *testing.T) {
+		assert.Equal(t, fallbackDuration, NewEtaEstimator(-10, 0.3).Update(5, gotime.Hour))
+	})
+
+	t.Run("Zero completed", func(t *testing.T) {
+		assert.Equal(t, fallbackDuration, NewEtaEstimator(10, 0.3).Update(0, gotime.Hour))
+	})
+
+	t.Run("Zero elapsed time", func(t *testing.T) {
+		assert.Equal(t, fallbackDuration, NewEtaEstimator(10, 0.3).Update(5, 0))
+	})
+
+	t.Run("Negative elapsed time", func(t *testing.T) {
+		assert.Equal(t, fallbackDuration, NewEtaEstimator(10, 0.3).Update(5, -gotime.Hour))
+	})
+}
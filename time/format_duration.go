@@ -0,0 +1,92 @@
+package time
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	gotime "time"
+)
+
+// FormatDuration formats a duration in a human-readable way, like "1h 2m 3s", to display ETAs and elapsed times to
+// users. The duration is rounded to the nearest second, and units equal to zero are dropped; durations of a day or more
+// also include the days, like "1d 2h 30m".
+//
+// # Returns:
+//   - The formatted duration, e.g. "1h 2m 3s", "2m 5s" or "7d"
+//   - Returns "0s" for durations that round to zero
+//   - Negative durations are formatted like positive ones, prefixed with "-", e.g. "-1m 30s"
+//
+// # Example:
+//
+//	eta := CalculateEta(10, 3, 30*time.Minute)
+//	fmt.Println(FormatDuration(eta)) // "1h 10m"
+func FormatDuration(d gotime.Duration) string {
+	sign, d := splitSign(d.Round(gotime.Second))
+	if d == 0 {
+		return "0s"
+	}
+
+	units := []struct {
+		suffix string
+		size   gotime.Duration
+	}{
+		{"d", 24 * gotime.Hour},
+		{"h", gotime.Hour},
+		{"m", gotime.Minute},
+		{"s", gotime.Second},
+	}
+
+	parts := make([]string, 0, len(units))
+	for _, unit := range units {
+		if value := d / unit.size; value > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", value, unit.suffix))
+			d -= value * unit.size
+		}
+	}
+
+	return sign + strings.Join(parts, " ")
+}
+
+// FormatDurationShort formats a duration in a compact clock-like way, like "1:02:03", to display ETAs in progress bars.
+// The duration is rounded to the nearest second; the hours are omitted when the duration is shorter than an hour, like
+// "2:03", and they're not wrapped into days, like "168:00:00".
+//
+// # Returns:
+//   - The formatted duration, e.g. "1:02:03" or "2:03"
+//   - Returns "0:00" for durations that round to zero
+//   - Negative durations are formatted like positive ones, prefixed with "-", e.g. "-1:30"
+//
+// # Example:
+//
+//	fmt.Println(FormatDurationShort(3723 * time.Second)) // "1:02:03"
+func FormatDurationShort(d gotime.Duration) string {
+	sign, d := splitSign(d.Round(gotime.Second))
+
+	hours := d / gotime.Hour
+	minutes := (d % gotime.Hour) / gotime.Minute
+	seconds := (d % gotime.Minute) / gotime.Second
+
+	if hours > 0 {
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, hours, minutes, seconds)
+	}
+
+	return fmt.Sprintf("%s%d:%02d", sign, minutes, seconds)
+}
+
+// region - Private functions
+
+// splitSign returns the sign prefix of the duration and its absolute value.
+func splitSign(d gotime.Duration) (string, gotime.Duration) {
+	if d >= 0 {
+		return "", d
+	}
+
+	// The absolute value of the smallest duration overflows, so it's capped to the largest one
+	if d == math.MinInt64 {
+		return "-", math.MaxInt64
+	}
+
+	return "-", -d
+}
+
+// endregion
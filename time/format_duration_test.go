@@ -0,0 +1,57 @@
+package time
+
+import (
+	"math"
+	"testing"
+	gotime "time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration gotime.Duration
+		expected string
+	}{
+		{"hours, minutes and seconds", gotime.Hour + 2*gotime.Minute + 3*gotime.Second, "1h 2m 3s"},
+		{"sub-second noise is rounded", gotime.Hour + 2*gotime.Minute + 3*gotime.Second + 400*gotime.Microsecond, "1h 2m 3s"},
+		{"rounds up to the nearest second", 2*gotime.Minute + 4600*gotime.Millisecond, "2m 5s"},
+		{"zero units are dropped", gotime.Hour + 3*gotime.Second, "1h 3s"},
+		{"seconds only", 45 * gotime.Second, "45s"},
+		{"days", 7 * 24 * gotime.Hour, "7d"},
+		{"days and hours", 26*gotime.Hour + 30*gotime.Minute, "1d 2h 30m"},
+		{"zero", 0, "0s"},
+		{"less than half a second", 400 * gotime.Millisecond, "0s"},
+		{"negative", -(gotime.Minute + 30*gotime.Second), "-1m 30s"},
+		{"smallest duration", math.MinInt64, "-106751d 23h 47m 16s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatDuration(tt.duration))
+		})
+	}
+}
+
+func TestFormatDurationShort(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration gotime.Duration
+		expected string
+	}{
+		{"hours, minutes and seconds", gotime.Hour + 2*gotime.Minute + 3*gotime.Second, "1:02:03"},
+		{"sub-second noise is rounded", 2*gotime.Minute + 3*gotime.Second + 499*gotime.Millisecond, "2:03"},
+		{"less than an hour", 2*gotime.Minute + 3*gotime.Second, "2:03"},
+		{"seconds only", 9 * gotime.Second, "0:09"},
+		{"hours are not wrapped into days", 7 * 24 * gotime.Hour, "168:00:00"},
+		{"zero", 0, "0:00"},
+		{"negative", -(gotime.Minute + 30*gotime.Second), "-1:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatDurationShort(tt.duration))
+		})
+	}
+}
@@ -6,12 +6,34 @@ import (
 	"time"
 )
 
-// NotzTime is a wrapper around time.Time to handle JSON unmarshalling time without time zone.
+const notzLayout = "2006-01-02T15:04:05"
+
+// NotzTimeLayouts are the layouts accepted when unmarshalling a NotzTime, tried in order until one of them parses the
+// timestamp. By default, only "2006-01-02T15:04:05" is accepted; it can be changed to support APIs that use other
+// formats, e.g. "2006-01-02 15:04:05" or "2006-01-02T15:04". It should be set once, before any unmarshalling happens.
+var NotzTimeLayouts = []string{notzLayout}
+
+// NotzTime is a wrapper around time.Time to handle JSON marshalling and unmarshalling time without time zone.
+//
+// It's unmarshalled using the layouts in NotzTimeLayouts, and always marshalled using the "2006-01-02T15:04:05" layout.
+// The zero value is marshalled as null, and null is unmarshalled as the zero value, so it round-trips.
 type NotzTime struct {
 	time.Time
 }
 
+func (t NotzTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.Format(notzLayout))
+}
+
 func (t *NotzTime) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
 	var timestamp string
 	if err := json.Unmarshal(b, &timestamp); err != nil {
 		return err
@@ -21,7 +43,7 @@ func (t *NotzTime) UnmarshalJSON(b []byte) error {
 		return errors.New("invalid timestamp")
 	}
 
-	tt, err := time.Parse("2006-01-02T15:04:05", timestamp)
+	tt, err := parseNotz(timestamp, NotzTimeLayouts)
 	if err != nil {
 		return err
 	}
@@ -29,3 +51,29 @@ func (t *NotzTime) UnmarshalJSON(b []byte) error {
 	t.Time = tt
 	return nil
 }
+
+// region - Private functions
+
+// parseNotz parses the timestamp with the first layout that accepts it. If none do, the error of the first layout is
+// returned.
+func parseNotz(timestamp string, layouts []string) (time.Time, error) {
+	if len(layouts) == 0 {
+		return time.Time{}, errors.New("no time layouts configured")
+	}
+
+	var firstErr error
+	for _, layout := range layouts {
+		tt, err := time.Parse(layout, timestamp)
+		if err == nil {
+			return tt, nil
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return time.Time{}, firstErr
+}
+
+// endregion
@@ -505,3 +505,109 @@ func TestNotzTime_Performance(t *testing.T) {
 		}
 	})
 }
+
+func TestNotzTime_MarshalJSON(t *testing.T) {
+	t.Run("layout without time zone", func(t *testing.T) {
+		nt := NotzTime{time.Date(2023, 12, 15, 14, 30, 45, 0, time.UTC)}
+
+		data, err := json.Marshal(nt)
+
+		require.NoError(t, err)
+		assert.Equal(t, `"2023-12-15T14:30:45"`, string(data))
+	})
+
+	t.Run("formatted in its own location", func(t *testing.T) {
+		nt := NotzTime{time.Date(2023, 12, 15, 14, 30, 45, 0, time.FixedZone("UTC+2", 2*60*60))}
+
+		data, err := json.Marshal(nt)
+
+		require.NoError(t, err)
+		assert.Equal(t, `"2023-12-15T14:30:45"`, string(data))
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		data, err := json.Marshal(NotzTime{})
+
+		require.NoError(t, err)
+		assert.Equal(t, `null`, string(data))
+	})
+
+	t.Run("round trip within struct", func(t *testing.T) {
+		type Event struct {
+			Name      string   `json:"name"`
+			Timestamp NotzTime `json:"timestamp"`
+			Deadline  NotzTime `json:"deadline"`
+		}
+
+		original := Event{Name: "test event", Timestamp: NotzTime{time.Date(2023, 8, 15, 16, 45, 30, 0, time.UTC)}}
+
+		data, err := json.Marshal(original)
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"test event","timestamp":"2023-08-15T16:45:30","deadline":null}`, string(data))
+
+		var decoded Event
+		err = json.Unmarshal(data, &decoded)
+
+		require.NoError(t, err)
+		assert.True(t, original.Timestamp.Equal(decoded.Timestamp.Time))
+		assert.True(t, decoded.Deadline.IsZero())
+	})
+}
+
+func TestNotzTime_Layouts(t *testing.T) {
+	setLayouts := func(t *testing.T, layouts ...string) {
+		original := NotzTimeLayouts
+		NotzTimeLayouts = layouts
+		t.Cleanup(func() { NotzTimeLayouts = original })
+	}
+
+	t.Run("tries each layout in order", func(t *testing.T) {
+		setLayouts(t, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02T15:04")
+
+		tests := []struct {
+			jsonData string
+			expected time.Time
+		}{
+			{`"2023-12-15T14:30:45"`, time.Date(2023, 12, 15, 14, 30, 45, 0, time.UTC)},
+			{`"2023-12-15 14:30:45"`, time.Date(2023, 12, 15, 14, 30, 45, 0, time.UTC)},
+			{`"2023-12-15T14:30"`, time.Date(2023, 12, 15, 14, 30, 0, 0, time.UTC)},
+		}
+
+		for _, tt := range tests {
+			var nt NotzTime
+			err := json.Unmarshal([]byte(tt.jsonData), &nt)
+
+			require.NoError(t, err, tt.jsonData)
+			assert.True(t, nt.Time.Equal(tt.expected), tt.jsonData)
+		}
+	})
+
+	t.Run("fails when no layout matches", func(t *testing.T) {
+		setLayouts(t, "2006-01-02T15:04:05", "2006-01-02 15:04:05")
+
+		var nt NotzTime
+		err := json.Unmarshal([]byte(`"2023-12-15T14:30:45Z"`), &nt)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parsing time")
+	})
+
+	t.Run("marshals with the default layout", func(t *testing.T) {
+		setLayouts(t, "2006-01-02 15:04:05")
+
+		data, err := json.Marshal(NotzTime{time.Date(2023, 12, 15, 14, 30, 45, 0, time.UTC)})
+
+		require.NoError(t, err)
+		assert.Equal(t, `"2023-12-15T14:30:45"`, string(data))
+	})
+
+	t.Run("no layouts", func(t *testing.T) {
+		setLayouts(t)
+
+		var nt NotzTime
+		err := json.Unmarshal([]byte(`"2023-12-15T14:30:45"`), &nt)
+
+		require.Error(t, err)
+		assert.Equal(t, "no time layouts configured", err.Error())
+	})
+}
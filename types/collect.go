@@ -0,0 +1,43 @@
+package types
+
+// Collect turns a slice of Results into a Result of a slice. If every Result was successful, it returns Ok with all the
+// data in the same order; otherwise it returns the first error, ignoring the rest.
+//
+// # Example:
+//
+//	sizes := Collect(results)
+//	if sizes.Err != nil {
+//	    return sizes.Err
+//	}
+func Collect[T any](results []Result[T]) Result[[]T] {
+	values := make([]T, 0, len(results))
+
+	for _, result := range results {
+		if result.Err != nil {
+			return Err[[]T](result.Err)
+		}
+
+		values = append(values, result.Data)
+	}
+
+	return Ok(values)
+}
+
+// Partition splits a slice of Results into the data of the successful ones and the errors of the failed ones, both in
+// the original order. Unlike Collect, it goes through all the Results.
+//
+// # Example:
+//
+//	sizes, errs := Partition(results)
+//	log.Printf("%d succeeded, %d failed", len(sizes), len(errs))
+func Partition[T any](results []Result[T]) (oks []T, errs []error) {
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		} else {
+			oks = append(oks, result.Data)
+		}
+	}
+
+	return oks, errs
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollect(t *testing.T) {
+	t.Run("returns all data when every result succeeded", func(t *testing.T) {
+		result := Collect([]Result[int]{Ok(1), Ok(2), Ok(3)})
+
+		assert.True(t, result.IsSuccess())
+		assert.Equal(t, []int{1, 2, 3}, result.Data)
+	})
+
+	t.Run("returns the first error", func(t *testing.T) {
+		first := errors.New("first")
+		second := errors.New("second")
+
+		result := Collect([]Result[int]{Ok(1), Err[int](first), Ok(3), Err[int](second)})
+
+		assert.Same(t, first, result.Err)
+		assert.Nil(t, result.Data)
+	})
+
+	t.Run("empty slice is a success", func(t *testing.T) {
+		result := Collect([]Result[string]{})
+
+		assert.True(t, result.IsSuccess())
+		assert.Empty(t, result.Data)
+	})
+}
+
+func TestPartition(t *testing.T) {
+	t.Run("splits data and errors in order", func(t *testing.T) {
+		first := errors.New("first")
+		second := errors.New("second")
+
+		oks, errs := Partition([]Result[string]{Ok("a"), Err[string](first), Ok("b"), Err[string](second)})
+
+		assert.Equal(t, []string{"a", "b"}, oks)
+		assert.Equal(t, []error{first, second}, errs)
+	})
+
+	t.Run("all successful", func(t *testing.T) {
+		oks, errs := Partition([]Result[int]{Ok(1), Ok(2)})
+
+		assert.Equal(t, []int{1, 2}, oks)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("all failed", func(t *testing.T) {
+		oks, errs := Partition([]Result[int]{Err[int](errors.New("a")), Err[int](errors.New("b"))})
+
+		assert.Empty(t, oks)
+		assert.Len(t, errs, 2)
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		oks, errs := Partition[int](nil)
+
+		assert.Empty(t, oks)
+		assert.Empty(t, errs)
+	})
+}
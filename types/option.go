@@ -0,0 +1,42 @@
+package types
+
+// Option is a generic struct that represents a value that may or may not be present, without an error; for example, a
+// cache miss. Use Some to create an Option with a value and None to create an empty one. The zero value is None.
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some returns an Option that holds the value v.
+func Some[T any](v T) Option[T] {
+	return Option[T]{value: v, ok: true}
+}
+
+// None returns an Option that holds no value.
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// IsSome returns true if the Option holds a value, false otherwise.
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+// IsNone returns true if the Option holds no value, false otherwise.
+func (o Option[T]) IsNone() bool {
+	return !o.ok
+}
+
+// Get returns the value and true if the Option holds a value, or the zero value of T and false otherwise.
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// OrElse returns the value if the Option holds one, or defaultValue otherwise.
+func (o Option[T]) OrElse(defaultValue T) T {
+	if o.ok {
+		return o.value
+	}
+
+	return defaultValue
+}
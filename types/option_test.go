@@ -0,0 +1,103 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOption_SomeAndNone(t *testing.T) {
+	t.Run("some holds a value", func(t *testing.T) {
+		option := Some("test data")
+
+		assert.True(t, option.IsSome())
+		assert.False(t, option.IsNone())
+
+		value, ok := option.Get()
+		assert.True(t, ok)
+		assert.Equal(t, "test data", value)
+	})
+
+	t.Run("none holds no value", func(t *testing.T) {
+		option := None[string]()
+
+		assert.False(t, option.IsSome())
+		assert.True(t, option.IsNone())
+
+		value, ok := option.Get()
+		assert.False(t, ok)
+		assert.Empty(t, value)
+	})
+
+	t.Run("some with zero value is still present", func(t *testing.T) {
+		option := Some(0)
+
+		assert.True(t, option.IsSome())
+		assert.Equal(t, 0, option.OrElse(42))
+	})
+
+	t.Run("zero value option is none", func(t *testing.T) {
+		var option Option[int]
+
+		assert.True(t, option.IsNone())
+	})
+}
+
+func TestOption_OrElse(t *testing.T) {
+	t.Run("returns the value when present", func(t *testing.T) {
+		assert.Equal(t, "value", Some("value").OrElse("default"))
+	})
+
+	t.Run("returns the default when absent", func(t *testing.T) {
+		assert.Equal(t, "default", None[string]().OrElse("default"))
+	})
+}
+
+func TestOption_WithDifferentTypes(t *testing.T) {
+	t.Run("works with int type", func(t *testing.T) {
+		value, ok := Some(42).Get()
+		assert.True(t, ok)
+		assert.Equal(t, 42, value)
+
+		assert.Equal(t, 7, None[int]().OrElse(7))
+	})
+
+	t.Run("works with pointer type", func(t *testing.T) {
+		s := "pointed"
+		value, ok := Some(&s).Get()
+		assert.True(t, ok)
+		assert.Same(t, &s, value)
+
+		// A nil pointer is a present value, unlike None
+		assert.True(t, Some[*string](nil).IsSome())
+
+		value, ok = None[*string]().Get()
+		assert.False(t, ok)
+		assert.Nil(t, value)
+	})
+
+	t.Run("works with slice type", func(t *testing.T) {
+		value, ok := Some([]string{"apple", "banana", "cherry"}).Get()
+		assert.True(t, ok)
+		assert.Equal(t, 3, len(value))
+		assert.Contains(t, value, "apple")
+
+		assert.Equal(t, []string{"default"}, None[[]string]().OrElse([]string{"default"}))
+	})
+
+	t.Run("works with struct type", func(t *testing.T) {
+		type TestStruct struct {
+			Name string
+			Age  int
+		}
+
+		value, ok := Some(TestStruct{Name: "John", Age: 30}).Get()
+		assert.True(t, ok)
+		assert.Equal(t, "John", value.Name)
+		assert.Equal(t, 30, value.Age)
+
+		value, ok = None[TestStruct]().Get()
+		assert.False(t, ok)
+		assert.Equal(t, TestStruct{}, value)
+	})
+}
@@ -10,7 +10,85 @@ type Result[T any] struct {
 	Err  error
 }
 
+// Ok returns a successful Result that holds the data v.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{Data: v}
+}
+
+// Err returns a failed Result that holds the error e.
+func Err[T any](e error) Result[T] {
+	return Result[T]{Err: e}
+}
+
+// Wrap returns a Result that holds both the data and the error, so functions that return (T, error) can be turned into
+// a Result in a single call.
+//
+// # Example:
+//
+//	func readConfig(path string) Result[[]byte] {
+//	    return Wrap(os.ReadFile(path))
+//	}
+func Wrap[T any](v T, err error) Result[T] {
+	return Result[T]{Data: v, Err: err}
+}
+
+// Must returns the data of a successful Result, or panics with the error otherwise. It's meant for tests and
+// initialization code, where a failure can't be recovered from.
+//
+// # Example:
+//
+//	var config = Must(loadConfig())
+func Must[T any](r Result[T]) T {
+	return r.Unwrap()
+}
+
 // IsSuccess returns true if the operation was successful (no error occurred), false otherwise.
-func (r *Result[T]) IsSuccess() bool {
+func (r Result[T]) IsSuccess() bool {
 	return r.Err == nil
 }
+
+// Unwrap returns the data if the operation was successful; otherwise it panics with the error.
+func (r Result[T]) Unwrap() T {
+	if r.Err != nil {
+		panic(r.Err)
+	}
+
+	return r.Data
+}
+
+// UnwrapOr returns the data if the operation was successful, or defaultValue otherwise.
+func (r Result[T]) UnwrapOr(defaultValue T) T {
+	if r.Err != nil {
+		return defaultValue
+	}
+
+	return r.Data
+}
+
+// Map transforms the data of a successful Result with fn. If the Result has an error, fn isn't called and the error is
+// propagated untouched.
+//
+// # Example:
+//
+//	length := Map(result, func(s string) int { return len(s) })
+func Map[T, R any](r Result[T], fn func(T) R) Result[R] {
+	if r.Err != nil {
+		return Err[R](r.Err)
+	}
+
+	return Ok(fn(r.Data))
+}
+
+// AndThen chains a fallible step after a successful Result, returning the Result of fn. If the Result has an error, fn
+// isn't called and the error is propagated untouched.
+//
+// # Example:
+//
+//	config := AndThen(readFile(path), parseConfig)
+func AndThen[T, R any](r Result[T], fn func(T) Result[R]) Result[R] {
+	if r.Err != nil {
+		return Err[R](r.Err)
+	}
+
+	return fn(r.Data)
+}
@@ -184,3 +184,159 @@ func TestResult_ZeroValues(t *testing.T) {
 		assert.Nil(t, sliceResult.Data)
 	})
 }
+
+func TestResult_Unwrap(t *testing.T) {
+	t.Run("returns data on success", func(t *testing.T) {
+		result := Result[string]{Data: "test data"}
+
+		assert.Equal(t, "test data", result.Unwrap())
+	})
+
+	t.Run("panics with the error on failure", func(t *testing.T) {
+		err := errors.New("operation failed")
+		result := Result[string]{Data: "ignored", Err: err}
+
+		assert.PanicsWithError(t, "operation failed", func() { result.Unwrap() })
+	})
+}
+
+func TestResult_UnwrapOr(t *testing.T) {
+	t.Run("returns data on success", func(t *testing.T) {
+		result := Result[int]{Data: 42}
+
+		assert.Equal(t, 42, result.UnwrapOr(7))
+	})
+
+	t.Run("returns zero value data on success", func(t *testing.T) {
+		result := Result[int]{Data: 0}
+
+		assert.Equal(t, 0, result.UnwrapOr(7))
+	})
+
+	t.Run("returns default on failure", func(t *testing.T) {
+		result := Result[int]{Data: 42, Err: errors.New("failed")}
+
+		assert.Equal(t, 7, result.UnwrapOr(7))
+	})
+}
+
+func TestResult_ChainOnReturnValue(t *testing.T) {
+	t.Run("Unwrap on a constructor result", func(t *testing.T) {
+		assert.Equal(t, 42, Ok(42).Unwrap())
+	})
+
+	t.Run("UnwrapOr on a wrapped result", func(t *testing.T) {
+		assert.Equal(t, 7, Wrap(0, errors.New("failed")).UnwrapOr(7))
+		assert.Equal(t, 42, Wrap(42, nil).UnwrapOr(7))
+	})
+
+	t.Run("IsSuccess on a constructor result and through a pointer", func(t *testing.T) {
+		assert.False(t, Err[int](errors.New("failed")).IsSuccess())
+
+		result := &Result[int]{Data: 1}
+		assert.True(t, result.IsSuccess())
+	})
+}
+
+func TestMap(t *testing.T) {
+	t.Run("transforms data on success", func(t *testing.T) {
+		result := Map(Result[string]{Data: "hello"}, func(s string) int { return len(s) })
+
+		assert.True(t, result.IsSuccess())
+		assert.Equal(t, 5, result.Data)
+	})
+
+	t.Run("propagates the error without calling fn", func(t *testing.T) {
+		customErr := CustomError{Code: 500, Message: "internal"}
+		called := false
+
+		result := Map(Result[string]{Err: customErr}, func(s string) int {
+			called = true
+			return len(s)
+		})
+
+		assert.False(t, called)
+		assert.Equal(t, 0, result.Data)
+		assert.Equal(t, customErr, result.Err)
+	})
+}
+
+func TestAndThen(t *testing.T) {
+	parse := func(s string) Result[int] {
+		if s == "" {
+			return Result[int]{Err: errors.New("empty input")}
+		}
+		return Result[int]{Data: len(s)}
+	}
+
+	t.Run("chains successful steps", func(t *testing.T) {
+		result := AndThen(Result[string]{Data: "hello"}, parse)
+
+		assert.True(t, result.IsSuccess())
+		assert.Equal(t, 5, result.Data)
+	})
+
+	t.Run("returns the error of the chained step", func(t *testing.T) {
+		result := AndThen(Result[string]{Data: ""}, parse)
+
+		assert.EqualError(t, result.Err, "empty input")
+	})
+
+	t.Run("propagates the error without calling fn", func(t *testing.T) {
+		original := errors.New("read failed")
+		called := false
+
+		result := AndThen(Result[string]{Err: original}, func(s string) Result[int] {
+			called = true
+			return parse(s)
+		})
+
+		assert.False(t, called)
+		assert.Same(t, original, result.Err)
+	})
+}
+
+func TestResult_Constructors(t *testing.T) {
+	t.Run("ok holds data and no error", func(t *testing.T) {
+		result := Ok("test data")
+
+		assert.True(t, result.IsSuccess())
+		assert.Equal(t, "test data", result.Data)
+	})
+
+	t.Run("err holds the error and zero value data", func(t *testing.T) {
+		customErr := CustomError{Code: 404, Message: "not found"}
+		result := Err[[]string](customErr)
+
+		assert.False(t, result.IsSuccess())
+		assert.Nil(t, result.Data)
+		assert.Equal(t, customErr, result.Err)
+	})
+
+	t.Run("wrap holds both values", func(t *testing.T) {
+		produce := func(fail bool) (int, error) {
+			if fail {
+				return -1, errors.New("failed")
+			}
+			return 42, nil
+		}
+
+		success := Wrap(produce(false))
+		assert.True(t, success.IsSuccess())
+		assert.Equal(t, 42, success.Data)
+
+		failure := Wrap(produce(true))
+		assert.EqualError(t, failure.Err, "failed")
+		assert.Equal(t, -1, failure.Data)
+	})
+}
+
+func TestMust(t *testing.T) {
+	t.Run("returns data on success", func(t *testing.T) {
+		assert.Equal(t, 42, Must(Ok(42)))
+	})
+
+	t.Run("panics with the error on failure", func(t *testing.T) {
+		assert.PanicsWithError(t, "failed", func() { Must(Err[int](errors.New("failed"))) })
+	})
+}